@@ -10,9 +10,16 @@
 //
 // Global flags:
 //
-//	--fps <rate>    - Set tick rate (default: 60)
-//	--seed <value>  - Set RNG seed for reproducible gameplay
-//	--db <path>     - Set database path (default: ~/.arcade/scores.db)
+//	--fps <rate>        - Set simulation tick rate (default: 60)
+//	--render-fps <rate> - Set maximum frames drawn per second (default: 60)
+//	--seed <value>      - Set RNG seed for reproducible gameplay
+//	--db <path>         - Set database path (default: ~/.arcade/scores.db)
+//	--renderer <r>      - Frame renderer: standard or diff (default: standard)
+//	--assets <dir>      - Asset pack directory (default: ~/.arcade/assets)
+//	--screenshot-format - Ctrl+S format: ansi, html, svg or text (default: from Settings)
+//
+// Saved settings in ~/.arcade/settings.yaml provide the defaults of --fps,
+// --render-fps, --db, --difficulty and --config; flags given override them.
 package main
 
 import (
@@ -21,6 +28,10 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/vovakirdan/tui-arcade/internal/config"
+	"github.com/vovakirdan/tui-arcade/internal/platform/tui"
+	"github.com/vovakirdan/tui-arcade/internal/screenshot"
+
 	// Import games to register them
 	_ "github.com/vovakirdan/tui-arcade/internal/games/breakout"
 	_ "github.com/vovakirdan/tui-arcade/internal/games/dino"
@@ -32,9 +43,13 @@ import (
 
 var (
 	// Global flags
-	flagFPS    int
-	flagSeed   int64
-	flagDBPath string
+	flagFPS       int
+	flagRenderFPS int
+	flagSeed      int64
+	flagDBPath    string
+	flagRenderer  string
+	flagAssets    string
+	flagShotFmt   string
 )
 
 func main() {
@@ -63,13 +78,48 @@ Examples:
   arcade menu
   arcade serve --ssh :2222
   arcade scores flappy`,
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		config.SetAssetsDir(flagAssets)
+		loadSettings(cmd)
+
+		// A broken keys.yaml shouldn't stop anyone from playing with the defaults
+		keys, err := tui.LoadKeyBindings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		tui.SetKeyBindings(keys)
+
+		if cmd.Flags().Changed("screenshot-format") {
+			format, err := screenshot.ParseFormat(flagShotFmt)
+			if err != nil {
+				return err
+			}
+			tui.SetScreenshotFormat(format)
+		}
+
+		switch flagRenderer {
+		case tui.RendererStandard, tui.RendererDiff:
+			tui.SetRenderer(flagRenderer)
+			return nil
+		default:
+			return fmt.Errorf("unknown renderer %q (use %s or %s)", flagRenderer, tui.RendererStandard, tui.RendererDiff)
+		}
+	},
 }
 
 func init() {
 	// Global persistent flags
-	rootCmd.PersistentFlags().IntVar(&flagFPS, "fps", 60, "Tick rate (frames per second)")
+	rootCmd.PersistentFlags().IntVar(&flagFPS, "fps", 60, "Simulation tick rate (ticks per second)")
+	rootCmd.PersistentFlags().IntVar(&flagRenderFPS, "render-fps", tui.DefaultRenderFPS,
+		"Maximum frames drawn per second; drawing slows down further when frames are expensive")
 	rootCmd.PersistentFlags().Int64Var(&flagSeed, "seed", 0, "RNG seed (0 = random based on time)")
 	rootCmd.PersistentFlags().StringVar(&flagDBPath, "db", "~/.arcade/scores.db", "Path to scores database")
+	rootCmd.PersistentFlags().StringVar(&flagRenderer, "renderer", tui.RendererStandard,
+		"Frame renderer: standard, or diff to send only changed cells (less bandwidth over SSH)")
+	rootCmd.PersistentFlags().StringVar(&flagAssets, "assets", "",
+		"Asset pack directory with sprite overrides (default ~/.arcade/assets)")
+	rootCmd.PersistentFlags().StringVar(&flagShotFmt, "screenshot-format", "",
+		"Screenshot format for Ctrl+S: ansi, html, svg or text (default: the one picked in Settings)")
 
 	// Add subcommands
 	rootCmd.AddCommand(listCmd)
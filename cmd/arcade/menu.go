@@ -12,10 +12,11 @@ import (
 	"github.com/vovakirdan/tui-arcade/internal/games/breakout"
 	"github.com/vovakirdan/tui-arcade/internal/games/dino"
 	"github.com/vovakirdan/tui-arcade/internal/games/flappy"
+	"github.com/vovakirdan/tui-arcade/internal/games/pong"
 	"github.com/vovakirdan/tui-arcade/internal/games/snake"
 	"github.com/vovakirdan/tui-arcade/internal/games/t2048"
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
 	"github.com/vovakirdan/tui-arcade/internal/platform/tui"
-	"github.com/vovakirdan/tui-arcade/internal/registry"
 	"github.com/vovakirdan/tui-arcade/internal/storage"
 )
 
@@ -43,13 +44,14 @@ func init() {
 	// Uses global flags from main.go (--fps, --seed, --db)
 }
 
-func runMenu(_ *cobra.Command, _ []string) {
+func runMenu(cmd *cobra.Command, _ []string) {
 	// Open score storage
 	store, err := storage.Open(flagDBPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: could not open scores database: %v\n", err)
 		store = nil
 	}
+	tui.SetTheme(tui.LoadUserTheme(store, tui.LocalUsername(), false))
 
 	// Get terminal size
 	width, height := 80, 24
@@ -60,10 +62,11 @@ func runMenu(_ *cobra.Command, _ []string) {
 
 	// Create runtime config
 	cfg := core.RuntimeConfig{
-		ScreenW:  width,
-		ScreenH:  height,
-		TickRate: flagFPS,
-		Seed:     flagSeed,
+		ScreenW:   width,
+		ScreenH:   height,
+		TickRate:  flagFPS,
+		RenderFPS: flagRenderFPS,
+		Seed:      flagSeed,
 	}
 
 	// Menu loop
@@ -95,22 +98,37 @@ func runMenu(_ *cobra.Command, _ []string) {
 			break // User quit from scoreboard
 		}
 
+		// Check if user wants settings
+		if menuResult.WantsSettings {
+			goBack, settingsErr := tui.RunSettings(store, cfg.ScreenW, cfg.ScreenH)
+			if settingsErr != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", settingsErr)
+			}
+
+			// Saved rates apply from the next game, unless flags override them
+			loadSettings(cmd)
+			cfg.TickRate, cfg.RenderFPS = flagFPS, flagRenderFPS
+			if goBack {
+				continue // Back to menu
+			}
+			break // User quit from settings
+		}
+
 		gameID := menuResult.GameID
 		if gameID == "" {
 			break
 		}
 
-		// Set config path and difficulty for games before creation
+		// Set config path for games before creation
+		mode := multiplayer.MatchModeSolo
+		personality := "" // Pong CPU personality chosen in the opponent selector
 		switch gameID {
 		case "flappy":
-			flappy.SetConfigPath(flagConfig)
-			flappy.SetDifficultyPreset(flagDifficulty)
+			flappy.SetConfigPath(gameConfig(gameID))
 		case "dino":
-			dino.SetConfigPath(flagConfig)
-			dino.SetDifficultyPreset(flagDifficulty)
+			dino.SetConfigPath(gameConfig(gameID))
 		case "breakout":
-			breakout.SetConfigPath(flagConfig)
-			breakout.SetDifficultyPreset(flagDifficulty)
+			breakout.SetConfigPath(gameConfig(gameID))
 
 			// Show Breakout mode/level selector
 			selection, updatedCfg2, breakoutErr := tui.RunBreakoutModeSelector(cfg)
@@ -134,8 +152,7 @@ func runMenu(_ *cobra.Command, _ []string) {
 			}
 
 		case "snake":
-			snake.SetConfigPath(flagConfig)
-			snake.SetDifficultyPreset(flagDifficulty)
+			snake.SetConfigPath(gameConfig(gameID))
 
 			// Show Snake mode/level selector
 			snakeSelection, updatedCfg3, snakeErr := tui.RunSnakeModeSelector(cfg)
@@ -158,6 +175,26 @@ func runMenu(_ *cobra.Command, _ []string) {
 				snake.SetStartLevel(snakeSelection.Level)
 			}
 
+		case "pong":
+			pong.SetConfigPath(gameConfig(gameID))
+
+			// Show Pong opponent selector
+			pongSelection, updatedCfg5, pongErr := tui.RunPongModeSelector(cfg)
+			if pongErr != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", pongErr)
+				continue
+			}
+			cfg = updatedCfg5
+
+			// User pressed back or quit
+			if pongSelection == nil {
+				continue
+			}
+
+			// Apply selection
+			personality = pongSelection.Personality
+			mode = pongSelection.Mode
+
 		case "2048":
 			// Show 2048 mode/level selector
 			t2048Selection, updatedCfg4, t2048Err := tui.RunT2048ModeSelector(cfg)
@@ -181,12 +218,31 @@ func runMenu(_ *cobra.Command, _ []string) {
 			}
 		}
 
+		// Runner games can be raced in split screen
+		if tui.IsRaceGame(gameID) {
+			runnerSelection, updatedCfg6, runnerErr := tui.RunRunnerModeSelector(gameID, cfg)
+			if runnerErr != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", runnerErr)
+				continue
+			}
+			cfg = updatedCfg6
+
+			// User pressed back or quit
+			if runnerSelection == nil {
+				continue
+			}
+			mode = runnerSelection.Mode
+		}
+
 		// Create game instance
-		game, err := registry.Create(gameID)
+		game, err := tui.NewLocalGame(gameID, mode, gameDifficulty(gameID))
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating game: %v\n", err)
 			continue
 		}
+		if p, ok := game.(*pong.Game); ok {
+			p.SetPersonality(personality)
+		}
 
 		// Update seed for each game
 		cfg.Seed = time.Now().UnixNano()
@@ -11,8 +11,10 @@ import (
 	"github.com/vovakirdan/tui-arcade/internal/games/breakout"
 	"github.com/vovakirdan/tui-arcade/internal/games/dino"
 	"github.com/vovakirdan/tui-arcade/internal/games/flappy"
+	"github.com/vovakirdan/tui-arcade/internal/games/pong"
 	"github.com/vovakirdan/tui-arcade/internal/games/snake"
 	"github.com/vovakirdan/tui-arcade/internal/games/t2048"
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
 	"github.com/vovakirdan/tui-arcade/internal/platform/tui"
 	"github.com/vovakirdan/tui-arcade/internal/registry"
 	"github.com/vovakirdan/tui-arcade/internal/storage"
@@ -21,6 +23,7 @@ import (
 var (
 	flagConfig     string
 	flagDifficulty string
+	flagRecord     string
 )
 
 var playCmd = &cobra.Command{
@@ -32,8 +35,12 @@ Controls:
   Space/Up   - Jump/Flap
   P/Esc      - Pause
   R          - Restart (after game over)
+  Ctrl+S     - Save a screenshot
+  Ctrl+R     - Start/stop recording (~/.arcade/recordings)
   Q/Ctrl+C   - Quit
 
+Keys can be rebound in Settings -> Controls or ~/.arcade/keys.yaml.
+
 Difficulty options:
   easy   - Start at lowest difficulty, progresses to max
   normal - Start at 30% difficulty, progresses to max
@@ -45,7 +52,8 @@ Examples:
   arcade play dino --difficulty easy
   arcade play flappy --difficulty hard
   arcade play dino --difficulty fixed
-  arcade play flappy --config ./my-flappy.yaml`,
+  arcade play flappy --config ./my-flappy.yaml
+  arcade play snake --record snake.cast`,
 	Args: cobra.ExactArgs(1),
 	Run:  runPlay,
 }
@@ -53,6 +61,7 @@ Examples:
 func init() {
 	playCmd.Flags().StringVar(&flagConfig, "config", "", "Path to custom game config YAML")
 	playCmd.Flags().StringVar(&flagDifficulty, "difficulty", "", "Difficulty preset: easy, normal, hard, fixed")
+	playCmd.Flags().StringVar(&flagRecord, "record", "", "Record the game to an asciicast file (play it with asciinema)")
 }
 
 func runPlay(cmd *cobra.Command, args []string) {
@@ -74,23 +83,23 @@ func runPlay(cmd *cobra.Command, args []string) {
 
 	// Create runtime config
 	cfg := core.RuntimeConfig{
-		ScreenW:  width,
-		ScreenH:  height,
-		TickRate: flagFPS,
-		Seed:     flagSeed,
+		ScreenW:   width,
+		ScreenH:   height,
+		TickRate:  flagFPS,
+		RenderFPS: flagRenderFPS,
+		Seed:      flagSeed,
 	}
 
-	// Set config path and difficulty for games before creation
+	// Set config path for games before creation
+	mode := multiplayer.MatchModeSolo
+	personality := "" // Pong CPU personality chosen in the opponent selector
 	switch gameID {
 	case "flappy":
-		flappy.SetConfigPath(flagConfig)
-		flappy.SetDifficultyPreset(flagDifficulty)
+		flappy.SetConfigPath(gameConfig(gameID))
 	case "dino":
-		dino.SetConfigPath(flagConfig)
-		dino.SetDifficultyPreset(flagDifficulty)
+		dino.SetConfigPath(gameConfig(gameID))
 	case "breakout":
-		breakout.SetConfigPath(flagConfig)
-		breakout.SetDifficultyPreset(flagDifficulty)
+		breakout.SetConfigPath(gameConfig(gameID))
 
 		// Show Breakout mode/level selector
 		selection, updatedCfg, selErr := tui.RunBreakoutModeSelector(cfg)
@@ -114,8 +123,7 @@ func runPlay(cmd *cobra.Command, args []string) {
 		}
 
 	case "snake":
-		snake.SetConfigPath(flagConfig)
-		snake.SetDifficultyPreset(flagDifficulty)
+		snake.SetConfigPath(gameConfig(gameID))
 
 		// Show Snake mode/level selector
 		snakeSelection, updatedCfg, snakeErr := tui.RunSnakeModeSelector(cfg)
@@ -138,6 +146,26 @@ func runPlay(cmd *cobra.Command, args []string) {
 			snake.SetStartLevel(snakeSelection.Level)
 		}
 
+	case "pong":
+		pong.SetConfigPath(gameConfig(gameID))
+
+		// Show Pong opponent selector
+		pongSelection, updatedCfg, pongErr := tui.RunPongModeSelector(cfg)
+		if pongErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", pongErr)
+			os.Exit(1)
+		}
+		cfg = updatedCfg
+
+		// User pressed back or quit
+		if pongSelection == nil {
+			return
+		}
+
+		// Apply selection
+		personality = pongSelection.Personality
+		mode = pongSelection.Mode
+
 	case "2048":
 		// Show 2048 mode/level selector
 		t2048Selection, updatedCfg, t2048Err := tui.RunT2048ModeSelector(cfg)
@@ -161,12 +189,31 @@ func runPlay(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// Runner games can be raced in split screen
+	if tui.IsRaceGame(gameID) {
+		runnerSelection, updatedCfg, runnerErr := tui.RunRunnerModeSelector(gameID, cfg)
+		if runnerErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", runnerErr)
+			os.Exit(1)
+		}
+		cfg = updatedCfg
+
+		// User pressed back or quit
+		if runnerSelection == nil {
+			return
+		}
+		mode = runnerSelection.Mode
+	}
+
 	// Create game instance
-	game, err := registry.Create(gameID)
+	game, err := tui.NewLocalGame(gameID, mode, gameDifficulty(gameID))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating game: %v\n", err)
 		os.Exit(1)
 	}
+	if p, ok := game.(*pong.Game); ok {
+		p.SetPersonality(personality)
+	}
 
 	// Open score storage
 	store, err := storage.Open(flagDBPath)
@@ -175,8 +222,10 @@ func runPlay(cmd *cobra.Command, args []string) {
 		// Continue without storage - game still works
 		store = nil
 	}
+	tui.SetTheme(tui.LoadUserTheme(store, tui.LocalUsername(), false))
 
 	// Run the game
+	tui.SetRecordPath(flagRecord)
 	runErr := tui.Run(game, store, cfg)
 
 	// Close store before potential exit
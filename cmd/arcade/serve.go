@@ -15,6 +15,15 @@ var (
 	flagHostKey     string
 	flagSSHDBPath   string
 	flagIdleTimeout int
+	flagMetricsAddr string
+	flagAdminKeys   string
+	flagAuthKeys    string
+	flagBanList     string
+	flagMaxSessions int
+	flagMaxPerIP    int
+	flagRateLimit   int
+	flagRecordings  int
+	flagChatFilter  string
 )
 
 var serveCmd = &cobra.Command{
@@ -34,6 +43,20 @@ Examples:
   arcade serve --ssh :2222               # Listen on port 2222
   arcade serve --host-key ./my_host_key  # Use specific host key
   arcade serve --db ./scores.db          # Use specific database
+  arcade serve --metrics 127.0.0.1:9100  # Expose /metrics and /healthz over HTTP
+  arcade serve --admin-keys ./admins.pub # Give these keys the Admin console
+  arcade serve --authorized-keys ./players.pub --max-sessions 50 --max-per-ip 3
+  arcade serve --renderer diff           # Send only changed cells to clients
+  arcade serve --render-fps 30           # Draw at most 30 frames per second
+  arcade serve --max-recordings 0        # Don't let players record games
+  arcade serve --chat-filter ./words.txt # Mask the listed words in chat
+
+Access control:
+  - --authorized-keys restricts access to the listed public keys (admins always allowed)
+  - --ban-list holds banned key fingerprints (SHA256:...), IPs and CIDR ranges,
+    one per line; the admin console appends to it (default ~/.arcade/bans.txt)
+  - --max-sessions and --max-per-ip cap concurrent sessions (0 = unlimited)
+  - --rate-limit caps new connections per IP per minute (0 = unlimited)
 
 Users can connect with:
   ssh localhost -p 23234`,
@@ -45,14 +68,36 @@ func init() {
 	serveCmd.Flags().StringVar(&flagHostKey, "host-key", "", "Path to host key file (auto-generated if not specified)")
 	serveCmd.Flags().StringVar(&flagSSHDBPath, "db", "~/.arcade/scores.db", "Path to scores database")
 	serveCmd.Flags().IntVar(&flagIdleTimeout, "idle-timeout", 30, "Idle timeout in minutes before disconnecting")
+	serveCmd.Flags().StringVar(&flagMetricsAddr, "metrics", "", "HTTP address for Prometheus /metrics and /healthz (disabled if empty)")
+	serveCmd.Flags().StringVar(&flagAdminKeys, "admin-keys", "", "authorized_keys file of admins who get the Admin console")
+	serveCmd.Flags().StringVar(&flagAuthKeys, "authorized-keys", "", "authorized_keys file of players allowed to connect (anyone if empty)")
+	serveCmd.Flags().StringVar(&flagBanList, "ban-list", "", "Ban list file (default ~/.arcade/bans.txt)")
+	serveCmd.Flags().IntVar(&flagMaxSessions, "max-sessions", 0, "Maximum concurrent sessions (0 = unlimited)")
+	serveCmd.Flags().IntVar(&flagMaxPerIP, "max-per-ip", 0, "Maximum concurrent sessions per IP address (0 = unlimited)")
+	serveCmd.Flags().IntVar(&flagRateLimit, "rate-limit", 0, "Maximum new connections per IP per minute (0 = unlimited)")
+	serveCmd.Flags().IntVar(&flagRecordings, "max-recordings", tui.DefaultMaxRecordings,
+		"Game recordings kept per player (0 disables recording)")
+	serveCmd.Flags().StringVar(&flagChatFilter, "chat-filter", "", "File of words masked in chat, one per line")
 }
 
 func runServe(_ *cobra.Command, _ []string) {
 	cfg := tui.SSHServerConfig{
-		Address:     flagSSHAddr,
-		HostKeyPath: flagHostKey,
-		DBPath:      flagSSHDBPath,
-		IdleTimeout: time.Duration(flagIdleTimeout) * time.Minute,
+		Address:        flagSSHAddr,
+		HostKeyPath:    flagHostKey,
+		DBPath:         flagSSHDBPath,
+		IdleTimeout:    time.Duration(flagIdleTimeout) * time.Minute,
+		MetricsAddress: flagMetricsAddr,
+		AdminKeysPath:  flagAdminKeys,
+
+		AuthorizedKeysPath:   flagAuthKeys,
+		BanListPath:          flagBanList,
+		MaxSessions:          flagMaxSessions,
+		MaxSessionsPerIP:     flagMaxPerIP,
+		ConnectionsPerMinute: flagRateLimit,
+		Renderer:             flagRenderer,
+		RenderFPS:            flagRenderFPS,
+		MaxRecordings:        flagRecordings,
+		ChatFilterPath:       flagChatFilter,
 	}
 
 	server, err := tui.NewSSHServer(cfg)
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vovakirdan/tui-arcade/internal/config"
+)
+
+// settings are the local player's saved settings from ~/.arcade/settings.yaml.
+var settings config.Settings
+
+// loadSettings reads ~/.arcade/settings.yaml and uses its values for the
+// flags that weren't given on the command line. A broken file is reported
+// and ignored.
+func loadSettings(cmd *cobra.Command) {
+	s, err := config.LoadUserSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	settings = s
+
+	flags := cmd.Flags()
+	if !flags.Changed("fps") && s.FPS > 0 {
+		flagFPS = s.FPS
+	}
+	if !flags.Changed("render-fps") && s.RenderFPS > 0 {
+		flagRenderFPS = s.RenderFPS
+	}
+	if !flags.Changed("db") && s.DB != "" {
+		flagDBPath = s.DB
+	}
+}
+
+// gameDifficulty returns the difficulty preset a game starts with:
+// --difficulty, else the saved default for the game.
+func gameDifficulty(gameID string) string {
+	if flagDifficulty != "" {
+		return flagDifficulty
+	}
+	return settings.GameDifficulty(gameID)
+}
+
+// gameConfig returns the custom config of a game: --config, else the saved
+// one for the game.
+func gameConfig(gameID string) string {
+	if flagConfig != "" {
+		return flagConfig
+	}
+	return settings.GameConfig(gameID)
+}
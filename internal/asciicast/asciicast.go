@@ -0,0 +1,140 @@
+// Package asciicast writes terminal recordings in the asciicast v2 format
+// played by asciinema (https://docs.asciinema.org/manual/asciicast/v2/).
+//
+// A recording is a JSON header line followed by one JSON array per event:
+// the seconds since the start, the event type and its data.
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the asciicast format version written.
+const Version = 2
+
+// Event types.
+const (
+	EventOutput = "o" // Data written to the terminal
+	EventResize = "r" // Terminal resized, data is "COLSxROWS"
+)
+
+// Header describes a recording.
+type Header struct {
+	Width     int               // Terminal columns
+	Height    int               // Terminal rows
+	Timestamp time.Time         // Start of the recording; events are timed from it
+	Title     string            // Optional title shown by players
+	Env       map[string]string // Optional environment, usually TERM and SHELL
+}
+
+// headerJSON is the header line as written.
+type headerJSON struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Writer writes a recording event by event.
+// After the first error every method returns it without writing.
+type Writer struct {
+	w     io.Writer
+	start time.Time
+	last  time.Duration // Time of the last event; later events never go back in time
+	drawn bool          // A frame has been written
+	err   error
+}
+
+// NewWriter writes the header and returns a writer for the events.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	header := headerJSON{
+		Version: Version,
+		Width:   h.Width,
+		Height:  h.Height,
+		Title:   h.Title,
+		Env:     h.Env,
+	}
+	if !h.Timestamp.IsZero() {
+		header.Timestamp = h.Timestamp.Unix()
+	}
+
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, start: h.Timestamp}, nil
+}
+
+// Output records data written to the terminal at t.
+func (w *Writer) Output(t time.Time, data string) error {
+	return w.event(t, EventOutput, data)
+}
+
+// Resize records the terminal being resized at t.
+func (w *Writer) Resize(t time.Time, width, height int) error {
+	return w.event(t, EventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Frame records a full-screen frame at t: the cursor moves home and the
+// frame's lines are drawn over the previous frame. The first frame clears
+// the screen and hides the cursor. Lines must cover the full width, as
+// lines rendered from a screen buffer do.
+func (w *Writer) Frame(t time.Time, frame string) error {
+	var b strings.Builder
+	if !w.drawn {
+		b.WriteString("\x1b[?25l\x1b[2J")
+	}
+	b.WriteString("\x1b[H")
+	b.WriteString(strings.ReplaceAll(frame, "\n", "\r\n"))
+	if err := w.Output(t, b.String()); err != nil {
+		return err
+	}
+	w.drawn = true
+	return nil
+}
+
+// Elapsed returns the time of the last event since the start.
+func (w *Writer) Elapsed() time.Duration {
+	return w.last
+}
+
+// event writes one event line.
+func (w *Writer) event(t time.Time, kind, data string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	elapsed := max(t.Sub(w.start), w.last)
+	w.last = elapsed
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		w.err = err
+		return err
+	}
+
+	line := make([]byte, 0, len(encoded)+24)
+	line = append(line, '[')
+	line = strconv.AppendFloat(line, elapsed.Seconds(), 'f', 6, 64)
+	line = append(line, `, "`...)
+	line = append(line, kind...)
+	line = append(line, `", `...)
+	line = append(line, encoded...)
+	line = append(line, "]\n"...)
+
+	if _, err := w.w.Write(line); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
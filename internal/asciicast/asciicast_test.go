@@ -0,0 +1,126 @@
+package asciicast
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseLines decodes every line of a recording.
+func parseLines(t *testing.T, data string) (map[string]any, [][]any) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(data, "\n"), "\n")
+
+	var header map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("header %q is not JSON: %v", lines[0], err)
+	}
+	events := make([][]any, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		var event []any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("event %q is not JSON: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return header, events
+}
+
+func TestWriterHeader(t *testing.T) {
+	var buf bytes.Buffer
+	start := time.Unix(1700000000, 0)
+	_, err := NewWriter(&buf, Header{
+		Width:     80,
+		Height:    24,
+		Timestamp: start,
+		Title:     "Snake",
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	header, events := parseLines(t, buf.String())
+	expected := map[string]any{
+		"version":   float64(2),
+		"width":     float64(80),
+		"height":    float64(24),
+		"timestamp": float64(1700000000),
+		"title":     "Snake",
+	}
+	for key, value := range expected {
+		if header[key] != value {
+			t.Errorf("header %s = %v, expected %v", key, header[key], value)
+		}
+	}
+	if env, _ := header["env"].(map[string]any); env["TERM"] != "xterm-256color" {
+		t.Errorf("header env = %v, expected TERM", header["env"])
+	}
+	if len(events) != 0 {
+		t.Errorf("got %d events, expected none", len(events))
+	}
+}
+
+func TestWriterEvents(t *testing.T) {
+	var buf bytes.Buffer
+	start := time.Unix(1700000000, 0)
+	w, err := NewWriter(&buf, Header{Width: 4, Height: 2, Timestamp: start})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	w.Frame(start.Add(250*time.Millisecond), "ab  \ncd  ")
+	w.Resize(start.Add(time.Second), 10, 5)
+	w.Frame(start.Add(1500*time.Millisecond), "x\x1b[31m\"y\"")
+	w.Output(start.Add(time.Second), "late") // Earlier than the last event
+
+	_, events := parseLines(t, buf.String())
+	expected := [][]any{
+		{0.25, "o", "\x1b[?25l\x1b[2J\x1b[Hab  \r\ncd  "},
+		{1.0, "r", "10x5"},
+		{1.5, "o", "\x1b[Hx\x1b[31m\"y\""},
+		{1.5, "o", "late"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("got %d events, expected %d:\n%s", len(events), len(expected), buf.String())
+	}
+	for i, event := range events {
+		for j := range event {
+			if event[j] != expected[i][j] {
+				t.Errorf("event %d = %v, expected %v", i, event, expected[i])
+				break
+			}
+		}
+	}
+	if w.Elapsed() != 1500*time.Millisecond {
+		t.Errorf("Elapsed() = %v, expected 1.5s", w.Elapsed())
+	}
+}
+
+// failingWriter accepts the header and fails afterwards.
+type failingWriter struct{ writes int }
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	f.writes++
+	if f.writes > 1 {
+		return 0, errors.New("disk full")
+	}
+	return len(p), nil
+}
+
+func TestWriterKeepsFirstError(t *testing.T) {
+	out := &failingWriter{}
+	w, err := NewWriter(out, Header{Width: 1, Height: 1})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	if err := w.Output(time.Now(), "a"); err == nil {
+		t.Fatal("Output succeeded on a failing writer")
+	}
+	if err := w.Output(time.Now(), "b"); err == nil || out.writes != 2 {
+		t.Errorf("second Output = %v after %d writes, expected the first error without writing", err, out.writes)
+	}
+}
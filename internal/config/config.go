@@ -2,6 +2,8 @@
 // difficulty management for the arcade platform.
 package config
 
+import "slices"
+
 // FlappyConfig contains all configuration for the Flappy Bird game.
 type FlappyConfig struct {
 	Physics    FlappyPhysics    `yaml:"physics"`
@@ -122,8 +124,9 @@ type PongGameplay struct {
 
 // PongCPU defines CPU opponent parameters for Pong.
 type PongCPU struct {
-	MinSkill float64 `yaml:"min_skill"` // Starting CPU skill (0-1)
-	MaxSkill float64 `yaml:"max_skill"` // Maximum CPU skill (0-1)
+	MinSkill    float64 `yaml:"min_skill"`   // Starting CPU skill (0-1)
+	MaxSkill    float64 `yaml:"max_skill"`   // Maximum CPU skill (0-1)
+	Personality string  `yaml:"personality"` // CPU play style: "rookie", "pro", or "wall"
 }
 
 // BreakoutConfig contains all configuration for the Breakout game.
@@ -185,6 +188,18 @@ const (
 	DifficultyFixed  DifficultyPreset = "fixed"
 )
 
+// DifficultyPresets are the named difficulty levels, easiest first.
+var DifficultyPresets = []DifficultyPreset{DifficultyEasy, DifficultyNormal, DifficultyHard, DifficultyFixed}
+
+// ParseDifficultyPreset returns the preset named name, or empty, meaning
+// the game's config, for an empty or unknown name.
+func ParseDifficultyPreset(name string) DifficultyPreset {
+	if slices.Contains(DifficultyPresets, DifficultyPreset(name)) {
+		return DifficultyPreset(name)
+	}
+	return ""
+}
+
 // InitialLevelForPreset returns the initial_level for a difficulty preset.
 func InitialLevelForPreset(preset DifficultyPreset) float64 {
 	switch preset {
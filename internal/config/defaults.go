@@ -115,8 +115,9 @@ func DefaultPongConfig() PongConfig {
 			ServeDelay: 60,
 		},
 		CPU: PongCPU{
-			MinSkill: 0.6,
-			MaxSkill: 0.85,
+			MinSkill:    0.6,
+			MaxSkill:    0.85,
+			Personality: "pro",
 		},
 		Difficulty: DifficultyConfig{
 			Enabled:      true,
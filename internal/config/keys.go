@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed defaults/keys.yaml
+var defaultKeysYAML []byte
+
+// KeysFile is the YAML form of key bindings. Each section maps action names
+// to the keys that trigger them; Games holds per-game overrides of Game.
+// Player1 and Player2 split the keyboard when two players share it.
+// Mouse switches mouse control on or off per game.
+type KeysFile struct {
+	Game    map[string][]string            `yaml:"game,omitempty"`
+	Menu    map[string][]string            `yaml:"menu,omitempty"`
+	Games   map[string]map[string][]string `yaml:"games,omitempty"`
+	Player1 map[string][]string            `yaml:"player1,omitempty"`
+	Player2 map[string][]string            `yaml:"player2,omitempty"`
+	Mouse   map[string]bool                `yaml:"mouse,omitempty"`
+}
+
+// ParseKeys parses YAML key bindings. Unknown sections are rejected so a
+// typo doesn't silently leave the defaults in place; action names are
+// checked by the caller, which knows them.
+func ParseKeys(data []byte) (KeysFile, error) {
+	var file KeysFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return KeysFile{}, err
+	}
+	return file, nil
+}
+
+// DefaultKeys returns the built-in key bindings.
+// It panics if they are invalid, which is a programming error.
+func DefaultKeys() KeysFile {
+	file, err := ParseKeys(defaultKeysYAML)
+	if err != nil {
+		panic(fmt.Errorf("built-in key bindings: %w", err))
+	}
+	return file
+}
+
+// UserKeysPath returns ~/.arcade/keys.yaml, or empty if home is unavailable.
+func UserKeysPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".arcade", "keys.yaml")
+}
+
+// LoadUserKeys reads ~/.arcade/keys.yaml. A missing file is not an error and
+// returns nil.
+func LoadUserKeys() (*KeysFile, error) {
+	path := UserKeysPath()
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // Reading the user's own key bindings
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	file, err := ParseKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("key bindings %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// Marshal returns the YAML form of the key bindings.
+func (f KeysFile) Marshal() ([]byte, error) {
+	return yaml.Marshal(f)
+}
+
+// SaveUserKeys writes key bindings to ~/.arcade/keys.yaml.
+func SaveUserKeys(file KeysFile) error {
+	path := UserKeysPath()
+	if path == "" {
+		return errors.New("home directory is unavailable")
+	}
+	data, err := file.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
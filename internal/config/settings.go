@@ -0,0 +1,157 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vovakirdan/tui-arcade/internal/screenshot"
+)
+
+// MaxNicknameLen is the longest nickname, in characters.
+const MaxNicknameLen = 16
+
+// Settings are a player's saved preferences. Locally they are kept in
+// ~/.arcade/settings.yaml and command-line flags override them; SSH players
+// keep them in their profile on the server. Zero values leave the built-in
+// defaults in place. Key bindings live next to them in keys.yaml.
+type Settings struct {
+	Difficulty string                  `yaml:"difficulty,omitempty"` // Default difficulty preset
+	FPS        int                     `yaml:"fps,omitempty"`        // Simulation tick rate
+	RenderFPS  int                     `yaml:"render_fps,omitempty"` // Maximum frames drawn per second
+	Theme      string                  `yaml:"theme,omitempty"`      // Color theme name
+	Nickname   string                  `yaml:"nickname,omitempty"`   // Name shown to other players
+	Attract    int                     `yaml:"attract,omitempty"`    // Seconds idle in the menu before demos play; -1 turns them off
+	Screenshot string                  `yaml:"screenshot,omitempty"` // Screenshot format: ansi, html, svg or text
+	DB         string                  `yaml:"db,omitempty"`         // Scores database path
+	Games      map[string]GameSettings `yaml:"games,omitempty"`      // Per-game defaults, by game ID
+}
+
+// GameSettings are the defaults of one game, on top of Settings.
+type GameSettings struct {
+	Difficulty string `yaml:"difficulty,omitempty"` // Overrides Settings.Difficulty
+	Config     string `yaml:"config,omitempty"`     // Path to a custom game config YAML
+}
+
+// ParseSettings parses YAML settings. Unknown fields and invalid values are
+// rejected so a typo doesn't silently leave the defaults in place.
+func ParseSettings(data []byte) (Settings, error) {
+	var s Settings
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil && !errors.Is(err, io.EOF) {
+		return Settings{}, err
+	}
+	if err := s.Validate(); err != nil {
+		return Settings{}, err
+	}
+	return s, nil
+}
+
+// Validate checks the difficulty presets, rates, nickname and screenshot format.
+func (s Settings) Validate() error {
+	if err := validateDifficulty(s.Difficulty); err != nil {
+		return err
+	}
+	for id, g := range s.Games {
+		if err := validateDifficulty(g.Difficulty); err != nil {
+			return fmt.Errorf("games.%s: %w", id, err)
+		}
+	}
+	if s.FPS < 0 || s.RenderFPS < 0 {
+		return errors.New("fps and render_fps must not be negative")
+	}
+	if s.Attract < -1 {
+		return errors.New("attract must be -1 (off), 0 (default) or a number of seconds")
+	}
+	if utf8.RuneCountInString(s.Nickname) > MaxNicknameLen {
+		return fmt.Errorf("nickname is longer than %d characters", MaxNicknameLen)
+	}
+	if _, err := screenshot.ParseFormat(s.Screenshot); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateDifficulty checks that name is empty or a difficulty preset.
+func validateDifficulty(name string) error {
+	if name != "" && !slices.Contains(DifficultyPresets, DifficultyPreset(name)) {
+		return fmt.Errorf("unknown difficulty %q (use easy, normal, hard or fixed)", name)
+	}
+	return nil
+}
+
+// GameDifficulty returns the difficulty preset a game starts with: its own
+// default, else the default of all games. Empty means the game's config.
+// Endless variants share the default of their game.
+func (s Settings) GameDifficulty(gameID string) string {
+	if d := s.Games[strings.TrimSuffix(gameID, "_endless")].Difficulty; d != "" {
+		return d
+	}
+	return s.Difficulty
+}
+
+// GameConfig returns the path of a game's custom config, or empty.
+func (s Settings) GameConfig(gameID string) string {
+	return s.Games[gameID].Config
+}
+
+// Marshal returns the YAML form of the settings.
+func (s Settings) Marshal() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// UserSettingsPath returns ~/.arcade/settings.yaml, or empty if home is
+// unavailable.
+func UserSettingsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".arcade", "settings.yaml")
+}
+
+// LoadUserSettings reads ~/.arcade/settings.yaml. A missing file is not an
+// error and returns empty settings.
+func LoadUserSettings() (Settings, error) {
+	path := UserSettingsPath()
+	if path == "" {
+		return Settings{}, nil
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // Reading the user's own settings
+	if errors.Is(err, os.ErrNotExist) {
+		return Settings{}, nil
+	}
+	if err != nil {
+		return Settings{}, err
+	}
+	s, err := ParseSettings(data)
+	if err != nil {
+		return Settings{}, fmt.Errorf("settings %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// SaveUserSettings writes settings to ~/.arcade/settings.yaml.
+func SaveUserSettings(s Settings) error {
+	path := UserSettingsPath()
+	if path == "" {
+		return errors.New("home directory is unavailable")
+	}
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
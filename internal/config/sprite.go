@@ -0,0 +1,183 @@
+package config
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+//go:embed defaults/sprites/*.yaml
+var defaultSprites embed.FS
+
+// SpriteFile is the YAML form of a sprite.
+//
+// Each frame is text art, one string per row, with an optional matching
+// block of palette keys that color the art cell by cell; "." or a space in
+// that block keeps the sprite color. Colors are role names (player, title, ...)
+// or anything core.ParseColor accepts, optionally followed by "on <background>".
+type SpriteFile struct {
+	Name        string            `yaml:"name"`
+	FrameTicks  int               `yaml:"frame_ticks"` // Ticks per frame when animated
+	Transparent string            `yaml:"transparent"` // Art character that draws nothing (default space)
+	Color       string            `yaml:"color"`       // Color of art without a palette key
+	Palette     map[string]string `yaml:"palette"`     // Single-character key -> color
+	Frames      []SpriteFileFrame `yaml:"frames"`
+}
+
+// SpriteFileFrame is one frame of a sprite file.
+type SpriteFileFrame struct {
+	Art    string `yaml:"art"`
+	Colors string `yaml:"colors"`
+}
+
+// ParseSprite parses a YAML sprite.
+func ParseSprite(data []byte) (*core.Sprite, error) {
+	var file SpriteFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(file.Name) == "" {
+		return nil, errors.New("sprite has no name")
+	}
+
+	spec := core.SpriteSpec{
+		Name:       file.Name,
+		FrameTicks: file.FrameTicks,
+		Palette:    make(map[rune]core.SpriteColor, len(file.Palette)),
+	}
+	if file.Transparent != "" {
+		if utf8.RuneCountInString(file.Transparent) != 1 {
+			return nil, fmt.Errorf("transparent must be a single character, got %q", file.Transparent)
+		}
+		spec.Transparent, _ = utf8.DecodeRuneInString(file.Transparent)
+	}
+	if file.Color != "" {
+		c, err := parseSpriteColor(file.Color)
+		if err != nil {
+			return nil, err
+		}
+		spec.Color = c.Fg
+	}
+	for key, value := range file.Palette {
+		if utf8.RuneCountInString(key) != 1 || key == " " || key == "." {
+			return nil, fmt.Errorf("palette key %q must be a single character other than space or '.'", key)
+		}
+		c, err := parseSpriteColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("palette %s: %w", key, err)
+		}
+		r, _ := utf8.DecodeRuneInString(key)
+		spec.Palette[r] = c
+	}
+	for _, frame := range file.Frames {
+		spec.Frames = append(spec.Frames, core.SpriteFrame{
+			Art:    spriteLines(frame.Art),
+			Colors: spriteLines(strings.ReplaceAll(frame.Colors, ".", " ")),
+		})
+	}
+
+	return core.NewSprite(spec)
+}
+
+// parseSpriteColor parses "fg" or "fg on bg", where each side is a role name or a color.
+func parseSpriteColor(s string) (core.SpriteColor, error) {
+	fg, bg, hasBg := strings.Cut(s, " on ")
+
+	var c core.SpriteColor
+	var err error
+	if c.Fg, err = parseRoleOrColor(fg); err != nil {
+		return c, err
+	}
+	if hasBg {
+		if c.Bg, err = parseRoleOrColor(bg); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+// parseRoleOrColor parses a theme role name or a concrete color.
+func parseRoleOrColor(s string) (core.Color, error) {
+	s = strings.TrimSpace(s)
+	if role, ok := core.RoleByName(strings.ToLower(s)); ok {
+		return role, nil
+	}
+	return core.ParseColor(s)
+}
+
+// spriteLines splits a YAML block into rows, dropping the final line break.
+func spriteLines(block string) []string {
+	block = strings.TrimSuffix(block, "\n")
+	if block == "" {
+		return nil
+	}
+	return strings.Split(block, "\n")
+}
+
+// assetsDir is the asset pack directory set via CLI, if any.
+var assetsDir string
+
+// SetAssetsDir sets the asset pack directory. Empty restores ~/.arcade/assets.
+func SetAssetsDir(dir string) {
+	assetsDir = dir
+}
+
+// AssetsDir returns the asset pack directory: the one set with SetAssetsDir,
+// or ~/.arcade/assets (empty if home is unavailable).
+func AssetsDir() string {
+	if assetsDir != "" {
+		return assetsDir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".arcade", "assets")
+}
+
+// LoadSprite loads a sprite by name.
+// Search order: <assets dir>/sprites/<name>.yaml -> embedded default.
+// A user sprite that fails to load is reported in the returned error along
+// with the built-in sprite, so games can keep running with the default look.
+func LoadSprite(name string) (*core.Sprite, error) {
+	var userErr error
+	if dir := AssetsDir(); dir != "" {
+		file := filepath.Join(dir, "sprites", name+".yaml")
+		if data, err := os.ReadFile(file); err == nil { //nolint:gosec // Reading the user's own asset pack
+			sprite, err := ParseSprite(data)
+			if err == nil {
+				return sprite, nil
+			}
+			userErr = fmt.Errorf("sprite %s: %w", file, err)
+		}
+	}
+
+	data, err := defaultSprites.ReadFile("defaults/sprites/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown sprite %q", name)
+	}
+	sprite, err := ParseSprite(data)
+	if err != nil {
+		return nil, fmt.Errorf("built-in sprite %s: %w", name, err)
+	}
+	return sprite, userErr
+}
+
+// MustLoadSprite loads a sprite like LoadSprite, ignoring a broken user
+// override. It panics if the built-in sprite is missing or invalid, which
+// is a programming error.
+func MustLoadSprite(name string) *core.Sprite {
+	sprite, err := LoadSprite(name)
+	if sprite == nil {
+		panic(err)
+	}
+	return sprite
+}
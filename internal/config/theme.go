@@ -0,0 +1,125 @@
+package config
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+//go:embed defaults/themes/*.yaml
+var defaultThemes embed.FS
+
+// ThemeFile is the YAML form of a color theme.
+// Colors maps role names (player, hazard, tier1, ...) to colors accepted by core.ParseColor.
+type ThemeFile struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Monochrome  bool              `yaml:"monochrome"`
+	Colors      map[string]string `yaml:"colors"`
+}
+
+// ParseTheme parses a YAML theme. Roles the file leaves out keep the classic colors.
+func ParseTheme(data []byte) (*core.Theme, error) {
+	var file ThemeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(file.Name) == "" {
+		return nil, errors.New("theme has no name")
+	}
+
+	theme := core.NewTheme(file.Name)
+	theme.Description = file.Description
+	theme.Monochrome = file.Monochrome
+	for roleName, value := range file.Colors {
+		role, ok := core.RoleByName(roleName)
+		if !ok {
+			return nil, fmt.Errorf("unknown color role %q", roleName)
+		}
+		c, err := core.ParseColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("role %s: %w", roleName, err)
+		}
+		theme.Set(role, c)
+	}
+	return theme, nil
+}
+
+// UserThemesDir returns ~/.arcade/themes, or empty if home is unavailable.
+func UserThemesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".arcade", "themes")
+}
+
+// LoadThemes returns the built-in themes followed by the user's themes from
+// ~/.arcade/themes, sorted by name. A user theme replaces a built-in theme
+// with the same name. Files that fail to parse are skipped and reported in
+// the returned error; the themes that did load are still returned.
+func LoadThemes() ([]*core.Theme, error) {
+	byName := make(map[string]*core.Theme)
+	var errs []error
+
+	entries, err := defaultThemes.ReadDir("defaults/themes")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		data, err := defaultThemes.ReadFile(path.Join("defaults/themes", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		theme, err := ParseTheme(data)
+		if err != nil {
+			return nil, fmt.Errorf("built-in theme %s: %w", entry.Name(), err)
+		}
+		byName[theme.Name] = theme
+	}
+
+	if dir := UserThemesDir(); dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.yaml")) // Only fails on a malformed pattern
+		for _, file := range files {
+			data, err := os.ReadFile(file) //nolint:gosec // Reading the user's own theme directory
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			theme, err := ParseTheme(data)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("theme %s: %w", file, err))
+				continue
+			}
+			byName[theme.Name] = theme
+		}
+	}
+
+	themes := make([]*core.Theme, 0, len(byName))
+	for _, theme := range byName {
+		themes = append(themes, theme)
+	}
+	sort.Slice(themes, func(i, j int) bool {
+		return themes[i].Name < themes[j].Name
+	})
+	return themes, errors.Join(errs...)
+}
+
+// FindTheme returns the theme with the given name, or nil.
+func FindTheme(themes []*core.Theme, name string) *core.Theme {
+	for _, theme := range themes {
+		if theme.Name == name {
+			return theme
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package core
+
+// Camera shows part of a game world that is larger than the screen.
+// The world has its own coordinates; the camera's position is the world point
+// shown at the top-left of its viewport, the screen area the world is drawn in.
+// The position only changes through MoveTo, CenterOn and Follow, which keep
+// the view inside the world's bounds.
+//
+// Games either draw the whole world on a layer and let DrawWorld show the
+// visible part, or draw only visible objects (see IsVisible) at ToScreen
+// positions, which leaves the clipping to the viewport to the game.
+type Camera struct {
+	x, y     int  // World position shown at the viewport's top-left
+	viewport Rect // Screen area the world is drawn in
+	bounds   Rect // World area the camera stays inside; empty = unbounded
+}
+
+// NewCamera creates a camera drawing into viewport that never shows anything
+// outside bounds. An empty bounds lets the camera move anywhere.
+func NewCamera(viewport, bounds Rect) *Camera {
+	c := &Camera{viewport: viewport, bounds: bounds}
+	c.MoveTo(bounds.X, bounds.Y)
+	return c
+}
+
+// Position returns the world position shown at the viewport's top-left.
+func (c *Camera) Position() (x, y int) {
+	return c.x, c.y
+}
+
+// Viewport returns the screen area the camera draws in.
+func (c *Camera) Viewport() Rect {
+	return c.viewport
+}
+
+// SetViewport changes the screen area, e.g. after a resize, keeping the
+// camera inside its bounds.
+func (c *Camera) SetViewport(viewport Rect) {
+	c.viewport = viewport
+	c.MoveTo(c.x, c.y)
+}
+
+// View returns the world area the camera shows.
+func (c *Camera) View() Rect {
+	return NewRect(c.x, c.y, c.viewport.W, c.viewport.H)
+}
+
+// MoveTo moves the camera, keeping the view inside the bounds.
+// A world smaller than the viewport stays at its top-left corner.
+func (c *Camera) MoveTo(x, y int) {
+	c.x, c.y = x, y
+	if c.bounds.W <= 0 || c.bounds.H <= 0 {
+		return
+	}
+	c.x = Max(Min(c.x, c.bounds.Right()-c.viewport.W), c.bounds.X)
+	c.y = Max(Min(c.y, c.bounds.Bottom()-c.viewport.H), c.bounds.Y)
+}
+
+// CenterOn moves the camera so the world point (x, y) is in the middle of the view.
+func (c *Camera) CenterOn(x, y int) {
+	c.MoveTo(x-c.viewport.W/2, y-c.viewport.H/2)
+}
+
+// Follow scrolls just enough to keep target at least margin cells away from
+// the edges of the view, so the camera only moves when the target nears them.
+func (c *Camera) Follow(target Rect, margin int) {
+	// A margin too large for the view would leave no room to stand still in
+	marginX := Min(margin, Max(0, (c.viewport.W-target.W)/2))
+	marginY := Min(margin, Max(0, (c.viewport.H-target.H)/2))
+
+	x, y := c.x, c.y
+	switch {
+	case target.X-marginX < x:
+		x = target.X - marginX
+	case target.Right()+marginX > x+c.viewport.W:
+		x = target.Right() + marginX - c.viewport.W
+	}
+	switch {
+	case target.Y-marginY < y:
+		y = target.Y - marginY
+	case target.Bottom()+marginY > y+c.viewport.H:
+		y = target.Bottom() + marginY - c.viewport.H
+	}
+	c.MoveTo(x, y)
+}
+
+// ToScreen converts a world position to screen coordinates.
+func (c *Camera) ToScreen(x, y int) (sx, sy int) {
+	return x - c.x + c.viewport.X, y - c.y + c.viewport.Y
+}
+
+// ToWorld converts screen coordinates to a world position.
+func (c *Camera) ToWorld(sx, sy int) (x, y int) {
+	return sx - c.viewport.X + c.x, sy - c.viewport.Y + c.y
+}
+
+// IsVisible reports whether any part of a world rectangle is in view.
+func (c *Camera) IsVisible(r Rect) bool {
+	return c.View().Intersects(r)
+}
+
+// DrawWorld composites the visible part of a world layer, whose cell (0, 0)
+// is world position (0, 0), into the viewport of dst.
+func (c *Camera) DrawWorld(dst, world *Screen) {
+	dst.CompositeRect(world, c.View(), c.viewport.X, c.viewport.Y)
+}
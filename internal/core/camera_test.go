@@ -0,0 +1,98 @@
+package core
+
+import "testing"
+
+func TestCameraBounds(t *testing.T) {
+	cam := NewCamera(NewRect(0, 1, 10, 5), NewRect(0, 0, 40, 20))
+
+	tests := []struct {
+		name         string
+		x, y         int
+		wantX, wantY int
+	}{
+		{"inside", 5, 5, 5, 5},
+		{"before the start", -3, -1, 0, 0},
+		{"past the end", 35, 18, 30, 15},
+	}
+	for _, tt := range tests {
+		cam.MoveTo(tt.x, tt.y)
+		if x, y := cam.Position(); x != tt.wantX || y != tt.wantY {
+			t.Errorf("%s: Position() = (%d, %d), expected (%d, %d)", tt.name, x, y, tt.wantX, tt.wantY)
+		}
+	}
+
+	// A world smaller than the viewport stays at its corner
+	small := NewCamera(NewRect(0, 0, 10, 5), NewRect(0, 0, 4, 4))
+	small.CenterOn(3, 3)
+	if x, y := small.Position(); x != 0 || y != 0 {
+		t.Errorf("small world Position() = (%d, %d), expected (0, 0)", x, y)
+	}
+
+	// Without bounds the camera goes anywhere
+	free := NewCamera(NewRect(0, 0, 10, 5), Rect{})
+	free.MoveTo(-7, 100)
+	if x, y := free.Position(); x != -7 || y != 100 {
+		t.Errorf("unbounded Position() = (%d, %d), expected (-7, 100)", x, y)
+	}
+}
+
+func TestCameraCoordinates(t *testing.T) {
+	cam := NewCamera(NewRect(2, 1, 10, 5), Rect{})
+	cam.MoveTo(20, 30)
+
+	sx, sy := cam.ToScreen(25, 32)
+	if sx != 7 || sy != 3 {
+		t.Errorf("ToScreen(25, 32) = (%d, %d), expected (7, 3)", sx, sy)
+	}
+	if x, y := cam.ToWorld(sx, sy); x != 25 || y != 32 {
+		t.Errorf("ToWorld(%d, %d) = (%d, %d), expected (25, 32)", sx, sy, x, y)
+	}
+
+	if !cam.IsVisible(NewRect(29, 34, 3, 3)) {
+		t.Error("Rect overlapping the view corner should be visible")
+	}
+	if cam.IsVisible(NewRect(30, 30, 2, 2)) {
+		t.Error("Rect right of the view should not be visible")
+	}
+}
+
+func TestCameraFollow(t *testing.T) {
+	cam := NewCamera(NewRect(0, 0, 20, 10), NewRect(0, 0, 100, 10))
+
+	// Inside the margins nothing moves
+	cam.Follow(NewRect(10, 5, 1, 1), 4)
+	if x, _ := cam.Position(); x != 0 {
+		t.Errorf("x = %d, expected 0 while the target is away from the edges", x)
+	}
+
+	// Nearing the right edge scrolls until the margin is restored
+	cam.Follow(NewRect(18, 5, 1, 1), 4)
+	if x, _ := cam.Position(); x != 3 {
+		t.Errorf("x = %d, expected 3", x)
+	}
+
+	// Going back left scrolls the other way, stopping at the world's start
+	cam.Follow(NewRect(1, 5, 1, 1), 4)
+	if x, _ := cam.Position(); x != 0 {
+		t.Errorf("x = %d, expected 0", x)
+	}
+}
+
+func TestCameraDrawWorld(t *testing.T) {
+	world := NewLayer(30, 3)
+	world.DrawText(10, 1, "@")
+
+	cam := NewCamera(NewRect(1, 1, 5, 2), NewRect(0, 0, 30, 3))
+	cam.CenterOn(10, 1)
+
+	dst := NewScreen(7, 4)
+	dst.Fill('.')
+	cam.DrawWorld(dst, world)
+
+	expected := []string{".......", ".......", "...@...", "......."}
+	for y, row := range expected {
+		if got := dst.Row(y); got != row {
+			t.Errorf("row %d = %q, expected %q", y, got, row)
+		}
+	}
+}
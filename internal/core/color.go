@@ -1,8 +1,19 @@
 package core
 
-// Color represents a foreground color for a screen cell.
-// Uses ANSI 256-color codes for terminal compatibility.
-type Color uint8
+// Color represents a foreground or background color for a screen cell.
+// A Color is one of the named colors below, an entry of the 256-color palette
+// (Color256), a 24-bit RGB value (RGB) or a semantic role (RolePlayer, ...)
+// that the active Theme maps to one of the others. The platform down-samples
+// colors to what the terminal supports, and drops them entirely for monochrome
+// output, so games should never tell elements apart by color alone.
+type Color uint32
+
+// Color kinds are stored in the high byte.
+const (
+	colorKindMask  Color = 0xFF << 24
+	colorKindIndex Color = 1 << 24 // Low byte is a 256-color palette index
+	colorKindRGB   Color = 2 << 24 // Low three bytes are R, G, B
+)
 
 // Predefined colors for game elements.
 const (
@@ -23,4 +34,194 @@ const (
 	ColorBrightWhite
 	ColorOrange
 	ColorGray
+	ColorBlack
+)
+
+// Attr is a set of text attributes for a screen cell.
+// Attributes combine with bitwise OR, e.g. AttrBold|AttrUnderline.
+type Attr uint8
+
+// Text attributes.
+const (
+	AttrBold Attr = 1 << iota
+	AttrDim
+	AttrReverse
+	AttrUnderline
+
+	// AttrNone is the plain attribute set.
+	AttrNone Attr = 0
+)
+
+// Has reports whether all attributes in flag are set.
+func (a Attr) Has(flag Attr) bool {
+	return a&flag == flag
+}
+
+// namedIndex maps the predefined colors to their 256-color palette index.
+var namedIndex = [...]uint8{
+	ColorRed:           1,
+	ColorGreen:         2,
+	ColorYellow:        3,
+	ColorBlue:          4,
+	ColorMagenta:       5,
+	ColorCyan:          6,
+	ColorWhite:         7,
+	ColorBrightRed:     9,
+	ColorBrightGreen:   10,
+	ColorBrightYellow:  11,
+	ColorBrightBlue:    12,
+	ColorBrightMagenta: 13,
+	ColorBrightCyan:    14,
+	ColorBrightWhite:   15,
+	ColorOrange:        208,
+	ColorGray:          245,
+	ColorBlack:         0,
+}
+
+// Color256 returns the color at the given index of the xterm 256-color palette.
+func Color256(index uint8) Color {
+	return colorKindIndex | Color(index)
+}
+
+// RGB returns a 24-bit color.
+func RGB(r, g, b uint8) Color {
+	return colorKindRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// IsDefault reports whether c is the terminal's default color.
+func (c Color) IsDefault() bool {
+	return c == ColorDefault
+}
+
+// Index returns the 256-color palette index of a named or palette color.
+// ok is false for RGB colors and the default color.
+func (c Color) Index() (index uint8, ok bool) {
+	switch c & colorKindMask {
+	case colorKindIndex:
+		return uint8(c), true
+	case colorKindRGB, colorKindRole:
+		return 0, false
+	}
+	if c == ColorDefault || int(c) >= len(namedIndex) {
+		return 0, false
+	}
+	return namedIndex[c], true
+}
+
+// RGBValues returns the red, green and blue components of c.
+// Palette colors use the standard xterm values. The default color is black.
+func (c Color) RGBValues() (r, g, b uint8) {
+	if c&colorKindMask == colorKindRGB {
+		return uint8(c >> 16), uint8(c >> 8), uint8(c)
+	}
+	index, ok := c.Index()
+	if !ok {
+		return 0, 0, 0
+	}
+	rgb := paletteRGB(index)
+	return rgb[0], rgb[1], rgb[2]
+}
+
+// ColorProfile is the range of colors an output can display.
+type ColorProfile int
+
+// Color profiles, from fewest to most colors.
+const (
+	ProfileMono      ColorProfile = iota // No colors (NO_COLOR or dumb terminals)
+	ProfileANSI                          // The 16 basic colors
+	ProfileANSI256                       // The xterm 256-color palette
+	ProfileTrueColor                     // 24-bit RGB
 )
+
+// Downsample converts c to the closest color the profile can display.
+// The result is ColorDefault for monochrome output, a palette color (Color256)
+// for the ANSI profiles, and c itself for true color.
+// Roles are resolved with the default theme first.
+func (c Color) Downsample(p ColorProfile) Color {
+	c = defaultTheme.Resolve(c)
+	if c == ColorDefault || p == ProfileMono {
+		return ColorDefault
+	}
+	if p == ProfileTrueColor && c&colorKindMask == colorKindRGB {
+		return c
+	}
+
+	index, ok := c.Index()
+	if !ok {
+		r, g, b := c.RGBValues()
+		index = nearest256([3]uint8{r, g, b})
+	}
+	if p == ProfileANSI && index >= 16 {
+		index = nearest16(paletteRGB(index))
+	}
+	return Color256(index)
+}
+
+// ansi16 holds the xterm RGB values of the 16 basic colors.
+var ansi16 = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube (indices 16-231).
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// paletteRGB returns the xterm RGB value of a 256-color palette index.
+func paletteRGB(index uint8) [3]uint8 {
+	switch {
+	case index < 16:
+		return ansi16[index]
+	case index < 232:
+		i := index - 16
+		return [3]uint8{cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]}
+	default:
+		v := 8 + 10*(index-232)
+		return [3]uint8{v, v, v}
+	}
+}
+
+// nearest256 returns the palette index closest to an RGB value,
+// choosing between the color cube and the grayscale ramp.
+func nearest256(rgb [3]uint8) uint8 {
+	cube := 16 + 36*nearestCubeLevel(rgb[0]) + 6*nearestCubeLevel(rgb[1]) + nearestCubeLevel(rgb[2])
+
+	avg := (int(rgb[0]) + int(rgb[1]) + int(rgb[2])) / 3
+	gray := 232 + uint8(Clamp((avg-3)/10, 0, 23)) //nolint:gosec // Clamped to 0-23
+
+	if colorDistance(paletteRGB(gray), rgb) < colorDistance(paletteRGB(cube), rgb) {
+		return gray
+	}
+	return cube
+}
+
+// nearestCubeLevel returns the index of the color cube level closest to v.
+func nearestCubeLevel(v uint8) uint8 {
+	best := uint8(0)
+	for i, level := range cubeLevels {
+		if Abs(int(v)-int(level)) < Abs(int(v)-int(cubeLevels[best])) {
+			best = uint8(i) //nolint:gosec // i < 6
+		}
+	}
+	return best
+}
+
+// nearest16 returns the basic color closest to an RGB value.
+func nearest16(rgb [3]uint8) uint8 {
+	best, bestDist := uint8(0), -1
+	for i, c := range ansi16 {
+		if d := colorDistance(c, rgb); bestDist < 0 || d < bestDist {
+			best, bestDist = uint8(i), d //nolint:gosec // i < 16
+		}
+	}
+	return best
+}
+
+// colorDistance returns the squared distance between two RGB values.
+func colorDistance(a, b [3]uint8) int {
+	dr := int(a[0]) - int(b[0])
+	dg := int(a[1]) - int(b[1])
+	db := int(a[2]) - int(b[2])
+	return dr*dr + dg*dg + db*db
+}
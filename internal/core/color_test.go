@@ -0,0 +1,94 @@
+package core
+
+import "testing"
+
+func TestColorIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		color  Color
+		want   uint8
+		wantOK bool
+	}{
+		{"default", ColorDefault, 0, false},
+		{"named red", ColorRed, 1, true},
+		{"named orange", ColorOrange, 208, true},
+		{"named black", ColorBlack, 0, true},
+		{"palette", Color256(123), 123, true},
+		{"rgb", RGB(1, 2, 3), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.color.Index()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Index() = (%d, %v), expected (%d, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestColorRGBValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   Color
+		r, g, b uint8
+	}{
+		{"rgb", RGB(10, 20, 30), 10, 20, 30},
+		{"basic", ColorBrightWhite, 255, 255, 255},
+		{"cube", Color256(208), 255, 135, 0},
+		{"grayscale", Color256(232), 8, 8, 8},
+		{"default", ColorDefault, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, g, b := tt.color.RGBValues()
+			if r != tt.r || g != tt.g || b != tt.b {
+				t.Errorf("RGBValues() = (%d, %d, %d), expected (%d, %d, %d)", r, g, b, tt.r, tt.g, tt.b)
+			}
+		})
+	}
+}
+
+func TestColorDownsample(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   Color
+		profile ColorProfile
+		want    Color
+	}{
+		{"mono drops color", ColorRed, ProfileMono, ColorDefault},
+		{"mono drops rgb", RGB(255, 0, 0), ProfileMono, ColorDefault},
+		{"default stays default", ColorDefault, ProfileTrueColor, ColorDefault},
+		{"truecolor keeps rgb", RGB(1, 2, 3), ProfileTrueColor, RGB(1, 2, 3)},
+		{"named to palette", ColorCyan, ProfileANSI256, Color256(6)},
+		{"named basic in ansi", ColorBrightRed, ProfileANSI, Color256(9)},
+		{"orange to ansi", ColorOrange, ProfileANSI, Color256(3)},
+		{"gray to ansi", ColorGray, ProfileANSI, Color256(8)},
+		{"rgb to cube", RGB(255, 135, 0), ProfileANSI256, Color256(208)},
+		{"rgb to grayscale", RGB(128, 128, 128), ProfileANSI256, Color256(244)},
+		{"rgb to ansi", RGB(250, 10, 10), ProfileANSI, Color256(9)},
+		{"palette in 256", Color256(100), ProfileANSI256, Color256(100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.color.Downsample(tt.profile); got != tt.want {
+				t.Errorf("Downsample(%d) = %#x, expected %#x", tt.profile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttrHas(t *testing.T) {
+	a := AttrBold | AttrUnderline
+	if !a.Has(AttrBold) || !a.Has(AttrUnderline) {
+		t.Error("Has should report set attributes")
+	}
+	if a.Has(AttrReverse) {
+		t.Error("Has should not report unset attributes")
+	}
+	if a.Has(AttrBold | AttrDim) {
+		t.Error("Has should require all attributes in the flag")
+	}
+}
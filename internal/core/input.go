@@ -1,5 +1,7 @@
 package core
 
+import "strings"
+
 // PlayerID identifies a player in a game.
 // Player1 is always the local human player, Player2 can be CPU or remote player.
 type PlayerID int
@@ -72,12 +74,53 @@ func (a Action) String() string {
 	}
 }
 
+// ActionByName returns the action whose String is name, ignoring case.
+// Key binding files name actions this way ("jump", "pause").
+func ActionByName(name string) (Action, bool) {
+	for a := ActionUp; a <= ActionPause; a++ {
+		if strings.EqualFold(a.String(), name) {
+			return a, true
+		}
+	}
+	return ActionNone, false
+}
+
 // InputFrame represents the input state for a single player during one simulation tick.
-// It contains all actions that were triggered during this frame.
+// An action is pressed on the frame its key goes down, held on every frame until
+// its key goes up, and released on the frame after that. Use Has for one-shot
+// actions (jump, turn, pause) and Held for continuous ones (paddle movement).
 type InputFrame struct {
-	// Actions maps action types to whether they were triggered this frame.
+	// Actions maps action types to whether they were pressed this frame.
 	// Using a map allows checking multiple actions without order dependency.
 	Actions map[Action]bool
+
+	// HeldActions and ReleasedActions are filled by the platform, which
+	// tracks key state across frames.
+	HeldActions     map[Action]bool
+	ReleasedActions map[Action]bool
+
+	// Mouse is the mouse during this frame. The platform only fills it in
+	// for games that have the mouse enabled.
+	Mouse MouseState
+}
+
+// MouseState is the mouse during one frame, in screen cells.
+type MouseState struct {
+	X, Y         int  // Cell under the pointer
+	DragX, DragY int  // Cell where the left button last went down
+	Active       bool // The pointer position is known
+	Moved        bool // The pointer moved this frame
+	Down         bool // The left button is held
+	Pressed      bool // The left button went down this frame
+	Released     bool // The left button went up this frame
+}
+
+// ClearEdges resets what happened during the frame but keeps the pointer
+// position and whether the button is held.
+func (m *MouseState) ClearEdges() {
+	m.Moved = false
+	m.Pressed = false
+	m.Released = false
 }
 
 // NewInputFrame creates an empty input frame.
@@ -87,7 +130,7 @@ func NewInputFrame() InputFrame {
 	}
 }
 
-// Set marks an action as triggered for this frame.
+// Set marks an action as pressed for this frame.
 func (f *InputFrame) Set(a Action) {
 	if f.Actions == nil {
 		f.Actions = make(map[Action]bool)
@@ -95,7 +138,23 @@ func (f *InputFrame) Set(a Action) {
 	f.Actions[a] = true
 }
 
-// Has returns true if the given action was triggered this frame.
+// SetHeld marks an action as held down during this frame.
+func (f *InputFrame) SetHeld(a Action) {
+	if f.HeldActions == nil {
+		f.HeldActions = make(map[Action]bool)
+	}
+	f.HeldActions[a] = true
+}
+
+// SetReleased marks an action as released this frame.
+func (f *InputFrame) SetReleased(a Action) {
+	if f.ReleasedActions == nil {
+		f.ReleasedActions = make(map[Action]bool)
+	}
+	f.ReleasedActions[a] = true
+}
+
+// Has returns true if the given action was pressed this frame.
 func (f InputFrame) Has(a Action) bool {
 	if f.Actions == nil {
 		return false
@@ -103,11 +162,31 @@ func (f InputFrame) Has(a Action) bool {
 	return f.Actions[a]
 }
 
+// Held returns true if the given action is held down during this frame.
+// An action pressed this frame counts as held, so a tap moves a paddle once.
+func (f InputFrame) Held(a Action) bool {
+	return f.Actions[a] || f.HeldActions[a]
+}
+
+// Released returns true if the given action was released this frame.
+func (f InputFrame) Released(a Action) bool {
+	return f.ReleasedActions[a]
+}
+
 // Clear resets all actions for the next frame.
 func (f *InputFrame) Clear() {
-	for k := range f.Actions {
-		delete(f.Actions, k)
-	}
+	clear(f.Actions)
+	clear(f.HeldActions)
+	clear(f.ReleasedActions)
+	f.Mouse = MouseState{}
+}
+
+// ClearEdges resets pressed and released actions but keeps held ones, for
+// input that carries over to the next frame until its keys are released.
+func (f *InputFrame) ClearEdges() {
+	clear(f.Actions)
+	clear(f.ReleasedActions)
+	f.Mouse.ClearEdges()
 }
 
 // Clone creates a copy of this input frame.
@@ -116,6 +195,17 @@ func (f InputFrame) Clone() InputFrame {
 	for k, v := range f.Actions {
 		clone.Actions[k] = v
 	}
+	for k, v := range f.HeldActions {
+		if v {
+			clone.SetHeld(k)
+		}
+	}
+	for k, v := range f.ReleasedActions {
+		if v {
+			clone.SetReleased(k)
+		}
+	}
+	clone.Mouse = f.Mouse
 	return clone
 }
 
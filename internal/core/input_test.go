@@ -0,0 +1,98 @@
+package core
+
+import "testing"
+
+func TestActionByName(t *testing.T) {
+	for a := ActionUp; a <= ActionPause; a++ {
+		got, ok := ActionByName(a.String())
+		if !ok || got != a {
+			t.Errorf("ActionByName(%q) = (%v, %v), expected %v", a.String(), got, ok, a)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		expected Action
+		ok       bool
+	}{
+		{"jump", ActionJump, true},
+		{"PAUSE", ActionPause, true},
+		{"none", ActionNone, false},
+		{"fly", ActionNone, false},
+		{"", ActionNone, false},
+	}
+	for _, tt := range tests {
+		got, ok := ActionByName(tt.name)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("ActionByName(%q) = (%v, %v), expected (%v, %v)", tt.name, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestInputFrameHeld(t *testing.T) {
+	f := NewInputFrame()
+	f.Set(ActionJump)
+	f.SetHeld(ActionLeft)
+	f.SetReleased(ActionRight)
+
+	tests := []struct {
+		action              Action
+		has, held, released bool
+	}{
+		{ActionJump, true, true, false}, // Pressed this frame counts as held
+		{ActionLeft, false, true, false},
+		{ActionRight, false, false, true},
+		{ActionUp, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := f.Has(tt.action); got != tt.has {
+			t.Errorf("Has(%v) = %v, expected %v", tt.action, got, tt.has)
+		}
+		if got := f.Held(tt.action); got != tt.held {
+			t.Errorf("Held(%v) = %v, expected %v", tt.action, got, tt.held)
+		}
+		if got := f.Released(tt.action); got != tt.released {
+			t.Errorf("Released(%v) = %v, expected %v", tt.action, got, tt.released)
+		}
+	}
+
+	clone := f.Clone()
+	f.ClearEdges()
+	if f.Has(ActionJump) || f.Released(ActionRight) || !f.Held(ActionLeft) {
+		t.Errorf("ClearEdges should keep only held actions, got %+v", f)
+	}
+	if !clone.Has(ActionJump) || !clone.Held(ActionLeft) || !clone.Released(ActionRight) {
+		t.Errorf("Clone = %+v, expected a copy unaffected by ClearEdges", clone)
+	}
+
+	f.Clear()
+	if f.Held(ActionLeft) {
+		t.Error("Clear should reset held actions")
+	}
+
+	var zero InputFrame
+	if zero.Has(ActionJump) || zero.Held(ActionJump) || zero.Released(ActionJump) {
+		t.Error("zero frame should report nothing")
+	}
+}
+
+func TestInputFrameMouse(t *testing.T) {
+	f := NewInputFrame()
+	f.Mouse = MouseState{X: 4, Y: 2, DragX: 1, DragY: 1, Active: true, Moved: true, Down: true, Pressed: true}
+
+	clone := f.Clone()
+	if clone.Mouse != f.Mouse {
+		t.Errorf("Clone().Mouse = %+v, expected %+v", clone.Mouse, f.Mouse)
+	}
+
+	f.ClearEdges()
+	expected := MouseState{X: 4, Y: 2, DragX: 1, DragY: 1, Active: true, Down: true}
+	if f.Mouse != expected {
+		t.Errorf("after ClearEdges Mouse = %+v, expected %+v", f.Mouse, expected)
+	}
+
+	f.Clear()
+	if f.Mouse != (MouseState{}) {
+		t.Errorf("after Clear Mouse = %+v, expected the zero state", f.Mouse)
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+// Composite draws src onto s with its top-left corner at (x, y).
+// Transparent cells of src leave s unchanged, cells without a background keep
+// the background of s, and cells outside s are clipped.
+//
+// A typical frame draws the playfield on the screen, then composites the HUD
+// and overlay layers over it in order.
+func (s *Screen) Composite(src *Screen, x, y int) {
+	s.CompositeRect(src, NewRect(0, 0, src.width, src.height), x, y)
+}
+
+// CompositeRect draws the area of src onto s with the area's top-left corner
+// at (x, y), like Composite. Use it to show part of a large layer, such as
+// the visible part of a world.
+func (s *Screen) CompositeRect(src *Screen, area Rect, x, y int) {
+	// Only the part of the area inside src has cells
+	fromX, fromY := Max(area.X, 0), Max(area.Y, 0)
+	toX, toY := Min(area.Right(), src.width), Min(area.Bottom(), src.height)
+
+	for sy := fromY; sy < toY; sy++ {
+		row := src.cells[sy]
+		for sx := fromX; sx < toX; sx++ {
+			s.blend(x+sx-area.X, y+sy-area.Y, row[sx])
+		}
+	}
+}
+
+// blend draws one cell of a layer or sprite over s.
+// Continuation cells are skipped: drawing the wide cell to their left writes them.
+func (s *Screen) blend(x, y int, c Cell) {
+	if x < 0 || x >= s.width || y < 0 || y >= s.height || c.IsTransparent() || c.IsContinuation() {
+		return
+	}
+	if c.Background.IsDefault() {
+		c.Background = s.cells[y][x].Background
+	}
+	s.put(x, y, c)
+}
@@ -0,0 +1,115 @@
+package core
+
+import "testing"
+
+func TestLayerStartsTransparent(t *testing.T) {
+	layer := NewLayer(3, 2)
+	if !layer.IsLayer() || NewScreen(3, 2).IsLayer() {
+		t.Error("IsLayer() should only be true for layers")
+	}
+	if !layer.GetCell(1, 1).IsTransparent() {
+		t.Error("New layer cells should be transparent")
+	}
+	if got := layer.String(); got != "   \n   " {
+		t.Errorf("String() = %q, expected transparent cells as spaces", got)
+	}
+
+	layer.DrawText(0, 0, "abc")
+	layer.Erase(NewRect(1, 0, 5, 1))
+	if got := layer.GetCell(0, 0).Rune; got != 'a' {
+		t.Errorf("cell outside the erased area = %q, expected 'a'", got)
+	}
+	if !layer.GetCell(2, 0).IsTransparent() {
+		t.Error("Erase should make layer cells transparent")
+	}
+
+	layer.Clear()
+	if !layer.GetCell(0, 0).IsTransparent() {
+		t.Error("Clear should make layer cells transparent")
+	}
+}
+
+func TestComposite(t *testing.T) {
+	dst := NewScreen(6, 3)
+	dst.Fill('.')
+	dst.SetBackground(2, 1, ColorBlue)
+
+	overlay := NewLayer(3, 2)
+	overlay.DrawTextWithColor(0, 0, "ab", ColorRed)
+	overlay.SetCell(2, 1, Cell{Rune: 'c'})
+	overlay.SetCell(0, 1, Cell{Rune: ' ', Background: ColorGreen}) // Opaque blank
+
+	dst.Composite(overlay, 1, 0)
+
+	expected := []string{".ab...", ". .c..", "......"}
+	for y, row := range expected {
+		if got := dst.Row(y); got != row {
+			t.Errorf("row %d = %q, expected %q", y, got, row)
+		}
+	}
+	if c := dst.GetCell(1, 0).Color; c != ColorRed {
+		t.Errorf("composited color = %v, expected red", c)
+	}
+	if c := dst.GetCell(1, 1).Background; c != ColorGreen {
+		t.Errorf("opaque blank background = %v, expected green", c)
+	}
+	if c := dst.GetCell(2, 1); c.Rune != '.' || c.Background != ColorBlue {
+		t.Errorf("cell under a transparent one = %q on %v, expected it unchanged", c.Rune, c.Background)
+	}
+	if c := dst.GetCell(3, 1).Background; c != ColorDefault {
+		t.Errorf("background under 'c' = %v, expected the screen's", c)
+	}
+}
+
+func TestCompositeClipping(t *testing.T) {
+	dst := NewScreen(3, 2)
+	layer := NewLayer(4, 3)
+	layer.Fill('x')
+
+	// Partly off every edge; must not panic
+	dst.Composite(layer, -2, -2)
+	dst.Composite(layer, 2, 1)
+	expected := []string{"xx ", "  x"}
+	for y, row := range expected {
+		if got := dst.Row(y); got != row {
+			t.Errorf("row %d = %q, expected %q", y, got, row)
+		}
+	}
+}
+
+func TestCompositeRect(t *testing.T) {
+	world := NewLayer(10, 1)
+	world.DrawText(0, 0, "0123456789")
+
+	dst := NewScreen(4, 1)
+	dst.CompositeRect(world, NewRect(3, 0, 4, 1), 0, 0)
+	if got := dst.Row(0); got != "3456" {
+		t.Errorf("row = %q, expected %q", got, "3456")
+	}
+
+	// An area reaching past the layer only draws the part inside it
+	dst.Clear()
+	dst.CompositeRect(world, NewRect(8, 0, 4, 1), 0, 0)
+	if got := dst.Row(0); got != "89  " {
+		t.Errorf("row = %q, expected %q", got, "89  ")
+	}
+}
+
+func TestCompositeWide(t *testing.T) {
+	layer := NewLayer(4, 1)
+	layer.DrawText(0, 0, "猫")
+
+	dst := NewScreen(4, 1)
+	dst.Fill('.')
+	dst.Composite(layer, 1, 0)
+	if got := dst.Row(0); got != ".猫." {
+		t.Errorf("row = %q, expected %q", got, ".猫.")
+	}
+
+	// Clipped at the right edge, the glyph can't be drawn whole
+	dst.Fill('.')
+	dst.Composite(layer, 3, 0)
+	if got := dst.Row(0); got != "... " {
+		t.Errorf("row = %q, expected %q", got, "... ")
+	}
+}
@@ -2,12 +2,63 @@ package core
 
 import (
 	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
 )
 
-// Cell represents a single screen cell with character and color.
+// Cell represents a single screen cell with character, colors and attributes.
+// The zero Background and Attrs draw with the terminal's defaults.
+//
+// A character two columns wide (CJK, most emoji) is stored in its left cell with
+// Wide set; the cell to its right is a continuation cell whose Rune is 0 and
+// which draws nothing. The screen keeps the pair together: overwriting either
+// half blanks the other.
+//
+// Layers and sprites also have transparent cells, which let whatever is below
+// show through when composited; they are never drawn to a terminal.
 type Cell struct {
-	Rune  rune
-	Color Color
+	Rune       rune
+	Color      Color // Foreground color
+	Background Color
+	Attrs      Attr
+
+	// Combining holds the rest of a character made of several runes, such as
+	// accents or emoji sequences; it is usually empty.
+	Combining string
+
+	// Wide marks a character two columns wide.
+	Wide bool
+}
+
+// clearRune marks a transparent cell.
+const clearRune rune = -1
+
+// IsTransparent reports whether c is a transparent layer or sprite cell.
+func (c Cell) IsTransparent() bool {
+	return c.Rune == clearRune
+}
+
+// IsContinuation reports whether c is the right half of a wide character.
+func (c Cell) IsContinuation() bool {
+	return c.Rune == 0
+}
+
+// Text returns the character a cell shows, "" for a continuation cell
+// and a space for a transparent cell.
+func (c Cell) Text() string {
+	switch {
+	case c.IsContinuation():
+		return ""
+	case c.IsTransparent():
+		return " "
+	}
+	return string(c.Rune) + c.Combining
+}
+
+// SameStyle reports whether two cells are drawn with the same colors and attributes.
+func (c Cell) SameStyle(other Cell) bool {
+	return c.Color == other.Color && c.Background == other.Background && c.Attrs == other.Attrs
 }
 
 // Screen is a 2D character buffer for rendering game graphics.
@@ -17,6 +68,7 @@ type Screen struct {
 	width  int
 	height int
 	cells  [][]Cell
+	blank  Cell // What Clear fills the screen with
 }
 
 // NewScreen creates a new screen buffer with the given dimensions.
@@ -24,12 +76,36 @@ func NewScreen(width, height int) *Screen {
 	s := &Screen{
 		width:  width,
 		height: height,
+		blank:  Cell{Rune: ' '},
+	}
+	s.allocate()
+	s.Clear()
+	return s
+}
+
+// NewLayer creates an off-screen buffer whose cells start transparent.
+// Draw on it like any screen, then composite it onto another screen with
+// Composite: only what was drawn covers the screen below. Clear and Erase
+// make a layer's cells transparent again.
+//
+// Layers keep HUDs and overlays apart from the playfield, and can be larger
+// than the terminal to hold a scrolling world (see Camera).
+func NewLayer(width, height int) *Screen {
+	s := &Screen{
+		width:  width,
+		height: height,
+		blank:  Cell{Rune: clearRune},
 	}
 	s.allocate()
 	s.Clear()
 	return s
 }
 
+// IsLayer reports whether s was created with NewLayer.
+func (s *Screen) IsLayer() bool {
+	return s.blank.IsTransparent()
+}
+
 // allocate creates the underlying cell storage.
 func (s *Screen) allocate() {
 	s.cells = make([][]Cell, s.height)
@@ -62,50 +138,120 @@ func (s *Screen) Resize(width, height int) {
 	s.allocate()
 	s.Clear()
 
-	// Copy old content (both rune and color)
+	// Copy old content (rune and style)
 	copyW := Min(oldW, width)
 	copyH := Min(oldH, height)
 	for y := range copyH {
 		for x := range copyW {
 			s.cells[y][x] = oldCells[y][x]
 		}
+		// A wide character cut by the new right edge is blanked
+		if copyW > 0 && copyW < oldW && s.cells[y][copyW-1].Wide {
+			last := &s.cells[y][copyW-1]
+			last.Rune, last.Combining, last.Wide = s.blank.Rune, "", false
+		}
 	}
 }
 
-// Clear fills the entire screen with spaces and resets colors.
+// Clear fills the entire screen with spaces and resets colors and attributes.
+// A layer becomes fully transparent instead.
 func (s *Screen) Clear() {
 	for y := range s.cells {
 		for x := range s.cells[y] {
-			s.cells[y][x] = Cell{Rune: ' ', Color: ColorDefault}
+			s.cells[y][x] = s.blank
 		}
 	}
 }
 
-// Fill fills the entire screen with the given rune (default color).
+// Erase clears an area like Clear does for the whole screen.
+func (s *Screen) Erase(r Rect) {
+	for y := Max(r.Y, 0); y < Min(r.Bottom(), s.height); y++ {
+		for x := Max(r.X, 0); x < Min(r.Right(), s.width); x++ {
+			s.breakWide(x, y)
+			s.cells[y][x] = s.blank
+		}
+	}
+}
+
+// Fill fills the entire screen with the given rune (default style).
 func (s *Screen) Fill(r rune) {
 	for y := range s.cells {
 		for x := range s.cells[y] {
-			s.cells[y][x] = Cell{Rune: r, Color: ColorDefault}
+			s.cells[y][x] = Cell{Rune: r}
 		}
 	}
 }
 
 // Set places a rune at the given position (preserves existing color).
+// A wide rune also covers the next cell. Runes that take no columns on their
+// own, such as combining marks, are drawn as spaces.
 // Out-of-bounds coordinates are silently ignored.
 func (s *Screen) Set(x, y int, r rune) {
 	if x < 0 || x >= s.width || y < 0 || y >= s.height {
 		return
 	}
-	s.cells[y][x].Rune = r
+	cell := s.cells[y][x]
+	cell.Rune, cell.Combining = r, ""
+	s.put(x, y, cell)
 }
 
 // SetWithColor places a rune with a specific color at the given position.
+// The background and attributes are preserved, so text can be drawn over filled areas.
 // Out-of-bounds coordinates are silently ignored.
 func (s *Screen) SetWithColor(x, y int, r rune, c Color) {
 	if x < 0 || x >= s.width || y < 0 || y >= s.height {
 		return
 	}
-	s.cells[y][x] = Cell{Rune: r, Color: c}
+	cell := s.cells[y][x]
+	cell.Rune, cell.Combining, cell.Color = r, "", c
+	s.put(x, y, cell)
+}
+
+// SetCell replaces the whole cell at the given position.
+// Wide is derived from the cell's character, so it need not be set.
+// Out-of-bounds coordinates are silently ignored.
+func (s *Screen) SetCell(x, y int, c Cell) {
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return
+	}
+	s.put(x, y, c)
+}
+
+// put stores a cell at an in-bounds position, keeping wide characters whole:
+// a wide cell claims the cell to its right, and any wide character it
+// overlaps is blanked.
+func (s *Screen) put(x, y int, c Cell) {
+	width := RuneWidth(c.Rune)
+	if c.Combining != "" {
+		width = uniseg.StringWidth(c.Text())
+	}
+	if width == 0 {
+		c.Rune, c.Combining = ' ', ""
+	}
+	c.Wide = width == 2
+	if c.Wide && x+1 >= s.width {
+		// Only half of it would fit
+		c.Rune, c.Combining, c.Wide = ' ', "", false
+	}
+
+	s.breakWide(x, y)
+	row := s.cells[y]
+	row[x] = c
+	if c.Wide {
+		s.breakWide(x+1, y)
+		row[x+1] = Cell{Color: c.Color, Background: c.Background, Attrs: c.Attrs}
+	}
+}
+
+// breakWide blanks the other half of a wide character covering (x, y).
+func (s *Screen) breakWide(x, y int) {
+	row := s.cells[y]
+	switch {
+	case row[x].IsContinuation() && x > 0:
+		row[x-1].Rune, row[x-1].Combining, row[x-1].Wide = s.blank.Rune, "", false
+	case row[x].Wide && x+1 < s.width:
+		row[x+1].Rune = s.blank.Rune
+	}
 }
 
 // SetColor sets the color at the given position without changing the rune.
@@ -117,8 +263,26 @@ func (s *Screen) SetColor(x, y int, c Color) {
 	s.cells[y][x].Color = c
 }
 
-// Get returns the rune at the given position.
-// Returns space for out-of-bounds coordinates.
+// SetBackground sets the background color at the given position without changing the rune.
+// Out-of-bounds coordinates are silently ignored.
+func (s *Screen) SetBackground(x, y int, c Color) {
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return
+	}
+	s.cells[y][x].Background = c
+}
+
+// SetAttrs replaces the text attributes at the given position without changing the rune.
+// Out-of-bounds coordinates are silently ignored.
+func (s *Screen) SetAttrs(x, y int, a Attr) {
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return
+	}
+	s.cells[y][x].Attrs = a
+}
+
+// Get returns the rune at the given position, 0 for the right half of a wide
+// character. Returns space for out-of-bounds coordinates.
 func (s *Screen) Get(x, y int) rune {
 	if x < 0 || x >= s.width || y < 0 || y >= s.height {
 		return ' '
@@ -126,40 +290,113 @@ func (s *Screen) Get(x, y int) rune {
 	return s.cells[y][x].Rune
 }
 
-// GetCell returns the full cell (rune + color) at the given position.
+// GetCell returns the full cell (rune and style) at the given position.
 // Returns empty cell for out-of-bounds coordinates.
 func (s *Screen) GetCell(x, y int) Cell {
 	if x < 0 || x >= s.width || y < 0 || y >= s.height {
-		return Cell{Rune: ' ', Color: ColorDefault}
+		return Cell{Rune: ' '}
 	}
 	return s.cells[y][x]
 }
 
-// DrawText writes a string horizontally starting at (x, y).
-// Characters that extend beyond screen bounds are clipped.
+// DrawText writes a string horizontally starting at (x, y), keeping each cell's style.
+// Each character takes its display width (see StringWidth), and characters made of
+// several runes stay in one cell. Characters that extend beyond screen bounds are clipped.
 func (s *Screen) DrawText(x, y int, text string) {
-	for i, r := range text {
-		s.Set(x+i, y, r)
-	}
+	s.drawText(x, y, text, textStyle{})
 }
 
 // DrawTextWithColor writes a colored string horizontally starting at (x, y).
+// The background and attributes are preserved, so text can be drawn over filled areas.
 // Characters that extend beyond screen bounds are clipped.
 func (s *Screen) DrawTextWithColor(x, y int, text string, c Color) {
-	for i, r := range text {
-		s.SetWithColor(x+i, y, r, c)
+	s.drawText(x, y, text, textStyle{mode: textSetColor, fg: c})
+}
+
+// DrawTextStyled writes a string with full styling starting at (x, y).
+// Characters that extend beyond screen bounds are clipped.
+func (s *Screen) DrawTextStyled(x, y int, text string, fg, bg Color, attrs Attr) {
+	s.drawText(x, y, text, textStyle{mode: textSetStyle, fg: fg, bg: bg, attrs: attrs})
+}
+
+// textStyle is how drawn text styles the cells it replaces.
+type textStyle struct {
+	mode  textStyleMode
+	fg    Color
+	bg    Color
+	attrs Attr
+}
+
+type textStyleMode uint8
+
+const (
+	textKeepStyle textStyleMode = iota // Keep the cells' colors and attributes
+	textSetColor                       // Set the foreground, keep the rest
+	textSetStyle                       // Replace colors and attributes
+)
+
+// apply returns the cell styled for text, before its character is set.
+func (t textStyle) apply(cell Cell) Cell {
+	switch t.mode {
+	case textSetColor:
+		cell.Color = t.fg
+	case textSetStyle:
+		cell = Cell{Color: t.fg, Background: t.bg, Attrs: t.attrs}
+	}
+	return cell
+}
+
+// drawText writes text one character (grapheme cluster) at a time.
+func (s *Screen) drawText(x, y int, text string, style textStyle) {
+	if y < 0 || y >= s.height {
+		return
+	}
+
+	if isPrintableASCII(text) {
+		for i := range len(text) {
+			if col := x + i; col >= 0 && col < s.width {
+				cell := style.apply(s.cells[y][col])
+				cell.Rune, cell.Combining = rune(text[i]), ""
+				s.put(col, y, cell)
+			}
+		}
+		return
+	}
+
+	state := -1
+	for len(text) > 0 {
+		var cluster string
+		var width int
+		cluster, text, width, state = uniseg.FirstGraphemeClusterInString(text, state)
+		if width == 0 {
+			continue // Control characters, or marks with nothing to combine with
+		}
+
+		switch {
+		case x >= 0 && x < s.width:
+			cell := style.apply(s.cells[y][x])
+			first, size := utf8.DecodeRuneInString(cluster)
+			cell.Rune, cell.Combining = first, cluster[size:]
+			s.put(x, y, cell)
+		case x == -1 && width == 2:
+			// The visible right half of a clipped wide character is blanked
+			cell := style.apply(s.cells[y][0])
+			cell.Rune, cell.Combining = ' ', ""
+			s.put(0, y, cell)
+		}
+		x += width
 	}
 }
 
 // DrawTextCentered draws text centered horizontally at the given y position.
 func (s *Screen) DrawTextCentered(y int, text string) {
-	x := (s.width - len(text)) / 2
+	x := (s.width - StringWidth(text)) / 2
 	s.DrawText(x, y, text)
 }
 
 // DrawTextCenteredWithColor draws colored text centered horizontally.
 func (s *Screen) DrawTextCenteredWithColor(y int, text string, c Color) {
-	x := (s.width - len(text)) / 2
+	x := (s.width - StringWidth(text)) / 2
 	s.DrawTextWithColor(x, y, text, c)
 }
 
@@ -172,6 +409,27 @@ func (s *Screen) DrawRect(r Rect, fill rune) {
 	}
 }
 
+// FillRect fills a rectangular area with the given rune and colors, clearing attributes.
+// Use it for solid blocks such as tiles and panels.
+func (s *Screen) FillRect(r Rect, fill rune, fg, bg Color) {
+	for y := r.Y; y < r.Y+r.H; y++ {
+		for x := r.X; x < r.X+r.W; x++ {
+			s.SetCell(x, y, Cell{Rune: fill, Color: fg, Background: bg})
+		}
+	}
+}
+
+// HighlightRect sets the background and adds attributes over an area, keeping its runes
+// and foreground colors. Use it for selection bars and highlighted elements.
+func (s *Screen) HighlightRect(r Rect, bg Color, attrs Attr) {
+	for y := Max(r.Y, 0); y < Min(r.Y+r.H, s.height); y++ {
+		for x := Max(r.X, 0); x < Min(r.X+r.W, s.width); x++ {
+			s.cells[y][x].Background = bg
+			s.cells[y][x].Attrs |= attrs
+		}
+	}
+}
+
 // DrawBox draws a box outline using box-drawing characters.
 func (s *Screen) DrawBox(r Rect) {
 	// Corners
@@ -193,6 +451,20 @@ func (s *Screen) DrawBox(r Rect) {
 	}
 }
 
+// DrawMessageBox draws a boxed title and subtitle in the center of the screen,
+// as games do for pause and game over messages.
+func (s *Screen) DrawMessageBox(title, subtitle string) {
+	titleW, subtitleW := StringWidth(title), StringWidth(subtitle)
+	box := NewRect(0, 0, Max(titleW, subtitleW)+4, 5)
+	box.X = (s.width - box.W) / 2
+	box.Y = (s.height - box.H) / 2
+
+	s.DrawRect(box, ' ')
+	s.DrawBox(box)
+	s.DrawText(box.X+(box.W-titleW)/2, box.Y+1, title)
+	s.DrawText(box.X+(box.W-subtitleW)/2, box.Y+3, subtitle)
+}
+
 // DrawHLine draws a horizontal line from (x, y) with the given length.
 func (s *Screen) DrawHLine(x, y, length int, r rune) {
 	for i := range length {
@@ -217,21 +489,75 @@ func (s *Screen) String() string {
 		if y > 0 {
 			sb.WriteRune('\n')
 		}
-		for x := range s.width {
-			sb.WriteRune(s.cells[y][x].Rune)
-		}
+		s.writeRow(&sb, y)
 	}
 	return sb.String()
 }
 
 // Row returns a copy of the specified row as a plain text string.
+// Its display width is the screen width.
 func (s *Screen) Row(y int) string {
 	if y < 0 || y >= s.height {
 		return strings.Repeat(" ", s.width)
 	}
-	runes := make([]rune, s.width)
-	for x := range s.width {
-		runes[x] = s.cells[y][x].Rune
+	var sb strings.Builder
+	sb.Grow(s.width)
+	s.writeRow(&sb, y)
+	return sb.String()
+}
+
+// writeRow writes the characters of a row, skipping continuation cells.
+func (s *Screen) writeRow(sb *strings.Builder, y int) {
+	for _, cell := range s.cells[y] {
+		sb.WriteString(cell.Text())
+	}
+}
+
+// Equal reports whether two screens have the same size and cells.
+func (s *Screen) Equal(other *Screen) bool {
+	if other == nil || s.width != other.width || s.height != other.height {
+		return false
+	}
+	for y := range s.cells {
+		for x := range s.cells[y] {
+			if s.cells[y][x] != other.cells[y][x] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CopyFrom makes s an exact copy of other, resizing s if needed.
+func (s *Screen) CopyFrom(other *Screen) {
+	if s.width != other.width || s.height != other.height {
+		s.width = other.width
+		s.height = other.height
+		s.allocate()
+	}
+	s.blank = other.blank
+	for y := range other.cells {
+		copy(s.cells[y], other.cells[y])
+	}
+}
+
+// Region returns a screen that draws into the area r of s, clipped to s.
+// The region shares its cells with s: everything drawn on it, Clear
+// included, changes that area of s and nothing else. Use it to give each of
+// several games or panels its own part of one screen. Resizing a region
+// detaches it from s.
+func (s *Screen) Region(r Rect) *Screen {
+	fromX, fromY := Clamp(r.X, 0, s.width), Clamp(r.Y, 0, s.height)
+	toX, toY := Clamp(r.Right(), fromX, s.width), Clamp(r.Bottom(), fromY, s.height)
+
+	region := &Screen{
+		width:  toX - fromX,
+		height: toY - fromY,
+		cells:  make([][]Cell, toY-fromY),
+		blank:  s.blank,
+	}
+	for y := range region.cells {
+		region.cells[y] = s.cells[fromY+y][fromX:toX:toX]
 	}
-	return string(runes)
+	return region
 }
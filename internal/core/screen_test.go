@@ -176,6 +176,27 @@ func TestScreenDrawBox(t *testing.T) {
 	}
 }
 
+func TestScreenDrawMessageBox(t *testing.T) {
+	s := NewScreen(12, 7)
+	s.Fill('.')
+	s.DrawMessageBox("猫", "Go on")
+
+	expected := []string{
+		"............",
+		".┌───────┐..",
+		".│  猫   │..",
+		".│       │..",
+		".│ Go on │..",
+		".└───────┘..",
+		"............",
+	}
+	for y, row := range expected {
+		if got := s.Row(y); got != row {
+			t.Errorf("row %d = %q, expected %q", y, got, row)
+		}
+	}
+}
+
 func TestScreenDrawHLine(t *testing.T) {
 	s := NewScreen(10, 5)
 	s.DrawHLine(2, 2, 5, '-')
@@ -254,3 +275,261 @@ func TestScreenRow(t *testing.T) {
 		t.Errorf("Out of bounds row should be spaces, got %q", outOfBounds)
 	}
 }
+
+func TestScreenFillRect(t *testing.T) {
+	s := NewScreen(10, 5)
+	s.FillRect(Rect{X: 2, Y: 1, W: 3, H: 2}, ' ', ColorBlack, ColorYellow)
+
+	got := s.GetCell(3, 2)
+	want := Cell{Rune: ' ', Color: ColorBlack, Background: ColorYellow}
+	if got != want {
+		t.Errorf("GetCell(3, 2) = %+v, expected %+v", got, want)
+	}
+	if s.GetCell(5, 1).Background != ColorDefault {
+		t.Error("FillRect should not touch cells outside the rect")
+	}
+
+	// Text drawn over the fill keeps the background
+	s.SetWithColor(3, 2, '8', ColorWhite)
+	if c := s.GetCell(3, 2); c.Rune != '8' || c.Background != ColorYellow {
+		t.Errorf("SetWithColor over fill = %+v, expected '8' on yellow", c)
+	}
+
+	// Clipped fills must not panic
+	s.FillRect(Rect{X: -2, Y: -2, W: 20, H: 20}, '#', ColorRed, ColorBlue)
+	if s.Get(9, 4) != '#' {
+		t.Error("Clipped FillRect should fill visible cells")
+	}
+}
+
+func TestScreenHighlightRect(t *testing.T) {
+	s := NewScreen(10, 3)
+	s.DrawTextWithColor(0, 1, "SCORE", ColorCyan)
+	s.SetAttrs(0, 1, AttrUnderline)
+	s.HighlightRect(Rect{X: 0, Y: 1, W: 10, H: 1}, ColorBlue, AttrBold)
+
+	c := s.GetCell(0, 1)
+	if c.Rune != 'S' || c.Color != ColorCyan {
+		t.Errorf("HighlightRect should keep rune and foreground, got %+v", c)
+	}
+	if c.Background != ColorBlue {
+		t.Errorf("Background = %d, expected %d", c.Background, ColorBlue)
+	}
+	if !c.Attrs.Has(AttrBold | AttrUnderline) {
+		t.Errorf("Attrs = %b, expected bold and underline", c.Attrs)
+	}
+	if s.GetCell(0, 0).Background != ColorDefault {
+		t.Error("HighlightRect should not touch other rows")
+	}
+
+	s.Clear()
+	if c := s.GetCell(0, 1); c != (Cell{Rune: ' '}) {
+		t.Errorf("Clear should reset style, got %+v", c)
+	}
+}
+
+func TestCellSameStyle(t *testing.T) {
+	a := Cell{Rune: 'a', Color: ColorRed, Background: ColorBlack, Attrs: AttrBold}
+	b := Cell{Rune: 'b', Color: ColorRed, Background: ColorBlack, Attrs: AttrBold}
+	if !a.SameStyle(b) {
+		t.Error("Cells differing only by rune should share a style")
+	}
+	b.Attrs |= AttrReverse
+	if a.SameStyle(b) {
+		t.Error("Cells with different attributes should not share a style")
+	}
+	b = a
+	b.Background = ColorDefault
+	if a.SameStyle(b) {
+		t.Error("Cells with different backgrounds should not share a style")
+	}
+}
+
+func TestScreenEqualAndCopy(t *testing.T) {
+	a := NewScreen(10, 5)
+	a.DrawTextWithColor(1, 1, "hi", ColorRed)
+
+	b := NewScreen(4, 4)
+	if a.Equal(b) || a.Equal(nil) {
+		t.Error("Screens of different sizes should not be equal")
+	}
+
+	b.CopyFrom(a)
+	if !a.Equal(b) {
+		t.Error("Copy should equal its source")
+	}
+	if b.Width() != 10 || b.Height() != 5 {
+		t.Errorf("Copy size = %dx%d, expected 10x5", b.Width(), b.Height())
+	}
+
+	b.SetColor(1, 1, ColorBlue)
+	if a.Equal(b) {
+		t.Error("Screens differing in color should not be equal")
+	}
+	if a.GetCell(1, 1).Color != ColorRed {
+		t.Error("Changing the copy should not change the source")
+	}
+}
+
+func TestScreenWideCharacters(t *testing.T) {
+	s := NewScreen(10, 1)
+	s.DrawText(0, 0, "a世b")
+
+	if got := s.Get(1, 0); got != '世' {
+		t.Errorf("Get(1, 0) = %q, expected '世'", got)
+	}
+	if !s.GetCell(1, 0).Wide {
+		t.Error("Wide character should be marked Wide")
+	}
+	if !s.GetCell(2, 0).IsContinuation() {
+		t.Error("Cell right of a wide character should be a continuation")
+	}
+	if got := s.Get(3, 0); got != 'b' {
+		t.Errorf("Get(3, 0) = %q, expected 'b' after the wide character", got)
+	}
+	if got := s.Row(0); got != "a世b      " {
+		t.Errorf("Row(0) = %q, expected %q", got, "a世b      ")
+	}
+	if got := StringWidth(s.Row(0)); got != 10 {
+		t.Errorf("Row display width = %d, expected 10", got)
+	}
+}
+
+func TestScreenOverwriteWideHalves(t *testing.T) {
+	s := NewScreen(6, 1)
+
+	// Overwriting the left half blanks the right half
+	s.DrawText(0, 0, "世")
+	s.Set(0, 0, 'x')
+	if got := s.Row(0); got != "x     " {
+		t.Errorf("after overwriting left half: %q", got)
+	}
+
+	// Overwriting the right half blanks the left half
+	s.DrawText(2, 0, "界")
+	s.Set(3, 0, 'y')
+	if got := s.Row(0); got != "x  y  " {
+		t.Errorf("after overwriting right half: %q", got)
+	}
+
+	// A wide character straddling another's right half replaces both
+	s.DrawText(0, 0, "世")
+	s.DrawText(1, 0, "界")
+	if got := s.Row(0); got != " 界y  " {
+		t.Errorf("after overlapping wide characters: %q", got)
+	}
+}
+
+func TestScreenWideClipping(t *testing.T) {
+	s := NewScreen(4, 1)
+
+	// Only half would fit at the right edge
+	s.DrawText(2, 0, "a世")
+	if got := s.Row(0); got != "  a " {
+		t.Errorf("wide character at right edge: %q", got)
+	}
+	if s.GetCell(3, 0).Wide {
+		t.Error("Clipped wide character should not be marked Wide")
+	}
+
+	// Half off the left edge
+	s.Clear()
+	s.DrawText(-1, 0, "世ab")
+	if got := s.Row(0); got != " ab " {
+		t.Errorf("wide character at left edge: %q", got)
+	}
+}
+
+func TestScreenGraphemeClusters(t *testing.T) {
+	s := NewScreen(6, 1)
+
+	// "e" + combining acute accent stays in one cell
+	s.DrawText(0, 0, "e\u0301x")
+	cell := s.GetCell(0, 0)
+	if cell.Rune != 'e' || cell.Combining != "\u0301" {
+		t.Errorf("cell 0 = %q + %q, expected 'e' + combining accent", cell.Rune, cell.Combining)
+	}
+	if got := s.Get(1, 0); got != 'x' {
+		t.Errorf("Get(1, 0) = %q, expected 'x'", got)
+	}
+
+	// Emoji take two columns; ZWJ sequences are one character
+	s.Clear()
+	s.DrawText(0, 0, "👩\u200d🚀!")
+	if !s.GetCell(0, 0).Wide || s.GetCell(0, 0).Text() != "👩\u200d🚀" {
+		t.Errorf("cell 0 = %q, expected the whole emoji sequence", s.GetCell(0, 0).Text())
+	}
+	if got := s.Get(2, 0); got != '!' {
+		t.Errorf("Get(2, 0) = %q, expected '!'", got)
+	}
+
+	// A lone combining rune can't take a cell by itself
+	s.Set(4, 0, '\u0301')
+	if got := s.Get(4, 0); got != ' ' {
+		t.Errorf("lone combining mark stored as %q, expected space", got)
+	}
+}
+
+func TestScreenDrawTextCenteredWide(t *testing.T) {
+	s := NewScreen(10, 1)
+	s.DrawTextCentered(0, "日本語")
+
+	// 6 columns wide, so it starts at column 2
+	if got := s.Get(2, 0); got != '日' {
+		t.Errorf("Get(2, 0) = %q, expected '日'", got)
+	}
+	if got := s.Row(0); got != "  日本語  " {
+		t.Errorf("Row(0) = %q", got)
+	}
+}
+
+func TestScreenDrawTextKeepsStyle(t *testing.T) {
+	s := NewScreen(6, 1)
+	s.FillRect(Rect{X: 0, Y: 0, W: 6, H: 1}, ' ', ColorDefault, ColorBlue)
+	s.DrawTextWithColor(0, 0, "世a", ColorRed)
+
+	for x := range 3 {
+		cell := s.GetCell(x, 0)
+		if cell.Color != ColorRed || cell.Background != ColorBlue {
+			t.Errorf("cell %d colors = %v on %v, expected red on blue", x, cell.Color, cell.Background)
+		}
+	}
+}
+
+func TestScreenResizeCutsWide(t *testing.T) {
+	s := NewScreen(4, 1)
+	s.DrawText(2, 0, "世")
+	s.Resize(3, 1)
+
+	if cell := s.GetCell(2, 0); cell.Wide || cell.Rune != ' ' {
+		t.Errorf("wide character cut by resize = %q (wide %v), expected blank", cell.Rune, cell.Wide)
+	}
+}
+
+func TestScreenRegion(t *testing.T) {
+	s := NewScreen(10, 4)
+	s.Fill('.')
+
+	r := s.Region(NewRect(2, 1, 4, 2))
+	if r.Width() != 4 || r.Height() != 2 {
+		t.Fatalf("Region size = %dx%d, expected 4x2", r.Width(), r.Height())
+	}
+	r.Clear()
+	r.DrawText(0, 0, "abcdefgh") // Clipped at the region's edge
+	r.Set(-1, 1, 'x')            // Outside the region
+
+	expected := "..........\n..abcd....\n..    ....\n.........."
+	if got := s.String(); got != expected {
+		t.Errorf("screen after drawing on a region =\n%s\nexpected\n%s", got, expected)
+	}
+
+	// Regions past the screen's edge are clipped to it
+	edge := s.Region(NewRect(8, 3, 5, 5))
+	if edge.Width() != 2 || edge.Height() != 1 {
+		t.Errorf("clipped region size = %dx%d, expected 2x1", edge.Width(), edge.Height())
+	}
+	edge.Fill('#')
+	if got := s.Row(3); got != "........##" {
+		t.Errorf("last row = %q, expected %q", got, "........##")
+	}
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// Sprite is a small picture drawn onto a Screen, such as a player character.
+// It has one or more frames of the same size; animated sprites cycle through
+// them, showing each for FrameTicks ticks. Transparent cells leave the screen
+// below unchanged, and cells outside the screen are clipped.
+type Sprite struct {
+	name       string
+	width      int
+	height     int
+	frameTicks int
+	frames     [][]Cell // Row-major cells of each frame
+}
+
+// SpriteSpec describes a sprite the way asset files do: frames of text art,
+// each optionally paired with rows of palette keys that color the art.
+type SpriteSpec struct {
+	Name       string
+	FrameTicks int // Ticks each frame is shown when animating (0 = no animation)
+
+	// Transparent is the art character that draws nothing (0 means a space).
+	Transparent rune
+
+	// Color is used for art cells without a palette key.
+	Color Color
+
+	// Palette maps the keys used in SpriteFrame.Colors to colors.
+	Palette map[rune]SpriteColor
+
+	Frames []SpriteFrame
+}
+
+// SpriteColor is the foreground and background of sprite cells.
+type SpriteColor struct {
+	Fg Color
+	Bg Color
+}
+
+// SpriteFrame is one picture of a sprite.
+// Colors, if present, has a row of palette keys per art row, one key per
+// column; a space or missing key uses the sprite's Color.
+type SpriteFrame struct {
+	Art    []string
+	Colors []string
+}
+
+// NewSprite builds a sprite from its description.
+// Frames of different sizes are padded with transparent cells to the largest.
+func NewSprite(spec SpriteSpec) (*Sprite, error) {
+	if len(spec.Frames) == 0 {
+		return nil, errors.New("sprite has no frames")
+	}
+	transparent := spec.Transparent
+	if transparent == 0 {
+		transparent = ' '
+	}
+
+	s := &Sprite{name: spec.Name, frameTicks: max(0, spec.FrameTicks)}
+	for _, frame := range spec.Frames {
+		s.height = max(s.height, len(frame.Art))
+		for _, row := range frame.Art {
+			s.width = max(s.width, StringWidth(row))
+		}
+	}
+
+	for i, frame := range spec.Frames {
+		cells, err := spriteCells(frame, s.width, s.height, transparent, spec)
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: %w", i+1, err)
+		}
+		s.frames = append(s.frames, cells)
+	}
+	return s, nil
+}
+
+// spriteCells lays out one frame's art and colors as width x height cells.
+func spriteCells(frame SpriteFrame, width, height int, transparent rune, spec SpriteSpec) ([]Cell, error) {
+	if len(frame.Colors) > len(frame.Art) {
+		return nil, fmt.Errorf("%d color rows for %d art rows", len(frame.Colors), len(frame.Art))
+	}
+
+	cells := make([]Cell, width*height)
+	for i := range cells {
+		cells[i] = Cell{Rune: clearRune}
+	}
+
+	for y, row := range frame.Art {
+		var keys []rune
+		if y < len(frame.Colors) {
+			keys = []rune(frame.Colors[y])
+		}
+
+		x := 0
+		state := -1
+		for len(row) > 0 {
+			var cluster string
+			var w int
+			cluster, row, w, state = uniseg.FirstGraphemeClusterInString(row, state)
+			if w == 0 {
+				continue
+			}
+
+			color := SpriteColor{Fg: spec.Color}
+			if x < len(keys) && keys[x] != ' ' {
+				c, ok := spec.Palette[keys[x]]
+				if !ok {
+					return nil, fmt.Errorf("row %d: unknown palette key %q", y+1, keys[x])
+				}
+				color = c
+			}
+
+			if cluster != string(transparent) {
+				first, size := utf8.DecodeRuneInString(cluster)
+				cells[y*width+x] = Cell{
+					Rune:       first,
+					Combining:  cluster[size:],
+					Color:      color.Fg,
+					Background: color.Bg,
+					Wide:       w == 2,
+				}
+			}
+			x += w
+		}
+	}
+	return cells, nil
+}
+
+// Name returns the sprite's name.
+func (s *Sprite) Name() string {
+	return s.name
+}
+
+// Width returns the sprite's width in columns.
+func (s *Sprite) Width() int {
+	return s.width
+}
+
+// Height returns the sprite's height in rows.
+func (s *Sprite) Height() int {
+	return s.height
+}
+
+// Frames returns the number of frames.
+func (s *Sprite) Frames() int {
+	return len(s.frames)
+}
+
+// FrameTicks returns how many ticks each frame is shown when animating.
+func (s *Sprite) FrameTicks() int {
+	return s.frameTicks
+}
+
+// FrameAt returns the frame to show after the given number of ticks.
+func (s *Sprite) FrameAt(tick int) int {
+	if s.frameTicks == 0 || tick < 0 {
+		return 0
+	}
+	return (tick / s.frameTicks) % len(s.frames)
+}
+
+// Draw draws a frame with its top-left corner at (x, y).
+// Transparent cells are skipped and cells without a background keep the
+// screen's, as with Screen.Composite. Out-of-range frames wrap around.
+func (s *Sprite) Draw(dst *Screen, x, y, frame int) {
+	frame %= len(s.frames)
+	if frame < 0 {
+		frame += len(s.frames)
+	}
+
+	cells := s.frames[frame]
+	for row := range s.height {
+		for col := range s.width {
+			dst.blend(x+col, y+row, cells[row*s.width+col])
+		}
+	}
+}
+
+// DrawAnimated draws the frame for the given tick, see FrameAt.
+func (s *Sprite) DrawAnimated(dst *Screen, x, y, tick int) {
+	s.Draw(dst, x, y, s.FrameAt(tick))
+}
+
+// String returns the frames as text art separated by blank lines,
+// with transparent cells shown as spaces.
+func (s *Sprite) String() string {
+	var sb strings.Builder
+	for i, cells := range s.frames {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		for row := range s.height {
+			if row > 0 {
+				sb.WriteByte('\n')
+			}
+			for col := 0; col < s.width; col++ {
+				cell := cells[row*s.width+col]
+				sb.WriteString(cell.Text())
+				if cell.Wide {
+					col++ // The continuation column
+				}
+			}
+		}
+	}
+	return sb.String()
+}
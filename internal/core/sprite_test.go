@@ -0,0 +1,146 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func testSprite(t *testing.T) *Sprite {
+	t.Helper()
+	s, err := NewSprite(SpriteSpec{
+		Name:       "walker",
+		FrameTicks: 5,
+		Color:      ColorGreen,
+		Palette: map[rune]SpriteColor{
+			'h': {Fg: ColorYellow},
+			'b': {Fg: ColorWhite, Bg: ColorBlue},
+		},
+		Frames: []SpriteFrame{
+			{Art: []string{" o", "/|\\", "/ \\"}, Colors: []string{" h", "bbb"}},
+			{Art: []string{" o", "/|\\", " |"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSprite() error = %v", err)
+	}
+	return s
+}
+
+func TestNewSprite(t *testing.T) {
+	s := testSprite(t)
+
+	if s.Name() != "walker" || s.Width() != 3 || s.Height() != 3 || s.Frames() != 2 {
+		t.Errorf("sprite = %q %dx%d with %d frames, expected walker 3x3 with 2",
+			s.Name(), s.Width(), s.Height(), s.Frames())
+	}
+	expected := " o \n/|\\\n/ \\\n\n o \n/|\\\n | "
+	if got := s.String(); got != expected {
+		t.Errorf("String() = %q, expected %q", got, expected)
+	}
+}
+
+func TestNewSpriteErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec SpriteSpec
+	}{
+		{"no frames", SpriteSpec{}},
+		{"unknown palette key", SpriteSpec{Frames: []SpriteFrame{{Art: []string{"x"}, Colors: []string{"q"}}}}},
+		{"too many color rows", SpriteSpec{Frames: []SpriteFrame{{Art: []string{"x"}, Colors: []string{" ", " "}}}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewSprite(tt.spec); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestSpriteDrawColorsAndTransparency(t *testing.T) {
+	s := testSprite(t)
+	dst := NewScreen(6, 4)
+	dst.Fill('.')
+	s.Draw(dst, 1, 0, 0)
+
+	expected := []string{"..o...", "./|\\..", "./.\\..", "......"}
+	for y, row := range expected {
+		if got := dst.Row(y); got != row {
+			t.Errorf("row %d = %q, expected %q", y, got, row)
+		}
+	}
+
+	if c := dst.GetCell(2, 0).Color; c != ColorYellow {
+		t.Errorf("head color = %v, expected palette yellow", c)
+	}
+	if c := dst.GetCell(1, 1); c.Color != ColorWhite || c.Background != ColorBlue {
+		t.Errorf("body cell = %v on %v, expected white on blue", c.Color, c.Background)
+	}
+	if c := dst.GetCell(1, 2).Color; c != ColorGreen {
+		t.Errorf("unkeyed cell color = %v, expected the sprite color", c)
+	}
+}
+
+func TestSpriteKeepsBackground(t *testing.T) {
+	s := testSprite(t)
+	dst := NewScreen(3, 3)
+	dst.FillRect(Rect{X: 0, Y: 0, W: 3, H: 3}, ' ', ColorDefault, ColorRed)
+	s.Draw(dst, 0, 0, 1)
+
+	if c := dst.GetCell(1, 0); c.Rune != 'o' || c.Background != ColorRed {
+		t.Errorf("cell = %q on %v, expected 'o' keeping the red background", c.Rune, c.Background)
+	}
+}
+
+func TestSpriteClipping(t *testing.T) {
+	s := testSprite(t)
+	dst := NewScreen(3, 2)
+
+	// Partly off the top-left and bottom-right edges; must not panic
+	s.Draw(dst, -1, -1, 0)
+	if got := dst.Row(0); got != "|\\ " {
+		t.Errorf("row 0 = %q, expected %q", got, "|\\ ")
+	}
+	dst.Clear()
+	s.Draw(dst, 1, 1, 0)
+	if got := dst.Row(1); got != "  o" {
+		t.Errorf("row 1 = %q, expected %q", got, "  o")
+	}
+}
+
+func TestSpriteAnimation(t *testing.T) {
+	s := testSprite(t)
+
+	for tick, frame := range map[int]int{0: 0, 4: 0, 5: 1, 9: 1, 10: 0, -3: 0} {
+		if got := s.FrameAt(tick); got != frame {
+			t.Errorf("FrameAt(%d) = %d, expected %d", tick, got, frame)
+		}
+	}
+
+	dst := NewScreen(3, 3)
+	s.DrawAnimated(dst, 0, 0, 7)
+	if got := dst.Row(2); got != " | " {
+		t.Errorf("animated row 2 = %q, expected the second frame", got)
+	}
+}
+
+func TestSpriteWideAndTransparentArt(t *testing.T) {
+	s, err := NewSprite(SpriteSpec{
+		Transparent: '.',
+		Frames:      []SpriteFrame{{Art: []string{".猫.", "a b"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewSprite() error = %v", err)
+	}
+	if s.Width() != 4 {
+		t.Errorf("Width() = %d, expected 4", s.Width())
+	}
+
+	dst := NewScreen(4, 2)
+	dst.Fill('#')
+	s.Draw(dst, 0, 0, 0)
+	if got := dst.Row(0); got != "#猫#" {
+		t.Errorf("row 0 = %q, expected %q", got, "#猫#")
+	}
+	if got := dst.Row(1); !strings.HasPrefix(got, "a b") {
+		t.Errorf("row 1 = %q: spaces should be opaque when another character is transparent", got)
+	}
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// colorKindRole marks a semantic role color; the low byte is the role number.
+const colorKindRole Color = 3 << 24
+
+// Semantic color roles.
+// Games draw with roles instead of concrete colors, and the active Theme maps
+// each role to a real color when the screen is rendered.
+const (
+	RolePlayer   Color = colorKindRole + iota // The player's avatar (bird, dino, snake, left paddle)
+	RoleOpponent                              // The other side (right paddle, CPU)
+	RoleBall                                  // Balls and projectiles
+	RoleHazard                                // Things that end the run (pipes, cacti)
+	RolePickup                                // Things worth collecting (food, power-ups)
+	RoleWall                                  // Solid walls and indestructible bricks
+	RoleGround                                // Floors and ground lines
+	RoleHUD                                   // Score, level and status text
+	RoleAlert                                 // Warnings, lives and harmful effects
+	RoleTitle                                 // Titles and highlighted text
+	RoleMuted                                 // Grid lines, separators, secondary text
+	RoleTier1                                 // Ranked series, lowest (brick rows, tile values)
+	RoleTier2
+	RoleTier3
+	RoleTier4
+	RoleTier5
+	RoleTier6
+	RoleTier7
+	RoleTier8    // Ranked series, highest
+	RoleTierText // Text drawn on top of tier-colored fills
+
+	roleEnd
+)
+
+// roleCount is the number of semantic roles.
+const roleCount = int(roleEnd - colorKindRole)
+
+// roleNames are the names roles use in theme files, indexed by role number.
+var roleNames = [roleCount]string{
+	"player", "opponent", "ball", "hazard", "pickup", "wall", "ground",
+	"hud", "alert", "title", "muted",
+	"tier1", "tier2", "tier3", "tier4", "tier5", "tier6", "tier7", "tier8",
+	"tier_text",
+}
+
+// classicColors are the colors the arcade used before themes existed.
+var classicColors = [roleCount]Color{
+	ColorYellow, ColorMagenta, ColorBrightWhite, ColorGreen, ColorRed, ColorWhite, ColorOrange,
+	ColorCyan, ColorBrightRed, ColorBrightYellow, ColorGray,
+	ColorRed, ColorOrange, ColorYellow, ColorGreen, ColorCyan, ColorBlue, ColorMagenta, ColorBrightRed,
+	ColorBlack,
+}
+
+// IsRole reports whether c is a semantic role rather than a concrete color.
+func (c Color) IsRole() bool {
+	return c&colorKindMask == colorKindRole && c < roleEnd
+}
+
+// RoleName returns the theme file name of a role, or "" if c is not a role.
+func (c Color) RoleName() string {
+	if !c.IsRole() {
+		return ""
+	}
+	return roleNames[c-colorKindRole]
+}
+
+// Roles returns all semantic roles in display order.
+func Roles() []Color {
+	roles := make([]Color, 0, roleCount)
+	for c := colorKindRole; c < roleEnd; c++ {
+		roles = append(roles, c)
+	}
+	return roles
+}
+
+// RoleByName returns the role with the given theme file name.
+func RoleByName(name string) (Color, bool) {
+	for i, n := range roleNames {
+		if n == name {
+			return colorKindRole + Color(i), true //nolint:gosec // i < roleCount
+		}
+	}
+	return ColorDefault, false
+}
+
+// Theme maps semantic roles to concrete colors.
+type Theme struct {
+	Name        string
+	Description string
+
+	// Monochrome themes render without any color, as if NO_COLOR were set.
+	Monochrome bool
+
+	colors [roleCount]Color
+}
+
+// NewTheme creates a theme whose roles start out with the classic colors.
+func NewTheme(name string) *Theme {
+	return &Theme{Name: name, colors: classicColors}
+}
+
+// defaultTheme is the theme used when none is selected.
+var defaultTheme = &Theme{
+	Name:        "classic",
+	Description: "The original arcade colors",
+	colors:      classicColors,
+}
+
+// DefaultTheme returns the classic theme. It must not be modified.
+func DefaultTheme() *Theme {
+	return defaultTheme
+}
+
+// Set maps a role to a color. Non-role colors are ignored.
+func (t *Theme) Set(role, c Color) {
+	if role.IsRole() && !c.IsRole() {
+		t.colors[role-colorKindRole] = c
+	}
+}
+
+// Resolve returns the concrete color for c.
+// Roles are looked up in the theme; other colors are returned unchanged.
+// A nil theme resolves with the default theme.
+func (t *Theme) Resolve(c Color) Color {
+	if !c.IsRole() {
+		return c
+	}
+	if t == nil {
+		t = defaultTheme
+	}
+	return t.colors[c-colorKindRole]
+}
+
+// colorNames maps the names accepted by ParseColor to the predefined colors.
+var colorNames = map[string]Color{
+	"default":        ColorDefault,
+	"black":          ColorBlack,
+	"red":            ColorRed,
+	"green":          ColorGreen,
+	"yellow":         ColorYellow,
+	"blue":           ColorBlue,
+	"magenta":        ColorMagenta,
+	"cyan":           ColorCyan,
+	"white":          ColorWhite,
+	"bright-red":     ColorBrightRed,
+	"bright-green":   ColorBrightGreen,
+	"bright-yellow":  ColorBrightYellow,
+	"bright-blue":    ColorBrightBlue,
+	"bright-magenta": ColorBrightMagenta,
+	"bright-cyan":    ColorBrightCyan,
+	"bright-white":   ColorBrightWhite,
+	"orange":         ColorOrange,
+	"gray":           ColorGray,
+	"grey":           ColorGray,
+}
+
+// ParseColor parses a color name ("bright-red"), a 256-color palette index
+// ("208") or a hex RGB value ("#ff8800").
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if c, ok := colorNames[strings.ReplaceAll(s, "_", "-")]; ok {
+		return c, nil
+	}
+
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) != 6 {
+			return ColorDefault, fmt.Errorf("invalid color %q: expected #rrggbb", s)
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return ColorDefault, fmt.Errorf("invalid color %q: expected #rrggbb", s)
+		}
+		return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil //nolint:gosec // Masked to one byte each
+	}
+
+	if index, err := strconv.ParseUint(s, 10, 8); err == nil {
+		return Color256(uint8(index)), nil
+	}
+
+	return ColorDefault, fmt.Errorf("unknown color %q", s)
+}
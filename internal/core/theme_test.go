@@ -0,0 +1,90 @@
+package core
+
+import "testing"
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Color
+		wantErr bool
+	}{
+		{"red", ColorRed, false},
+		{"Bright-Red", ColorBrightRed, false},
+		{"bright_cyan", ColorBrightCyan, false},
+		{"grey", ColorGray, false},
+		{"default", ColorDefault, false},
+		{"208", Color256(208), false},
+		{"#ff8800", RGB(0xff, 0x88, 0x00), false},
+		{" #00FF00 ", RGB(0, 0xff, 0), false},
+		{"#fff", ColorDefault, true},
+		{"#gggggg", ColorDefault, true},
+		{"256", ColorDefault, true},
+		{"chartreuse", ColorDefault, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseColor(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseColor(%q) = %#x, expected %#x", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleNames(t *testing.T) {
+	for _, role := range Roles() {
+		name := role.RoleName()
+		if name == "" {
+			t.Fatalf("role %#x has no name", role)
+		}
+		got, ok := RoleByName(name)
+		if !ok || got != role {
+			t.Errorf("RoleByName(%q) = (%#x, %v), expected %#x", name, got, ok, role)
+		}
+	}
+
+	if _, ok := RoleByName("nope"); ok {
+		t.Error("RoleByName should reject unknown names")
+	}
+	if ColorRed.IsRole() || ColorRed.RoleName() != "" {
+		t.Error("concrete colors are not roles")
+	}
+}
+
+func TestThemeResolve(t *testing.T) {
+	theme := NewTheme("test")
+	theme.Set(RolePlayer, RGB(1, 2, 3))
+
+	if got := theme.Resolve(RolePlayer); got != RGB(1, 2, 3) {
+		t.Errorf("Resolve(RolePlayer) = %#x, expected the theme color", got)
+	}
+	if got := theme.Resolve(RoleHazard); got != DefaultTheme().Resolve(RoleHazard) {
+		t.Errorf("unset roles should keep the classic color, got %#x", got)
+	}
+	if got := theme.Resolve(ColorCyan); got != ColorCyan {
+		t.Errorf("concrete colors should resolve to themselves, got %#x", got)
+	}
+
+	theme.Set(RoleHUD, RoleTitle)
+	if got := theme.Resolve(RoleHUD); got.IsRole() {
+		t.Error("roles must not map to other roles")
+	}
+
+	var nilTheme *Theme
+	if got := nilTheme.Resolve(RolePickup); got != ColorRed {
+		t.Errorf("nil theme should resolve with the classic theme, got %#x", got)
+	}
+}
+
+func TestDownsampleResolvesRoles(t *testing.T) {
+	if got := RolePickup.Downsample(ProfileANSI256); got != Color256(1) {
+		t.Errorf("Downsample(RolePickup) = %#x, expected the classic red", got)
+	}
+	if got := RolePickup.Downsample(ProfileMono); got != ColorDefault {
+		t.Errorf("Downsample(RolePickup, mono) = %#x, expected default", got)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import "time"
+
+// Timestep turns elapsed wall-clock time into fixed-length simulation steps.
+//
+// Elapsed time is added to an accumulator and consumed one step at a time, so a
+// game advances at its tick rate no matter how often the platform wakes up or
+// how long frames take to draw. After a stall the missed steps are run in a
+// burst to catch up; stalls longer than the maximum lag are dropped instead, so
+// a suspended process doesn't fast-forward through minutes of gameplay.
+type Timestep struct {
+	step        time.Duration
+	maxLag      time.Duration
+	accumulator time.Duration
+	last        time.Time
+}
+
+// NewTimestep creates a timestep for the given ticks per second.
+// maxLag bounds how much elapsed time a single Advance catches up on.
+func NewTimestep(tickRate int, maxLag time.Duration) *Timestep {
+	step := time.Second / time.Duration(max(1, tickRate))
+	return &Timestep{
+		step:   step,
+		maxLag: max(maxLag, step),
+	}
+}
+
+// Reset starts counting from now with no steps due.
+func (t *Timestep) Reset(now time.Time) {
+	t.accumulator = 0
+	t.last = now
+}
+
+// Advance adds the time elapsed since the last call and returns how many
+// steps are due. The remainder carries over to the next call.
+func (t *Timestep) Advance(now time.Time) int {
+	if t.last.IsZero() {
+		t.Reset(now)
+		return 0
+	}
+
+	elapsed := now.Sub(t.last)
+	t.last = now
+	if elapsed <= 0 {
+		return 0
+	}
+	t.accumulator = min(t.accumulator+elapsed, t.maxLag)
+
+	steps := int(t.accumulator / t.step)
+	t.accumulator -= time.Duration(steps) * t.step
+	return steps
+}
+
+// Step returns the length of one simulation step.
+func (t *Timestep) Step() time.Duration {
+	return t.step
+}
+
+// UntilNext returns how long after the last Advance the next step is due.
+func (t *Timestep) UntilNext() time.Duration {
+	return t.step - t.accumulator
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestepSteadyRate(t *testing.T) {
+	ts := NewTimestep(60, time.Second)
+	now := time.Unix(0, 0)
+	ts.Reset(now)
+
+	// Uneven wake-ups still add up to the tick rate
+	wakeups := []time.Duration{5, 30, 11, 16, 17, 21}
+	total := 0
+	elapsed := time.Duration(0)
+	for elapsed < time.Second {
+		d := wakeups[total%len(wakeups)] * time.Millisecond
+		elapsed += d
+		now = now.Add(d)
+		total += ts.Advance(now)
+	}
+	if total < 60 || total > 61 {
+		t.Errorf("steps after %v = %d, expected 60", elapsed, total)
+	}
+}
+
+func TestTimestepCarriesRemainder(t *testing.T) {
+	ts := NewTimestep(10, time.Second) // 100ms steps
+	now := time.Unix(0, 0)
+	ts.Reset(now)
+
+	if got := ts.Advance(now.Add(150 * time.Millisecond)); got != 1 {
+		t.Errorf("Advance(150ms) = %d, expected 1", got)
+	}
+	if got := ts.UntilNext(); got != 50*time.Millisecond {
+		t.Errorf("UntilNext() = %v, expected 50ms", got)
+	}
+	if got := ts.Advance(now.Add(200 * time.Millisecond)); got != 1 {
+		t.Errorf("Advance(200ms) = %d, expected 1", got)
+	}
+}
+
+func TestTimestepCatchUp(t *testing.T) {
+	ts := NewTimestep(60, 250*time.Millisecond)
+	now := time.Unix(0, 0)
+	ts.Reset(now)
+
+	// A short stall is made up in one burst
+	if got := ts.Advance(now.Add(100 * time.Millisecond)); got != 6 {
+		t.Errorf("steps after a 100ms stall = %d, expected 6", got)
+	}
+
+	// A long stall only catches up on maxLag
+	now = now.Add(100 * time.Millisecond)
+	if got := ts.Advance(now.Add(10 * time.Second)); got != 15 {
+		t.Errorf("steps after a 10s stall = %d, expected 15", got)
+	}
+}
+
+func TestTimestepFirstAdvance(t *testing.T) {
+	ts := NewTimestep(60, time.Second)
+	now := time.Unix(100, 0)
+
+	// Without Reset the first call only starts the clock
+	if got := ts.Advance(now); got != 0 {
+		t.Errorf("first Advance() = %d, expected 0", got)
+	}
+	if got := ts.Advance(now.Add(-time.Second)); got != 0 {
+		t.Errorf("Advance() back in time = %d, expected 0", got)
+	}
+	if got := ts.Step(); got != time.Second/60 {
+		t.Errorf("Step() = %v, expected %v", got, time.Second/60)
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Display widths follow the terminal convention: most characters take one
+// column, East Asian wide characters and emoji take two, and combining marks
+// and control characters take none. Ambiguous-width characters (box drawing,
+// block elements) count as one column.
+
+// RuneWidth returns the number of columns a rune takes on its own (0, 1 or 2).
+func RuneWidth(r rune) int {
+	if r >= 0x20 && r < 0x300 {
+		return 1 // Latin text before the combining marks, the common case
+	}
+	return uniseg.StringWidth(string(r))
+}
+
+// StringWidth returns the number of columns text takes when drawn.
+func StringWidth(text string) int {
+	if isPrintableASCII(text) {
+		return len(text)
+	}
+	return uniseg.StringWidth(text)
+}
+
+// TruncateWidth shortens text to at most width columns without splitting
+// characters. A wide character that doesn't fit is dropped.
+func TruncateWidth(text string, width int) string {
+	if isPrintableASCII(text) {
+		return text[:Clamp(width, 0, len(text))]
+	}
+
+	var sb strings.Builder
+	used := 0
+	state := -1
+	for len(text) > 0 {
+		var cluster string
+		var w int
+		cluster, text, w, state = uniseg.FirstGraphemeClusterInString(text, state)
+		if used+w > width {
+			break
+		}
+		sb.WriteString(cluster)
+		used += w
+	}
+	return sb.String()
+}
+
+// isPrintableASCII reports whether every byte of text is a printable ASCII
+// character, so its width is its length.
+func isPrintableASCII(text string) bool {
+	for i := range len(text) {
+		if text[i] < 0x20 || text[i] >= 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// PadWidth appends spaces to text until it is at least width columns wide,
+// like fmt's %-*s but counting columns instead of bytes.
+func PadWidth(text string, width int) string {
+	return text + strings.Repeat(" ", max(0, width-StringWidth(text)))
+}
@@ -0,0 +1,72 @@
+package core
+
+import "testing"
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"Score: 42", 9},
+		{"世界", 4},
+		{"café", 4},
+		{"cafe\u0301", 4}, // Combining accent
+		{"👍", 2},
+		{"👩\u200d🚀", 2}, // ZWJ sequence
+		{"█▀─│", 4},     // Ambiguous-width glyphs count as one column
+		{"日本語 ok", 9},
+	}
+	for _, tt := range tests {
+		if got := StringWidth(tt.text); got != tt.expected {
+			t.Errorf("StringWidth(%q) = %d, expected %d", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		r        rune
+		expected int
+	}{
+		{'a', 1},
+		{'█', 1},
+		{'世', 2},
+		{'\u0301', 0},
+		{'\n', 0},
+	}
+	for _, tt := range tests {
+		if got := RuneWidth(tt.r); got != tt.expected {
+			t.Errorf("RuneWidth(%q) = %d, expected %d", tt.r, got, tt.expected)
+		}
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{"hello", 3, "hel"},
+		{"hello", 10, "hello"},
+		{"hello", -1, ""},
+		{"世界", 3, "世"}, // The second character doesn't fit
+		{"世界", 4, "世界"},
+		{"e\u0301e\u0301", 1, "e\u0301"},
+	}
+	for _, tt := range tests {
+		if got := TruncateWidth(tt.text, tt.width); got != tt.expected {
+			t.Errorf("TruncateWidth(%q, %d) = %q, expected %q", tt.text, tt.width, got, tt.expected)
+		}
+	}
+}
+
+func TestPadWidth(t *testing.T) {
+	if got := PadWidth("世", 4); got != "世  " {
+		t.Errorf("PadWidth(世, 4) = %q", got)
+	}
+	if got := PadWidth("toolong", 3); got != "toolong" {
+		t.Errorf("PadWidth should not truncate, got %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package breakout
+
+import "github.com/vovakirdan/tui-arcade/internal/core"
+
+// Autopilot returns the input of the built-in player for the next step. It
+// launches the ball right away and keeps the paddle under where the lowest
+// falling ball will come down, wall bounces included. The ball is met off
+// center, on a side that changes with the score, so returns don't repeat.
+func (g *Game) Autopilot() core.InputFrame {
+	in := core.NewInputFrame()
+	switch g.state {
+	case StateServe:
+		in.Set(core.ActionJump)
+		return in
+	case StatePlaying:
+	default:
+		return in
+	}
+
+	var target *Ball
+	for _, ball := range g.balls {
+		if !ball.Active || ball.Stuck {
+			continue
+		}
+		if target == nil || (ball.VY > 0 && (target.VY <= 0 || ball.Y > target.Y)) {
+			target = ball
+		}
+	}
+	if target == nil {
+		return in
+	}
+
+	x := target.X
+	if target.VY > 0 {
+		ticks := int(ToFixed(g.paddle.Y).Sub(target.Y) / target.VY)
+		x = reflectX(target.X.Add(target.VX.Mul(max(ticks, 0))), ToFixed(1), ToFixed(g.runtime.ScreenW-2))
+	}
+	offset := ToFixed(g.paddle.Width).Div(4)
+	if g.score/10%2 == 0 {
+		offset = -offset
+	}
+	x = x.Add(offset)
+
+	speed := Fixed(g.cfg.Physics.PaddleSpeed)
+	switch diff := x.Sub(g.paddle.CenterX()); {
+	case diff < -speed:
+		in.SetHeld(core.ActionLeft)
+	case diff > speed:
+		in.SetHeld(core.ActionRight)
+	}
+	return in
+}
+
+// reflectX folds a free-flight X coordinate back into [left, right] as if
+// it bounced off the side walls.
+func reflectX(x, left, right Fixed) Fixed {
+	span := right - left
+	if span <= 0 {
+		return left
+	}
+	pos := (x - left) % (2 * span)
+	if pos < 0 {
+		pos += 2 * span
+	}
+	if pos > span {
+		pos = 2*span - pos
+	}
+	return left + pos
+}
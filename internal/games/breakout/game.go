@@ -35,7 +35,6 @@ const (
 	StatePlaying  = "playing"  // Ball in play
 	StateGameOver = "gameover" // No lives left
 	StateWin      = "win"      // All levels completed (campaign only)
-	StatePaused   = "paused"   // Game paused
 )
 
 // GameMode represents the game mode.
@@ -49,9 +48,6 @@ const (
 // configPath stores the custom config path set via CLI
 var configPath string
 
-// difficultyPreset stores the difficulty preset set via CLI
-var difficultyPreset config.DifficultyPreset
-
 // selectedStartLevel stores the starting level (1-10, 0 means default)
 var selectedStartLevel int
 
@@ -60,22 +56,6 @@ func SetConfigPath(path string) {
 	configPath = path
 }
 
-// SetDifficultyPreset sets the difficulty preset.
-func SetDifficultyPreset(preset string) {
-	switch preset {
-	case "easy":
-		difficultyPreset = config.DifficultyEasy
-	case "normal":
-		difficultyPreset = config.DifficultyNormal
-	case "hard":
-		difficultyPreset = config.DifficultyHard
-	case "fixed":
-		difficultyPreset = config.DifficultyFixed
-	default:
-		difficultyPreset = ""
-	}
-}
-
 // SetStartLevel sets the starting level (1-10). 0 means start from beginning.
 func SetStartLevel(level int) {
 	selectedStartLevel = level
@@ -114,6 +94,7 @@ type Game struct {
 	// Configuration
 	runtime    core.RuntimeConfig
 	cfg        config.BreakoutConfig
+	preset     config.DifficultyPreset // From SetDifficulty; empty uses cfg's difficulty
 	difficulty *config.DifficultyManager
 
 	// Layout (computed from screen size)
@@ -136,6 +117,12 @@ func NewEndless() *Game {
 	return &Game{mode: ModeEndless}
 }
 
+// SetDifficulty selects a difficulty preset by name for this game, taking
+// effect on the next Reset. Empty or unknown names keep the config's.
+func (g *Game) SetDifficulty(preset string) {
+	g.preset = config.ParseDifficultyPreset(preset)
+}
+
 // ID returns the unique identifier for this game.
 func (g *Game) ID() string {
 	if g.mode == ModeEndless {
@@ -152,6 +139,34 @@ func (g *Game) Title() string {
 	return "Breakout"
 }
 
+// Help explains how Breakout is played.
+func (g *Game) Help() registry.Help {
+	objective := "Bounce the ball off your paddle to break the bricks. Clear every brick to finish a level; a ball lost past the paddle costs a life."
+	if g.mode == ModeEndless {
+		objective = "Bounce the ball off your paddle to break the bricks. Levels keep coming until the last life is lost."
+	}
+	legend := []registry.HelpSymbol{
+		{Glyph: PaddleChar, Color: core.RolePlayer, Text: "Your paddle"},
+		{Glyph: BallChar, Color: core.RoleBall, Text: "Ball"},
+		{Glyph: BrickGlyphs[0], Color: brickRowColors[0], Text: "Brick, breaks in one hit"},
+		{Glyph: HardBrickGlyph, Color: core.RoleWall, Text: "Hard brick, takes two hits"},
+		{Glyph: SolidBrickGlyph, Color: core.RoleWall, Text: "Solid brick, can't be broken"},
+	}
+	for t := range PickupCount {
+		legend = append(legend, registry.HelpSymbol{Glyph: t.Glyph(), Color: pickupColor(t), Text: t.Effect()})
+	}
+	return registry.Help{
+		Objective: objective,
+		Controls: []registry.HelpControl{
+			{Action: core.ActionLeft, Text: "Move paddle left"},
+			{Action: core.ActionRight, Text: "Move paddle right"},
+			{Action: core.ActionJump, Text: "Launch the ball"},
+			{Keys: "Mouse", Text: "Paddle follows the pointer, click to launch"},
+		},
+		Legend: legend,
+	}
+}
+
 // Reset initializes or restarts the game.
 func (g *Game) Reset(runtime core.RuntimeConfig) {
 	g.runtime = runtime
@@ -163,8 +178,8 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	}
 
 	// Apply difficulty preset if set
-	if difficultyPreset != "" {
-		config.ApplyBreakoutPreset(&cfg, difficultyPreset)
+	if g.preset != "" {
+		config.ApplyBreakoutPreset(&cfg, g.preset)
 	}
 
 	g.cfg = cfg
@@ -301,17 +316,8 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 		return core.StepResult{State: g.State()}
 	}
 
-	// Handle pause toggle
-	if in.Has(core.ActionPause) {
-		if g.state == StatePaused {
-			g.state = StatePlaying
-		} else if g.state == StatePlaying {
-			g.state = StatePaused
-		}
-	}
-
-	// Don't update if paused or game over
-	if g.state == StatePaused || g.state == StateGameOver || g.state == StateWin {
+	// Don't update once the game is over
+	if g.state == StateGameOver || g.state == StateWin {
 		return core.StepResult{State: g.State()}
 	}
 
@@ -351,7 +357,7 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 			}
 		}
 
-		if in.Has(core.ActionJump) { // Space to launch
+		if in.Has(core.ActionJump) || in.Mouse.Pressed { // Space or a click to launch
 			g.launchBalls()
 		}
 		return core.StepResult{State: g.State()}
@@ -367,14 +373,19 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 func (g *Game) updatePaddle(in core.InputFrame) {
 	speed := Fixed(g.cfg.Physics.PaddleSpeed) // Already scaled by 1000 in config
 
-	// A/Left = move left, D/Right = move right
-	if in.Has(core.ActionLeft) {
+	// A/Left = move left, D/Right = move right, every tick while held
+	if in.Held(core.ActionLeft) {
 		g.paddle.X = g.paddle.X.Sub(speed)
 	}
-	if in.Has(core.ActionRight) {
+	if in.Held(core.ActionRight) {
 		g.paddle.X = g.paddle.X.Add(speed)
 	}
 
+	// The paddle jumps to the mouse pointer, centered on it
+	if in.Mouse.Moved {
+		g.paddle.X = ToFixed(in.Mouse.X - g.paddle.Width/2)
+	}
+
 	// Clamp paddle position
 	minX := ToFixed(1)
 	maxX := ToFixed(g.runtime.ScreenW - g.paddle.Width - 1)
@@ -703,12 +714,12 @@ func (g *Game) Render(dst *core.Screen) {
 func (g *Game) renderHUD(dst *core.Screen) {
 	// Score on left with cyan
 	scoreText := fmt.Sprintf("Score: %d", g.score)
-	dst.DrawTextWithColor(1, 0, scoreText, core.ColorCyan)
+	dst.DrawTextWithColor(1, 0, scoreText, core.RoleHUD)
 
 	// Lives in center with red for emphasis
 	livesText := fmt.Sprintf("Lives: %d", g.lives)
 	x := (dst.Width() - len(livesText)) / 2
-	dst.DrawTextWithColor(x, 0, livesText, core.ColorRed)
+	dst.DrawTextWithColor(x, 0, livesText, core.RoleAlert)
 
 	// Level on right with cyan
 	var levelText string
@@ -718,16 +729,16 @@ func (g *Game) renderHUD(dst *core.Screen) {
 	} else {
 		levelText = fmt.Sprintf("Level: %d/%d", g.levelIndex+1, LevelCount())
 	}
-	dst.DrawTextWithColor(dst.Width()-len(levelText)-1, 0, levelText, core.ColorCyan)
+	dst.DrawTextWithColor(dst.Width()-len(levelText)-1, 0, levelText, core.RoleHUD)
 
 	// Effects display (compact) on row 1
 	effectsStr := g.buildEffectsString()
 	if effectsStr != "" {
-		dst.DrawTextWithColor(1, 1, effectsStr, core.ColorYellow)
+		dst.DrawTextWithColor(1, 1, effectsStr, core.RolePickup)
 	} else {
 		// Separator line if no effects
 		for x := range dst.Width() {
-			dst.SetWithColor(x, 1, BorderHoriz, core.ColorGray)
+			dst.SetWithColor(x, 1, BorderHoriz, core.RoleMuted)
 		}
 	}
 }
@@ -752,14 +763,14 @@ func (g *Game) buildEffectsString() string {
 
 // brickRowColors defines colors for brick rows.
 var brickRowColors = []core.Color{
-	core.ColorRed,
-	core.ColorOrange,
-	core.ColorYellow,
-	core.ColorGreen,
-	core.ColorCyan,
-	core.ColorBlue,
-	core.ColorMagenta,
-	core.ColorBrightRed,
+	core.RoleTier1,
+	core.RoleTier2,
+	core.RoleTier3,
+	core.RoleTier4,
+	core.RoleTier5,
+	core.RoleTier6,
+	core.RoleTier7,
+	core.RoleTier8,
 }
 
 // renderBricks draws all alive bricks.
@@ -778,18 +789,21 @@ func (g *Game) renderBricks(dst *core.Screen) {
 			// Get glyph based on brick type
 			var glyph rune
 			var color core.Color
+			background := core.ColorDefault
 			switch brick.Type {
 			case BrickHard:
 				if brick.HP > 1 {
+					// Undamaged hard bricks stand out on a filled background
 					glyph = HardBrickGlyph
-					color = core.ColorBrightWhite
+					color = core.RoleWall
+					background = core.RoleMuted
 				} else {
 					glyph = BrickGlyphs[row%len(BrickGlyphs)]
 					color = brickRowColors[row%len(brickRowColors)]
 				}
 			case BrickSolid:
 				glyph = SolidBrickGlyph
-				color = core.ColorGray
+				color = core.RoleWall
 			default:
 				glyph = BrickGlyphs[row%len(BrickGlyphs)]
 				color = brickRowColors[row%len(brickRowColors)]
@@ -798,7 +812,7 @@ func (g *Game) renderBricks(dst *core.Screen) {
 			// Draw brick with color
 			for dx := range g.brickWidth {
 				if screenX+dx < dst.Width() && screenY < dst.Height() {
-					dst.SetWithColor(screenX+dx, screenY, glyph, color)
+					dst.SetCell(screenX+dx, screenY, core.Cell{Rune: glyph, Color: color, Background: background})
 				}
 			}
 		}
@@ -806,24 +820,13 @@ func (g *Game) renderBricks(dst *core.Screen) {
 }
 
 // pickupColor returns the color for a power-up type.
+// Harmful power-ups are drawn as alerts; each type also has its own glyph.
 func pickupColor(t PickupType) core.Color {
 	switch t {
-	case PickupWiden:
-		return core.ColorGreen
-	case PickupShrink:
-		return core.ColorRed
-	case PickupMultiball:
-		return core.ColorMagenta
-	case PickupSticky:
-		return core.ColorYellow
-	case PickupSpeedUp:
-		return core.ColorOrange
-	case PickupSlowDown:
-		return core.ColorCyan
-	case PickupExtraLife:
-		return core.ColorBrightRed
+	case PickupShrink, PickupSpeedUp:
+		return core.RoleAlert
 	default:
-		return core.ColorWhite
+		return core.RolePickup
 	}
 }
 
@@ -848,7 +851,7 @@ func (g *Game) renderPaddle(dst *core.Screen) {
 	paddleX := g.paddle.CellX()
 	for i := range g.paddle.Width {
 		if paddleX+i < dst.Width() {
-			dst.SetWithColor(paddleX+i, g.paddle.Y, PaddleChar, core.ColorCyan)
+			dst.SetWithColor(paddleX+i, g.paddle.Y, PaddleChar, core.RolePlayer)
 		}
 	}
 }
@@ -864,7 +867,7 @@ func (g *Game) renderBalls(dst *core.Screen) {
 		ballY := ball.CellY()
 
 		if ballX >= 0 && ballX < dst.Width() && ballY >= 0 && ballY < dst.Height() {
-			dst.SetWithColor(ballX, ballY, BallChar, core.ColorBrightWhite)
+			dst.SetWithColor(ballX, ballY, BallChar, core.RoleBall)
 		}
 	}
 }
@@ -879,47 +882,21 @@ func (g *Game) renderOverlay(dst *core.Screen) {
 			dst.DrawTextCentered(dst.Height()-1, "Get ready...")
 		}
 
-	case StatePaused:
-		g.drawCenteredBox(dst, "PAUSED", "Press P to resume")
-
 	case StateGameOver:
 		subtitle := fmt.Sprintf("Score: %d  |  Press R to restart", g.score)
-		g.drawCenteredBox(dst, "GAME OVER", subtitle)
+		dst.DrawMessageBox("GAME OVER", subtitle)
 
 	case StateWin:
 		subtitle := fmt.Sprintf("Final Score: %d  |  Press R to restart", g.score)
-		g.drawCenteredBox(dst, "YOU WIN!", subtitle)
+		dst.DrawMessageBox("YOU WIN!", subtitle)
 	}
 }
 
-// drawCenteredBox draws a centered message box.
-func (g *Game) drawCenteredBox(dst *core.Screen, title, subtitle string) {
-	w := dst.Width()
-	h := dst.Height()
-
-	boxW := core.Max(len(title), len(subtitle)) + 4
-	boxH := 5
-	boxX := (w - boxW) / 2
-	boxY := (h - boxH) / 2
-
-	// Draw box background
-	dst.DrawRect(core.NewRect(boxX, boxY, boxW, boxH), ' ')
-	dst.DrawBox(core.NewRect(boxX, boxY, boxW, boxH))
-
-	// Draw text
-	titleX := boxX + (boxW-len(title))/2
-	dst.DrawText(titleX, boxY+1, title)
-
-	subtitleX := boxX + (boxW-len(subtitle))/2
-	dst.DrawText(subtitleX, boxY+3, subtitle)
-}
-
 // State returns the current game state.
 func (g *Game) State() core.GameState {
 	return core.GameState{
 		Score:    g.score,
 		GameOver: g.state == StateGameOver || g.state == StateWin,
-		Paused:   g.state == StatePaused,
 	}
 }
 
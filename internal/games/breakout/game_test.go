@@ -220,6 +220,87 @@ func TestPaddleMovement(t *testing.T) {
 	}
 }
 
+func TestPaddleMovesWhileHeld(t *testing.T) {
+	cfg := core.RuntimeConfig{
+		ScreenW:  80,
+		ScreenH:  24,
+		TickRate: 60,
+		Seed:     1,
+	}
+
+	g := New()
+	g.Reset(cfg)
+	startX := g.paddle.X
+
+	// Pressed on the first frame, held on the next ones
+	press := core.NewInputFrame()
+	press.Set(core.ActionRight)
+	g.Step(press)
+	step := g.paddle.X - startX
+
+	held := core.NewInputFrame()
+	held.SetHeld(core.ActionRight)
+	for range 3 {
+		g.Step(held)
+	}
+	if expected := startX + 4*step; g.paddle.X != expected {
+		t.Errorf("paddle X after 1 press and 3 held frames = %d, expected %d", g.paddle.X, expected)
+	}
+
+	// Released keys don't move the paddle
+	released := core.NewInputFrame()
+	released.SetReleased(core.ActionRight)
+	x := g.paddle.X
+	g.Step(released)
+	if g.paddle.X != x {
+		t.Errorf("paddle moved from %d to %d on release", x, g.paddle.X)
+	}
+}
+
+func TestPaddleFollowsMouse(t *testing.T) {
+	cfg := core.RuntimeConfig{
+		ScreenW:  80,
+		ScreenH:  24,
+		TickRate: 60,
+		Seed:     1,
+	}
+
+	g := New()
+	g.Reset(cfg)
+
+	in := core.NewInputFrame()
+	in.Mouse = core.MouseState{X: 20, Y: 10, Active: true, Moved: true}
+	g.Step(in)
+	if center := g.paddle.X.ToCell() + g.paddle.Width/2; center != 20 {
+		t.Errorf("paddle center = %d, expected the pointer column 20", center)
+	}
+
+	// A pointer that doesn't move leaves the paddle to the keys
+	in.Mouse.Moved = false
+	in.Set(core.ActionRight)
+	x := g.paddle.X
+	g.Step(in)
+	if g.paddle.X <= x {
+		t.Errorf("paddle X = %d, expected it to move right of %d", g.paddle.X, x)
+	}
+
+	// Past the wall the paddle is clamped
+	in = core.NewInputFrame()
+	in.Mouse = core.MouseState{X: 79, Y: 10, Active: true, Moved: true}
+	g.Step(in)
+	if maxX := ToFixed(cfg.ScreenW - g.paddle.Width - 1); g.paddle.X != maxX {
+		t.Errorf("paddle X = %d, expected the clamped %d", g.paddle.X, maxX)
+	}
+
+	// A click launches the ball
+	in = core.NewInputFrame()
+	in.Mouse = core.MouseState{X: 40, Y: 10, Active: true, Down: true, Pressed: true}
+	g.Step(in)
+	if g.state != StatePlaying {
+		t.Errorf("state after click = %s, expected %s", g.state, StatePlaying)
+	}
+}
+
 func TestPaddleCollision(t *testing.T) {
 	cfg := core.RuntimeConfig{
 		ScreenW:  80,
@@ -349,53 +430,6 @@ func TestBrickCollision(t *testing.T) {
 	}
 }
 
-func TestGamePause(t *testing.T) {
-	cfg := core.RuntimeConfig{
-		ScreenW:  80,
-		ScreenH:  24,
-		TickRate: 60,
-		Seed:     1,
-	}
-
-	g := New()
-	g.Reset(cfg)
-
-	// Launch ball first
-	jumpInput := core.NewInputFrame()
-	jumpInput.Set(core.ActionJump)
-	g.Step(jumpInput)
-
-	// Pause the game
-	pauseInput := core.NewInputFrame()
-	pauseInput.Set(core.ActionPause)
-	g.Step(pauseInput)
-
-	if g.state != StatePaused {
-		t.Errorf("Game should be paused, got %s", g.state)
-	}
-
-	// Record state
-	ball := g.balls[0]
-	ballX := ball.X
-	ballY := ball.Y
-
-	// Step while paused (without pause toggle)
-	noInput := core.NewInputFrame()
-	g.Step(noInput)
-
-	// Ball should not move while paused
-	if ball.X != ballX || ball.Y != ballY {
-		t.Error("Ball position should not change while paused")
-	}
-
-	// Unpause
-	g.Step(pauseInput)
-
-	if g.state == StatePaused {
-		t.Error("Game should be unpaused")
-	}
-}
-
 func TestWallCollision(t *testing.T) {
 	cfg := core.RuntimeConfig{
 		ScreenW:  80,
@@ -811,3 +845,38 @@ func TestEffectDurations(t *testing.T) {
 		t.Errorf("Non-existent effect should return 0, got %d", remaining)
 	}
 }
+
+func TestHelpExplainsPickups(t *testing.T) {
+	help := New().Help()
+
+	for p := range PickupCount {
+		found := false
+		for _, s := range help.Legend {
+			if s.Glyph == p.Glyph() && s.Color == pickupColor(p) {
+				found = true
+				if s.Text == "" {
+					t.Errorf("Pickup %s should have an explanation", p)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Legend should list pickup %s (%c)", p, p.Glyph())
+		}
+	}
+}
+
+func TestAutopilotBreaksBricks(t *testing.T) {
+	g := New()
+	g.Reset(core.RuntimeConfig{ScreenW: 80, ScreenH: 24, TickRate: 60, Seed: 1})
+	lives := g.lives
+
+	for range 60 * 30 {
+		g.Step(g.Autopilot())
+	}
+	if g.lives < lives {
+		t.Errorf("Autopilot should not lose a ball in 30 seconds, lives %d of %d", g.lives, lives)
+	}
+	if g.score == 0 {
+		t.Error("Autopilot should break bricks")
+	}
+}
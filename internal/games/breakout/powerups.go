@@ -36,6 +36,28 @@ func (p PickupType) Glyph() rune {
 	}
 }
 
+// Effect describes what collecting a pickup of the type does.
+func (p PickupType) Effect() string {
+	switch p {
+	case PickupWiden:
+		return "Wider paddle"
+	case PickupShrink:
+		return "Narrower paddle"
+	case PickupMultiball:
+		return "Extra balls"
+	case PickupSticky:
+		return "Balls stick to the paddle until launched"
+	case PickupSpeedUp:
+		return "Faster balls"
+	case PickupSlowDown:
+		return "Slower balls"
+	case PickupExtraLife:
+		return "Extra life"
+	default:
+		return ""
+	}
+}
+
 // String returns the name of the pickup type.
 func (p PickupType) String() string {
 	switch p {
@@ -0,0 +1,25 @@
+package dino
+
+import "github.com/vovakirdan/tui-arcade/internal/core"
+
+// Autopilot returns the input of the built-in player for the next step: it
+// jumps when the next cactus is a few steps away.
+func (g *Game) Autopilot() core.InputFrame {
+	in := core.NewInputFrame()
+	if g.gameOver || !g.isGrounded {
+		return in
+	}
+
+	speed := max(1, int(g.difficulty.Speed(g.cfg.Physics.BaseSpeed, g.score, g.tickCount)))
+	front := g.cfg.Player.X + g.cfg.Player.Width
+	for _, c := range g.obstacles.Cacti() {
+		if c.X+c.Width <= g.cfg.Player.X {
+			continue // Already behind
+		}
+		if gap := c.X - front; gap <= 3*speed {
+			in.Set(core.ActionJump)
+		}
+		break
+	}
+	return in
+}
@@ -12,61 +12,49 @@ import (
 
 // Visual characters for rendering
 const (
-	DinoBody   = '█'
-	DinoHead   = '◆'
-	DinoLeg1   = '╱'
-	DinoLeg2   = '╲'
 	CactusChar = '▓'
 	GroundChar = '═'
 )
 
 // Game implements the Dino Runner game logic.
 type Game struct {
-	playerY    float64            // Player vertical position (relative to ground, negative = up)
-	playerVel  float64            // Player vertical velocity
-	isGrounded bool               // Whether player is on the ground
-	obstacles  *ObstacleManager   // Obstacle manager
-	score      int                // Current score (distance traveled)
-	gameOver   bool               // Whether game has ended
-	paused     bool               // Whether game is paused
-	runtime    core.RuntimeConfig // Runtime config (screen size, tick rate)
-	cfg        config.DinoConfig  // Game-specific config
+	playerY    float64                 // Player vertical position (relative to ground, negative = up)
+	playerVel  float64                 // Player vertical velocity
+	isGrounded bool                    // Whether player is on the ground
+	obstacles  *ObstacleManager        // Obstacle manager
+	score      int                     // Current score (distance traveled)
+	gameOver   bool                    // Whether game has ended
+	runtime    core.RuntimeConfig      // Runtime config (screen size, tick rate)
+	cfg        config.DinoConfig       // Game-specific config
+	preset     config.DifficultyPreset // From SetDifficulty; empty uses cfg's difficulty
 	difficulty *config.DifficultyManager
 	tickCount  int // Number of ticks since start
 	groundY    int // Y position of ground line
 	legFrame   int // Animation frame for running legs
+
+	runSprite  *core.Sprite // Dino on the ground, animated by legFrame
+	jumpSprite *core.Sprite // Dino in the air
 }
 
 // configPath stores the custom config path set via CLI
 var configPath string
-var difficultyPreset config.DifficultyPreset
 
 // SetConfigPath sets the custom config path for loading.
 func SetConfigPath(path string) {
 	configPath = path
 }
 
-// SetDifficultyPreset sets the difficulty preset.
-func SetDifficultyPreset(preset string) {
-	switch preset {
-	case "easy":
-		difficultyPreset = config.DifficultyEasy
-	case "normal":
-		difficultyPreset = config.DifficultyNormal
-	case "hard":
-		difficultyPreset = config.DifficultyHard
-	case "fixed":
-		difficultyPreset = config.DifficultyFixed
-	default:
-		difficultyPreset = "" // Use config default
-	}
-}
-
 // New creates a new Dino Runner game instance.
 func New() *Game {
 	return &Game{}
 }
 
+// SetDifficulty selects a difficulty preset by name for this game, taking
+// effect on the next Reset. Empty or unknown names keep the config's.
+func (g *Game) SetDifficulty(preset string) {
+	g.preset = config.ParseDifficultyPreset(preset)
+}
+
 // ID returns the unique identifier for this game.
 func (g *Game) ID() string {
 	return "dino"
@@ -77,6 +65,16 @@ func (g *Game) Title() string {
 	return "Dino Runner"
 }
 
+// Help explains how Dino Runner is played.
+func (g *Game) Help() registry.Help {
+	return registry.Help{
+		Objective: "Run as far as you can and jump over the cacti. The score grows with the distance, and so does the speed.",
+		Controls: []registry.HelpControl{
+			{Action: core.ActionJump, Text: "Jump (hold to keep jumping)"},
+		},
+	}
+}
+
 // Reset initializes or restarts the game.
 func (g *Game) Reset(runtime core.RuntimeConfig) {
 	g.runtime = runtime
@@ -88,12 +86,16 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	}
 
 	// Apply difficulty preset if set
-	if difficultyPreset != "" {
-		config.ApplyDinoPreset(&cfg, difficultyPreset)
+	if g.preset != "" {
+		config.ApplyDinoPreset(&cfg, g.preset)
 	}
 
 	g.cfg = cfg
 
+	// Load sprites; a broken asset pack override falls back to the built-in look
+	g.runSprite = config.MustLoadSprite("dino-run")
+	g.jumpSprite = config.MustLoadSprite("dino-jump")
+
 	// Initialize difficulty manager
 	g.difficulty = config.NewDifficultyManager(cfg.Difficulty)
 
@@ -104,7 +106,6 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	g.isGrounded = true
 	g.score = 0
 	g.gameOver = false
-	g.paused = false
 	g.tickCount = 0
 	g.legFrame = 0
 
@@ -124,20 +125,11 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 		return core.StepResult{State: g.State()}
 	}
 
-	// Handle pause toggle
-	if in.Has(core.ActionPause) {
-		g.paused = !g.paused
-	}
-
-	if g.paused {
-		return core.StepResult{State: g.State()}
-	}
-
 	g.tickCount++
 	g.legFrame = (g.legFrame + 1) % 10 // Animation cycle
 
-	// Handle jump input (only when grounded)
-	if in.Has(core.ActionJump) && g.isGrounded {
+	// Handle jump input (only when grounded); holding jump keeps jumping
+	if in.Held(core.ActionJump) && g.isGrounded {
 		g.playerVel = g.cfg.Physics.JumpImpulse
 		g.isGrounded = false
 	}
@@ -187,7 +179,7 @@ func (g *Game) Render(dst *core.Screen) {
 
 	// Draw ground with gray
 	for x := range dst.Width() {
-		dst.SetWithColor(x, g.groundY, GroundChar, core.ColorGray)
+		dst.SetWithColor(x, g.groundY, GroundChar, core.RoleGround)
 	}
 
 	// Draw obstacles
@@ -200,60 +192,29 @@ func (g *Game) Render(dst *core.Screen) {
 
 	// Draw HUD with cyan
 	scoreText := fmt.Sprintf(" Score: %d ", g.score)
-	dst.DrawTextWithColor(2, 0, scoreText, core.ColorCyan)
+	dst.DrawTextWithColor(2, 0, scoreText, core.RoleHUD)
 
 	// Show difficulty level if progression is enabled
 	if g.difficulty.IsEnabled() {
 		speed := g.difficulty.Speed(g.cfg.Physics.BaseSpeed, g.score, g.tickCount)
 		levelText := fmt.Sprintf(" Spd: %.1f ", speed)
-		dst.DrawTextWithColor(dst.Width()-len(levelText)-2, 0, levelText, core.ColorCyan)
-	}
-
-	if g.paused {
-		g.drawCenteredMessage(dst, "PAUSED", "Press P to resume")
+		dst.DrawTextWithColor(dst.Width()-len(levelText)-2, 0, levelText, core.RoleHUD)
 	}
 
 	if g.gameOver {
-		g.drawCenteredMessage(dst, "GAME OVER", fmt.Sprintf("Score: %d  |  Press R to restart", g.score))
+		dst.DrawMessageBox("GAME OVER", fmt.Sprintf("Score: %d  |  Press R to restart", g.score))
 	}
 }
 
-// drawDino renders the player character with green color.
+// drawDino renders the player character from its sprites.
 func (g *Game) drawDino(dst *core.Screen) {
 	// Player Y is relative to ground (negative = above ground)
 	baseY := g.groundY - g.cfg.Player.Height - int(-g.playerY)
-	playerX := g.cfg.Player.X
-
-	// Simple dino sprite (3x3)
-	//  ◆█
-	// ███
-	// ╱╲
 
-	// Head and body
-	dst.SetWithColor(playerX+1, baseY, DinoHead, core.ColorGreen)
-	dst.SetWithColor(playerX+2, baseY, DinoBody, core.ColorGreen)
-
-	// Body
-	dst.SetWithColor(playerX, baseY+1, DinoBody, core.ColorGreen)
-	dst.SetWithColor(playerX+1, baseY+1, DinoBody, core.ColorGreen)
-	dst.SetWithColor(playerX+2, baseY+1, DinoBody, core.ColorGreen)
-
-	// Legs (animated when grounded)
 	if g.isGrounded {
-		if g.legFrame < 5 {
-			dst.SetWithColor(playerX, baseY+2, DinoLeg1, core.ColorGreen)
-			dst.Set(playerX+1, baseY+2, ' ')
-			dst.SetWithColor(playerX+2, baseY+2, DinoLeg2, core.ColorGreen)
-		} else {
-			dst.Set(playerX, baseY+2, ' ')
-			dst.SetWithColor(playerX+1, baseY+2, DinoLeg1, core.ColorGreen)
-			dst.SetWithColor(playerX+2, baseY+2, DinoLeg2, core.ColorGreen)
-		}
+		g.runSprite.DrawAnimated(dst, g.cfg.Player.X, baseY, g.legFrame)
 	} else {
-		// In air - legs tucked
-		dst.SetWithColor(playerX, baseY+2, DinoLeg1, core.ColorGreen)
-		dst.SetWithColor(playerX+1, baseY+2, DinoLeg2, core.ColorGreen)
-		dst.Set(playerX+2, baseY+2, ' ')
+		g.jumpSprite.Draw(dst, g.cfg.Player.X, baseY, 0)
 	}
 }
 
@@ -262,40 +223,16 @@ func (g *Game) drawCactus(dst *core.Screen, c Cactus) {
 	for dy := range c.Height {
 		for dx := range c.Width {
 			y := g.groundY - c.Height + dy
-			dst.SetWithColor(c.X+dx, y, CactusChar, core.ColorBrightGreen)
+			dst.SetWithColor(c.X+dx, y, CactusChar, core.RoleHazard)
 		}
 	}
 }
 
-// drawCenteredMessage draws a message box in the center of the screen.
-func (g *Game) drawCenteredMessage(dst *core.Screen, title, subtitle string) {
-	w := dst.Width()
-	h := dst.Height()
-
-	// Calculate box dimensions
-	boxW := core.Max(len(title), len(subtitle)) + 4
-	boxH := 5
-	boxX := (w - boxW) / 2
-	boxY := (h - boxH) / 2
-
-	// Draw box
-	dst.DrawRect(core.NewRect(boxX, boxY, boxW, boxH), ' ')
-	dst.DrawBox(core.NewRect(boxX, boxY, boxW, boxH))
-
-	// Draw text
-	titleX := boxX + (boxW-len(title))/2
-	dst.DrawText(titleX, boxY+1, title)
-
-	subtitleX := boxX + (boxW-len(subtitle))/2
-	dst.DrawText(subtitleX, boxY+3, subtitle)
-}
-
 // State returns the current game state.
 func (g *Game) State() core.GameState {
 	return core.GameState{
 		Score:    g.score,
 		GameOver: g.gameOver,
-		Paused:   g.paused,
 	}
 }
 
@@ -0,0 +1,44 @@
+package flappy
+
+import "github.com/vovakirdan/tui-arcade/internal/core"
+
+// Autopilot returns the input of the built-in player for the next step. It
+// lets the bird fall until a flap would carry it up to the top of the next
+// gap, and flaps before it would drop below the gap.
+func (g *Game) Autopilot() core.InputFrame {
+	in := core.NewInputFrame()
+	if g.gameOver {
+		return in
+	}
+	if g.waiting {
+		in.Set(core.ActionJump)
+		return in
+	}
+
+	// The gap of the first pipe the bird hasn't cleared, or the middle of
+	// the screen before one shows up
+	height := float64(g.cfg.Player.Height)
+	top := float64(g.runtime.ScreenH) / 3
+	bottom := top * 2
+	for _, p := range g.pipes.Pipes() {
+		if p.X+g.cfg.Obstacles.PipeWidth >= g.cfg.Player.X {
+			top, bottom = float64(p.GapY), float64(p.GapY+p.GapHeight)
+			break
+		}
+	}
+
+	next := g.playerY + min(g.playerVel+g.cfg.Physics.Gravity, g.cfg.Physics.MaxFallSpeed)
+	if next-g.flapRise() > top+0.5 || next+height > bottom-0.5 {
+		in.Set(core.ActionJump)
+	}
+	return in
+}
+
+// flapRise returns how far a flap lifts the bird before it falls again.
+func (g *Game) flapRise() float64 {
+	rise := 0.0
+	for v := g.cfg.Physics.JumpImpulse + g.cfg.Physics.Gravity; v < 0; v += g.cfg.Physics.Gravity {
+		rise -= v
+	}
+	return rise
+}
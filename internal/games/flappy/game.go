@@ -12,7 +12,6 @@ import (
 
 // Visual characters for rendering
 const (
-	PlayerChar    = '▶'
 	PipeChar      = '█'
 	PipeCapTop    = '▄'
 	PipeCapBottom = '▀'
@@ -21,49 +20,42 @@ const (
 
 // Game implements the Flappy Bird game logic.
 type Game struct {
-	playerY    float64             // Player vertical position (top of hitbox)
-	playerVel  float64             // Player vertical velocity
-	pipes      *PipeManager        // Obstacle manager
-	score      int                 // Current score
-	gameOver   bool                // Whether game has ended
-	paused     bool                // Whether game is paused
-	waiting    bool                // Waiting for first input to start
-	runtime    core.RuntimeConfig  // Runtime config (screen size, tick rate)
-	cfg        config.FlappyConfig // Game-specific config
+	playerY    float64                 // Player vertical position (top of hitbox)
+	playerVel  float64                 // Player vertical velocity
+	pipes      *PipeManager            // Obstacle manager
+	score      int                     // Current score
+	gameOver   bool                    // Whether game has ended
+	waiting    bool                    // Waiting for first input to start
+	runtime    core.RuntimeConfig      // Runtime config (screen size, tick rate)
+	cfg        config.FlappyConfig     // Game-specific config
+	preset     config.DifficultyPreset // From SetDifficulty; empty uses cfg's difficulty
 	difficulty *config.DifficultyManager
 	tickCount  int // Number of ticks since start
+	wingTick   int // Ticks of wing animation, including while waiting
+
+	flapSprite  *core.Sprite // Bird flapping while rising or waiting
+	glideSprite *core.Sprite // Bird gliding down
 }
 
 // configPath stores the custom config path set via CLI
 var configPath string
-var difficultyPreset config.DifficultyPreset
 
 // SetConfigPath sets the custom config path for loading.
 func SetConfigPath(path string) {
 	configPath = path
 }
 
-// SetDifficultyPreset sets the difficulty preset.
-func SetDifficultyPreset(preset string) {
-	switch preset {
-	case "easy":
-		difficultyPreset = config.DifficultyEasy
-	case "normal":
-		difficultyPreset = config.DifficultyNormal
-	case "hard":
-		difficultyPreset = config.DifficultyHard
-	case "fixed":
-		difficultyPreset = config.DifficultyFixed
-	default:
-		difficultyPreset = "" // Use config default
-	}
-}
-
 // New creates a new Flappy Bird game instance.
 func New() *Game {
 	return &Game{}
 }
 
+// SetDifficulty selects a difficulty preset by name for this game, taking
+// effect on the next Reset. Empty or unknown names keep the config's.
+func (g *Game) SetDifficulty(preset string) {
+	g.preset = config.ParseDifficultyPreset(preset)
+}
+
 // ID returns the unique identifier for this game.
 func (g *Game) ID() string {
 	return "flappy"
@@ -74,6 +66,16 @@ func (g *Game) Title() string {
 	return "Flappy Bird"
 }
 
+// Help explains how Flappy Bird is played.
+func (g *Game) Help() registry.Help {
+	return registry.Help{
+		Objective: "Flap through the gaps between the pipes. Each pipe passed scores a point; touching a pipe, the ground or the ceiling ends the game.",
+		Controls: []registry.HelpControl{
+			{Action: core.ActionJump, Text: "Flap"},
+		},
+	}
+}
+
 // Reset initializes or restarts the game.
 func (g *Game) Reset(runtime core.RuntimeConfig) {
 	g.runtime = runtime
@@ -86,12 +88,16 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	}
 
 	// Apply difficulty preset if set
-	if difficultyPreset != "" {
-		config.ApplyFlappyPreset(&cfg, difficultyPreset)
+	if g.preset != "" {
+		config.ApplyFlappyPreset(&cfg, g.preset)
 	}
 
 	g.cfg = cfg
 
+	// Load sprites; a broken asset pack override falls back to the built-in look
+	g.flapSprite = config.MustLoadSprite("flappy-flap")
+	g.glideSprite = config.MustLoadSprite("flappy-glide")
+
 	// Initialize difficulty manager
 	g.difficulty = config.NewDifficultyManager(cfg.Difficulty)
 
@@ -100,9 +106,9 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	g.playerVel = 0
 	g.score = 0
 	g.gameOver = false
-	g.paused = false
 	g.waiting = true
 	g.tickCount = 0
+	g.wingTick = 0
 
 	// Initialize pipe manager
 	if g.pipes == nil {
@@ -122,6 +128,7 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 
 	// Wait for first input to start the game
 	if g.waiting {
+		g.wingTick++
 		if in.Has(core.ActionJump) {
 			g.waiting = false
 			g.playerVel = g.cfg.Physics.JumpImpulse // First jump
@@ -129,16 +136,8 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 		return core.StepResult{State: g.State()}
 	}
 
-	// Handle pause toggle
-	if in.Has(core.ActionPause) {
-		g.paused = !g.paused
-	}
-
-	if g.paused {
-		return core.StepResult{State: g.State()}
-	}
-
 	g.tickCount++
+	g.wingTick++
 
 	// Handle jump input
 	if in.Has(core.ActionJump) {
@@ -192,7 +191,7 @@ func (g *Game) Render(dst *core.Screen) {
 	// Draw ground with bright yellow
 	groundY := dst.Height() - 1
 	for x := range dst.Width() {
-		dst.SetWithColor(x, groundY, GroundChar, core.ColorBrightYellow)
+		dst.SetWithColor(x, groundY, GroundChar, core.RoleGround)
 	}
 
 	// Draw pipes
@@ -200,39 +199,30 @@ func (g *Game) Render(dst *core.Screen) {
 		g.drawPipe(dst, p)
 	}
 
-	// Draw player (bird) with yellow
-	playerY := int(g.playerY)
-	for dy := range g.cfg.Player.Height {
-		for dx := range g.cfg.Player.Width {
-			if dx == g.cfg.Player.Width-1 && dy == 0 {
-				dst.SetWithColor(g.cfg.Player.X+dx, playerY+dy, PlayerChar, core.ColorYellow)
-			} else {
-				dst.SetWithColor(g.cfg.Player.X+dx, playerY+dy, '●', core.ColorYellow)
-			}
-		}
+	// Draw player (bird), flapping while it rises
+	if g.waiting || g.playerVel < 0 {
+		g.flapSprite.DrawAnimated(dst, g.cfg.Player.X, int(g.playerY), g.wingTick)
+	} else {
+		g.glideSprite.Draw(dst, g.cfg.Player.X, int(g.playerY), 0)
 	}
 
 	// Draw HUD with cyan
 	scoreText := fmt.Sprintf(" Score: %d ", g.score)
-	dst.DrawTextWithColor(2, 0, scoreText, core.ColorCyan)
+	dst.DrawTextWithColor(2, 0, scoreText, core.RoleHUD)
 
 	// Show difficulty level if progression is enabled
 	if g.difficulty.IsEnabled() {
 		level := g.difficulty.Level(g.score, g.tickCount)
 		levelText := fmt.Sprintf(" Lvl: %.0f%% ", level*100)
-		dst.DrawTextWithColor(dst.Width()-len(levelText)-2, 0, levelText, core.ColorCyan)
+		dst.DrawTextWithColor(dst.Width()-len(levelText)-2, 0, levelText, core.RoleHUD)
 	}
 
 	if g.waiting {
-		g.drawCenteredMessage(dst, "FLAPPY BIRD", "Press SPACE to start")
-	}
-
-	if g.paused {
-		g.drawCenteredMessage(dst, "PAUSED", "Press P to resume")
+		dst.DrawMessageBox("FLAPPY BIRD", "Press SPACE to start")
 	}
 
 	if g.gameOver {
-		g.drawCenteredMessage(dst, "GAME OVER", fmt.Sprintf("Score: %d  |  Press R to restart", g.score))
+		dst.DrawMessageBox("GAME OVER", fmt.Sprintf("Score: %d  |  Press R to restart", g.score))
 	}
 }
 
@@ -244,13 +234,13 @@ func (g *Game) drawPipe(dst *core.Screen, p Pipe) {
 	// Draw top section (from top of screen to gap)
 	for y := range p.GapY {
 		for x := range pipeWidth {
-			dst.SetWithColor(p.X+x, y, PipeChar, core.ColorGreen)
+			dst.SetWithColor(p.X+x, y, PipeChar, core.RoleHazard)
 		}
 	}
 	// Cap on top section (at bottom of top section)
 	if p.GapY > 0 {
 		for x := range pipeWidth {
-			dst.SetWithColor(p.X+x, p.GapY-1, PipeCapTop, core.ColorBrightGreen)
+			dst.SetWithColor(p.X+x, p.GapY-1, PipeCapTop, core.RoleHazard)
 		}
 	}
 
@@ -258,46 +248,22 @@ func (g *Game) drawPipe(dst *core.Screen, p Pipe) {
 	bottomY := p.GapY + p.GapHeight
 	for y := bottomY; y < screenH; y++ {
 		for x := range pipeWidth {
-			dst.SetWithColor(p.X+x, y, PipeChar, core.ColorGreen)
+			dst.SetWithColor(p.X+x, y, PipeChar, core.RoleHazard)
 		}
 	}
 	// Cap on bottom section (at top of bottom section)
 	if bottomY < screenH {
 		for x := range pipeWidth {
-			dst.SetWithColor(p.X+x, bottomY, PipeCapBottom, core.ColorBrightGreen)
+			dst.SetWithColor(p.X+x, bottomY, PipeCapBottom, core.RoleHazard)
 		}
 	}
 }
 
-// drawCenteredMessage draws a message box in the center of the screen.
-func (g *Game) drawCenteredMessage(dst *core.Screen, title, subtitle string) {
-	w := dst.Width()
-	h := dst.Height()
-
-	// Calculate box dimensions
-	boxW := core.Max(len(title), len(subtitle)) + 4
-	boxH := 5
-	boxX := (w - boxW) / 2
-	boxY := (h - boxH) / 2
-
-	// Draw box
-	dst.DrawRect(core.NewRect(boxX, boxY, boxW, boxH), ' ')
-	dst.DrawBox(core.NewRect(boxX, boxY, boxW, boxH))
-
-	// Draw text
-	titleX := boxX + (boxW-len(title))/2
-	dst.DrawText(titleX, boxY+1, title)
-
-	subtitleX := boxX + (boxW-len(subtitle))/2
-	dst.DrawText(subtitleX, boxY+3, subtitle)
-}
-
 // State returns the current game state.
 func (g *Game) State() core.GameState {
 	return core.GameState{
 		Score:    g.score,
 		GameOver: g.gameOver,
-		Paused:   g.paused,
 	}
 }
 
@@ -89,9 +89,6 @@ func TestGameReset(t *testing.T) {
 	if g.gameOver {
 		t.Error("Reset should clear gameOver flag")
 	}
-	if g.paused {
-		t.Error("Reset should clear paused flag")
-	}
 	if !g.waiting {
 		t.Error("Reset should set waiting flag")
 	}
@@ -212,47 +209,6 @@ func TestGameGravity(t *testing.T) {
 	}
 }
 
-func TestGamePause(t *testing.T) {
-	cfg := core.RuntimeConfig{
-		ScreenW:  80,
-		ScreenH:  24,
-		TickRate: 60,
-		Seed:     1,
-	}
-
-	g := New()
-	g.Reset(cfg)
-	g.waiting = false // Skip waiting state for test
-
-	// Pause the game
-	pauseInput := core.NewInputFrame()
-	pauseInput.Set(core.ActionPause)
-	g.Step(pauseInput)
-
-	if !g.paused {
-		t.Error("Game should be paused")
-	}
-
-	// Record state
-	yBefore := g.playerY
-
-	// Step while paused (without pause toggle)
-	noInput := core.NewInputFrame()
-	g.Step(noInput)
-
-	// Physics should not update while paused
-	if g.playerY != yBefore {
-		t.Errorf("Player position should not change while paused, was %f, now %f", yBefore, g.playerY)
-	}
-
-	// Unpause
-	g.Step(pauseInput)
-
-	if g.paused {
-		t.Error("Game should be unpaused")
-	}
-}
-
 func TestGameOver(t *testing.T) {
 	cfg := core.RuntimeConfig{
 		ScreenW:  80,
@@ -312,6 +268,44 @@ func TestGameRender(t *testing.T) {
 	}
 }
 
+func TestGameWingAnimation(t *testing.T) {
+	cfg := core.RuntimeConfig{
+		ScreenW:  80,
+		ScreenH:  24,
+		TickRate: 60,
+		Seed:     1,
+	}
+
+	g := New()
+	g.Reset(cfg)
+	screen := core.NewScreen(cfg.ScreenW, cfg.ScreenH)
+	birdX, birdY := g.cfg.Player.X, int(g.playerY)
+
+	// The bird flaps while waiting to start
+	g.Render(screen)
+	first := screen.Get(birdX, birdY)
+	for range g.flapSprite.FrameTicks() {
+		g.Step(core.NewInputFrame())
+	}
+	g.Render(screen)
+	if screen.Get(birdX, birdY) == first && screen.Get(birdX, birdY+1) == '●' {
+		t.Error("Bird should change wing frame while waiting")
+	}
+
+	// Once falling it glides with folded wings
+	jump := core.NewInputFrame()
+	jump.Set(core.ActionJump)
+	g.Step(jump)
+	for g.playerVel <= 0 {
+		g.Step(core.NewInputFrame())
+	}
+	g.Render(screen)
+	birdY = int(g.playerY)
+	if screen.Get(birdX, birdY) != '●' || screen.Get(birdX+1, birdY) != '▶' {
+		t.Errorf("Gliding bird = %q%q, expected the folded wing", screen.Get(birdX, birdY), screen.Get(birdX+1, birdY))
+	}
+}
+
 func TestPipeCollision(t *testing.T) {
 	cfg := core.RuntimeConfig{
 		ScreenW:  80,
@@ -342,3 +336,33 @@ func TestPipeCollision(t *testing.T) {
 		t.Error("Game should be over when player hits pipe")
 	}
 }
+
+func TestAutopilotPassesPipes(t *testing.T) {
+	g := New()
+	g.Reset(core.RuntimeConfig{ScreenW: 80, ScreenH: 24, TickRate: 60, Seed: 1})
+
+	for range 60 * 20 {
+		g.Step(g.Autopilot())
+		if g.State().GameOver {
+			break
+		}
+	}
+	if g.State().Score < 10 {
+		t.Errorf("Autopilot should pass at least 10 pipes, passed %d", g.State().Score)
+	}
+}
+
+func TestSetDifficultyPerGame(t *testing.T) {
+	cfg := core.RuntimeConfig{ScreenW: 80, ScreenH: 24, TickRate: 60, Seed: 1}
+
+	// Two players on one server can pick their own presets
+	easy, hard := New(), New()
+	easy.SetDifficulty("easy")
+	hard.SetDifficulty("hard")
+	easy.Reset(cfg)
+	hard.Reset(cfg)
+
+	if e, h := easy.difficulty.Level(0, 0), hard.difficulty.Level(0, 0); e >= h {
+		t.Errorf("easy starts at level %v, hard at %v", e, h)
+	}
+}
@@ -0,0 +1,39 @@
+package pong
+
+import "github.com/vovakirdan/tui-arcade/internal/core"
+
+// Autopilot returns the input of the built-in player for the left paddle in
+// the next step. It moves to where the ball will cross the paddle, bounces
+// included, meeting it off center to angle the return away from the right
+// paddle, and back to the middle while the ball moves away.
+func (g *Game) Autopilot() core.InputFrame {
+	in := core.NewInputFrame()
+	if g.gameOver {
+		return in
+	}
+
+	height := float64(g.cfg.Paddles.Height)
+	target := float64(g.runtime.ScreenH) / 2
+	if g.ballVX < 0 {
+		paddleX := float64(g.cfg.Paddles.Offset + g.cfg.Paddles.Width)
+		ticks := (g.ballX - paddleX) / -g.ballVX
+		target = reflectY(g.ballY+g.ballVY*max(ticks, 0), 1, float64(g.runtime.ScreenH-2))
+
+		// Meeting the ball above the center sends it up, below sends it down
+		if g.paddle2Y+height/2 > float64(g.runtime.ScreenH)/2 {
+			target += height * 0.3
+		} else {
+			target -= height * 0.3
+		}
+	}
+
+	// Hold still once the paddle center is close enough
+	diff := target - (g.paddle1Y + height/2)
+	switch {
+	case diff < -g.cfg.Physics.PaddleSpeed:
+		in.SetHeld(core.ActionUp)
+	case diff > g.cfg.Physics.PaddleSpeed:
+		in.SetHeld(core.ActionDown)
+	}
+	return in
+}
@@ -0,0 +1,216 @@
+package pong
+
+import (
+	"math"
+	"strings"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// Personality describes how the CPU opponent plays.
+// Skill (from config and difficulty progression) scales reaction delay and aim error,
+// while the personality decides the overall play style.
+type Personality struct {
+	ID   string // Identifier used in config and menus (e.g., "pro")
+	Name string // Display name (e.g., "Pro")
+
+	SkillBias      float64 // Added to the current skill (clamped to 0-1)
+	SpeedFactor    float64 // Multiplier for paddle speed
+	ReactionTicks  int     // Ticks before reacting to a new ball direction at zero skill
+	AimError       float64 // Max intercept error in cells at zero skill
+	PredictBounces bool    // Whether the CPU folds wall bounces into its intercept
+	Aggression     float64 // 0-1, how hard the CPU angles returns away from the player
+	ReturnToCenter bool    // Whether the CPU recenters while the ball moves away
+}
+
+// Built-in CPU personalities.
+var (
+	// PersonalityRookie chases the ball where it is now and reacts slowly.
+	PersonalityRookie = Personality{
+		ID:            "rookie",
+		Name:          "Rookie",
+		SkillBias:     -0.2,
+		SpeedFactor:   0.8,
+		ReactionTicks: 20,
+		AimError:      3.0,
+	}
+
+	// PersonalityPro predicts bounces and angles returns away from the player.
+	PersonalityPro = Personality{
+		ID:             "pro",
+		Name:           "Pro",
+		SpeedFactor:    1.0,
+		ReactionTicks:  12,
+		AimError:       2.0,
+		PredictBounces: true,
+		Aggression:     0.8,
+		ReturnToCenter: true,
+	}
+
+	// PersonalityWall is a near-perfect defender that rarely angles its returns.
+	PersonalityWall = Personality{
+		ID:             "wall",
+		Name:           "Wall",
+		SkillBias:      0.3,
+		SpeedFactor:    1.2,
+		ReactionTicks:  4,
+		AimError:       0.5,
+		PredictBounces: true,
+		Aggression:     0.1,
+		ReturnToCenter: true,
+	}
+)
+
+// Personalities returns all built-in CPU personalities in menu order.
+func Personalities() []Personality {
+	return []Personality{PersonalityRookie, PersonalityPro, PersonalityWall}
+}
+
+// PersonalityByID looks up a personality by its ID (case-insensitive).
+// Returns false if the ID is unknown.
+func PersonalityByID(id string) (Personality, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for _, p := range Personalities() {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return Personality{}, false
+}
+
+// resolvePersonality picks the personality from the menu selection, then config, then Pro.
+func resolvePersonality(selected, configured string) Personality {
+	if p, ok := PersonalityByID(selected); ok {
+		return p
+	}
+	if p, ok := PersonalityByID(configured); ok {
+		return p
+	}
+	return PersonalityPro
+}
+
+// cpuState tracks the CPU's current plan between ticks.
+type cpuState struct {
+	targetY       float64 // Planned paddle top position
+	aimError      float64 // Intercept error drawn when the CPU last reacted
+	reactionTimer int     // Ticks left before the CPU re-plans
+	lastDirection float64 // Sign of ballVX at the last plan
+}
+
+// effectiveSkill returns the current skill adjusted by the personality bias.
+func (g *Game) effectiveSkill() float64 {
+	return core.ClampF(g.cpuSkill+g.personality.SkillBias, 0, 1)
+}
+
+// updateCPU handles CPU paddle movement.
+func (g *Game) updateCPU() {
+	skill := g.effectiveSkill()
+
+	// Re-plan after a skill-dependent reaction delay whenever the ball changes direction
+	direction := math.Copysign(1, g.ballVX)
+	if direction != g.cpu.lastDirection {
+		g.cpu.lastDirection = direction
+		g.cpu.reactionTimer = int(float64(g.personality.ReactionTicks)*(1-skill)) + 1
+	}
+
+	switch {
+	case g.cpu.reactionTimer > 0:
+		g.cpu.reactionTimer--
+		if g.cpu.reactionTimer == 0 {
+			// Skill-dependent aim error, drawn once per reaction
+			g.cpu.aimError = (g.rng.Float64()*2 - 1) * g.personality.AimError * (1 - skill)
+			g.planCPU()
+		}
+	case g.ballVX > 0 && !g.personality.PredictBounces:
+		// Chasers keep tracking the ball instead of committing to an intercept
+		g.planCPU()
+	}
+
+	// Move towards the planned target with skill-based speed
+	moveSpeed := g.cfg.Physics.PaddleSpeed * g.personality.SpeedFactor * math.Max(skill, 0.1)
+	diff := g.cpu.targetY - g.paddle2Y
+	if math.Abs(diff) > moveSpeed {
+		g.paddle2Y += math.Copysign(moveSpeed, diff)
+	} else {
+		g.paddle2Y = g.cpu.targetY
+	}
+
+	// Clamp CPU paddle
+	maxY := float64(g.runtime.ScreenH - g.cfg.Paddles.Height - 1)
+	g.paddle2Y = core.ClampF(g.paddle2Y, 1, maxY)
+}
+
+// planCPU chooses where the CPU paddle should be for the current ball trajectory.
+func (g *Game) planCPU() {
+	paddleHeight := float64(g.cfg.Paddles.Height)
+
+	if g.ballVX <= 0 {
+		// Ball moving away: recenter or hold position
+		if g.personality.ReturnToCenter {
+			g.cpu.targetY = float64(g.runtime.ScreenH)/2.0 - paddleHeight/2.0
+		} else {
+			g.cpu.targetY = g.paddle2Y
+		}
+		return
+	}
+
+	interceptY := g.ballY
+	if g.personality.PredictBounces {
+		interceptY = g.predictInterceptY()
+	}
+	interceptY += g.cpu.aimError
+
+	// Pick where on the paddle to meet the ball so that SpinFactor angles the return
+	hitFrac := 0.5 + g.aimOffset()
+	g.cpu.targetY = interceptY - hitFrac*paddleHeight
+}
+
+// aimOffset returns how far from the paddle center (-0.5 to 0.5) the CPU wants to hit the ball.
+// A positive offset sends the ball downward, a negative one upward.
+func (g *Game) aimOffset() float64 {
+	if g.personality.Aggression <= 0 || g.cfg.Physics.SpinFactor == 0 {
+		return 0
+	}
+
+	// Aim away from the player's paddle
+	paddle1Center := g.paddle1Y + float64(g.cfg.Paddles.Height)/2.0
+	screenCenter := float64(g.runtime.ScreenH) / 2.0
+	sign := 1.0
+	if paddle1Center > screenCenter {
+		sign = -1.0
+	}
+	return sign * g.personality.Aggression * 0.4
+}
+
+// predictInterceptY computes where the ball will cross the CPU paddle, including wall bounces.
+func (g *Game) predictInterceptY() float64 {
+	paddle2X := float64(g.runtime.ScreenW - g.cfg.Paddles.Offset - g.cfg.Paddles.Width)
+	if g.ballVX <= 0 {
+		return g.ballY
+	}
+
+	ticks := (paddle2X - g.ballX) / g.ballVX
+	if ticks <= 0 {
+		return g.ballY
+	}
+
+	return reflectY(g.ballY+g.ballVY*ticks, 1, float64(g.runtime.ScreenH-2))
+}
+
+// reflectY folds a free-flight Y coordinate back into [top, bottom] as if it bounced off walls.
+func reflectY(y, top, bottom float64) float64 {
+	span := bottom - top
+	if span <= 0 {
+		return top
+	}
+
+	// Unfold bounces: the path repeats every 2*span
+	pos := math.Mod(y-top, 2*span)
+	if pos < 0 {
+		pos += 2 * span
+	}
+	if pos > span {
+		pos = 2*span - pos
+	}
+	return top + pos
+}
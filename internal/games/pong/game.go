@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"strings"
 
 	"github.com/vovakirdan/tui-arcade/internal/config"
 	"github.com/vovakirdan/tui-arcade/internal/core"
@@ -23,30 +24,11 @@ const (
 // configPath stores the custom config path set via CLI
 var configPath string
 
-// difficultyPreset stores the difficulty preset set via CLI
-var difficultyPreset config.DifficultyPreset
-
 // SetConfigPath sets the custom config path for loading.
 func SetConfigPath(path string) {
 	configPath = path
 }
 
-// SetDifficultyPreset sets the difficulty preset.
-func SetDifficultyPreset(preset string) {
-	switch preset {
-	case "easy":
-		difficultyPreset = config.DifficultyEasy
-	case "normal":
-		difficultyPreset = config.DifficultyNormal
-	case "hard":
-		difficultyPreset = config.DifficultyHard
-	case "fixed":
-		difficultyPreset = config.DifficultyFixed
-	default:
-		difficultyPreset = "" // Use config default
-	}
-}
-
 // GameMode indicates the type of opponent.
 type GameMode int
 
@@ -55,6 +37,8 @@ const (
 	ModeVsCPU GameMode = iota
 	// ModeOnline is player vs player over network.
 	ModeOnline
+	// ModeLocal2P is two players at one keyboard.
+	ModeLocal2P
 )
 
 // Game implements the Pong game logic.
@@ -62,6 +46,8 @@ type Game struct {
 	// Paddles
 	paddle1Y float64 // Player 1 (left) paddle Y position
 	paddle2Y float64 // Player 2/CPU (right) paddle Y position
+	mouse1Y  float64 // Paddle 1 Y the mouse points at; negative while keys steer it
+	mouse2Y  float64 // Paddle 2 Y the mouse points at; negative while keys steer it
 
 	// Ball
 	ballX  float64
@@ -75,19 +61,22 @@ type Game struct {
 
 	// Game state
 	gameOver   bool
-	paused     bool
 	winner     int  // 1 or 2
 	serving    bool // True when waiting to serve
 	serveDelay int  // Ticks to wait before serving
 
 	// Mode and settings
-	mode       GameMode
-	runtime    core.RuntimeConfig
-	cfg        config.PongConfig
-	difficulty *config.DifficultyManager
-	cpuSkill   float64 // Current CPU skill (0-1), only used in ModeVsCPU
-	rng        *rand.Rand
-	tickCount  int
+	mode        GameMode
+	runtime     core.RuntimeConfig
+	cfg         config.PongConfig
+	preset      config.DifficultyPreset // From SetDifficulty; empty uses cfg's difficulty
+	difficulty  *config.DifficultyManager
+	cpuSkill    float64 // Current CPU skill (0-1), only used in ModeVsCPU
+	personality Personality
+	selected    string // Personality ID chosen in the mode picker ("" means use config)
+	cpu         cpuState
+	rng         *rand.Rand
+	tickCount   int
 }
 
 // New creates a new Pong game instance (vs CPU mode).
@@ -109,6 +98,33 @@ func (g *Game) SetMode(mode GameMode) {
 	g.mode = mode
 }
 
+// SetTwoPlayer switches between two players at one keyboard and playing
+// against the CPU.
+func (g *Game) SetTwoPlayer(on bool) {
+	if on {
+		g.mode = ModeLocal2P
+	} else {
+		g.mode = ModeVsCPU
+	}
+}
+
+// SetPersonality sets the CPU personality by ID, taking effect on the next
+// Reset. Empty string means use the config value.
+func (g *Game) SetPersonality(id string) {
+	g.selected = id
+}
+
+// SetDifficulty selects a difficulty preset by name for this game, taking
+// effect on the next Reset. Empty or unknown names keep the config's.
+func (g *Game) SetDifficulty(preset string) {
+	g.preset = config.ParseDifficultyPreset(preset)
+}
+
+// TwoPlayer reports whether two players share the keyboard.
+func (g *Game) TwoPlayer() bool {
+	return g.mode == ModeLocal2P
+}
+
 // ID returns the unique identifier for this game.
 func (g *Game) ID() string {
 	return "pong"
@@ -119,6 +135,23 @@ func (g *Game) Title() string {
 	return "Pong"
 }
 
+// Help explains how Pong is played.
+func (g *Game) Help() registry.Help {
+	return registry.Help{
+		Objective: "Return the ball with your paddle. A ball that gets past a paddle scores for the other side; the first to the winning score takes the match.",
+		Controls: []registry.HelpControl{
+			{Action: core.ActionUp, Text: "Move paddle up"},
+			{Action: core.ActionDown, Text: "Move paddle down"},
+			{Keys: "Mouse", Text: "Paddle chases the pointer"},
+		},
+		Legend: []registry.HelpSymbol{
+			{Glyph: PaddleChar, Color: core.RolePlayer, Text: "Your paddle"},
+			{Glyph: PaddleChar, Color: core.RoleOpponent, Text: "Opponent's paddle"},
+			{Glyph: BallChar, Color: core.RoleBall, Text: "Ball"},
+		},
+	}
+}
+
 // Reset initializes or restarts the game.
 func (g *Game) Reset(runtime core.RuntimeConfig) {
 	g.runtime = runtime
@@ -131,8 +164,8 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	}
 
 	// Apply difficulty preset if set
-	if difficultyPreset != "" {
-		config.ApplyPongPreset(&cfg, difficultyPreset)
+	if g.preset != "" {
+		config.ApplyPongPreset(&cfg, g.preset)
 	}
 
 	g.cfg = cfg
@@ -140,8 +173,10 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	// Initialize difficulty manager
 	g.difficulty = config.NewDifficultyManager(cfg.Difficulty)
 
-	// Initialize CPU skill
+	// Initialize CPU skill and personality
 	g.cpuSkill = cfg.CPU.MinSkill
+	g.personality = resolvePersonality(g.selected, cfg.CPU.Personality)
+	g.cpu = cpuState{}
 
 	// Adjust paddle height based on screen size (use config as base)
 	paddleHeight := core.Clamp(runtime.ScreenH/5, 3, cfg.Paddles.Height+2)
@@ -153,12 +188,12 @@ func (g *Game) Reset(runtime core.RuntimeConfig) {
 	centerY := float64(runtime.ScreenH) / 2.0
 	g.paddle1Y = centerY - float64(paddleHeight)/2.0
 	g.paddle2Y = centerY - float64(paddleHeight)/2.0
+	g.mouse1Y, g.mouse2Y = -1, -1
 
 	// Reset scores
 	g.score1 = 0
 	g.score2 = 0
 	g.gameOver = false
-	g.paused = false
 	g.winner = 0
 	g.tickCount = 0
 
@@ -206,15 +241,6 @@ func (g *Game) StepMulti(input core.MultiInputFrame) core.StepResult {
 	p1Input := input.Player(multiplayer.Player1)
 	p2Input := input.Player(multiplayer.Player2)
 
-	// Handle pause toggle (either player can pause)
-	if p1Input.Has(core.ActionPause) || p2Input.Has(core.ActionPause) {
-		g.paused = !g.paused
-	}
-
-	if g.paused {
-		return core.StepResult{State: g.State()}
-	}
-
 	g.tickCount++
 
 	// Handle serve delay
@@ -227,29 +253,12 @@ func (g *Game) StepMulti(input core.MultiInputFrame) core.StepResult {
 	}
 
 	// Update Player 1 paddle
-	paddleSpeed := g.cfg.Physics.PaddleSpeed
-	if p1Input.Has(core.ActionUp) || p1Input.Has(core.ActionJump) {
-		g.paddle1Y -= paddleSpeed
-	}
-	if p1Input.Has(core.ActionDown) || p1Input.Has(core.ActionDuck) {
-		g.paddle1Y += paddleSpeed
-	}
-
-	// Clamp Player 1 paddle
-	paddleHeight := g.cfg.Paddles.Height
-	maxY := float64(g.runtime.ScreenH - paddleHeight - 1)
-	g.paddle1Y = core.ClampF(g.paddle1Y, 1, maxY)
+	g.movePaddle(&g.paddle1Y, &g.mouse1Y, p1Input)
 
 	// Update Player 2 paddle based on mode
-	if g.mode == ModeOnline {
-		// Online mode: use actual player input
-		if p2Input.Has(core.ActionUp) || p2Input.Has(core.ActionJump) {
-			g.paddle2Y -= paddleSpeed
-		}
-		if p2Input.Has(core.ActionDown) || p2Input.Has(core.ActionDuck) {
-			g.paddle2Y += paddleSpeed
-		}
-		g.paddle2Y = core.ClampF(g.paddle2Y, 1, maxY)
+	if g.mode != ModeVsCPU {
+		// Online or local 2P: use actual player input
+		g.movePaddle(&g.paddle2Y, &g.mouse2Y, p2Input)
 	} else {
 		// CPU mode
 		g.updateCPU()
@@ -269,32 +278,36 @@ func (g *Game) StepMulti(input core.MultiInputFrame) core.StepResult {
 	return core.StepResult{State: g.State()}
 }
 
-// updateCPU handles CPU paddle movement.
-func (g *Game) updateCPU() {
+// movePaddle moves a paddle by one tick of player input. Held keys move it
+// at paddle speed. Once the mouse moves, the paddle follows the pointer at
+// the same speed until a key is used again, so the mouse is no faster than
+// the keys or the CPU.
+func (g *Game) movePaddle(y, mouseY *float64, in core.InputFrame) {
+	speed := g.cfg.Physics.PaddleSpeed
 	paddleHeight := g.cfg.Paddles.Height
 
-	// CPU tracks ball with some imperfection
-	targetY := g.ballY - float64(paddleHeight)/2.0
-
-	// Add some "reaction time" - CPU doesn't perfectly follow
-	diff := targetY - g.paddle2Y
-
-	// Only move if ball is coming towards CPU
-	if g.ballVX > 0 {
-		// Move towards target with skill-based speed
-		moveSpeed := g.cfg.Physics.PaddleSpeed * g.cpuSkill
-		if math.Abs(diff) > moveSpeed {
-			if diff > 0 {
-				g.paddle2Y += moveSpeed
-			} else {
-				g.paddle2Y -= moveSpeed
-			}
-		}
+	up := in.Held(core.ActionUp) || in.Held(core.ActionJump)
+	down := in.Held(core.ActionDown) || in.Held(core.ActionDuck)
+	if up {
+		*y -= speed
+	}
+	if down {
+		*y += speed
+	}
+
+	switch {
+	case up || down:
+		*mouseY = -1
+	case in.Mouse.Moved:
+		// Center the paddle on the pointer
+		*mouseY = float64(in.Mouse.Y) - float64(paddleHeight-1)/2
+	}
+	if *mouseY >= 0 {
+		*y += core.ClampF(*mouseY-*y, -speed, speed)
 	}
 
-	// Clamp CPU paddle
 	maxY := float64(g.runtime.ScreenH - paddleHeight - 1)
-	g.paddle2Y = core.ClampF(g.paddle2Y, 1, maxY)
+	*y = core.ClampF(*y, 1, maxY)
 }
 
 // updateBall handles ball physics and collision.
@@ -392,7 +405,7 @@ func (g *Game) Render(dst *core.Screen) {
 	// Draw center line (net) with gray
 	centerX := dst.Width() / 2
 	for y := 1; y < dst.Height()-1; y += 2 {
-		dst.SetWithColor(centerX, y, NetChar, core.ColorGray)
+		dst.SetWithColor(centerX, y, NetChar, core.RoleMuted)
 	}
 
 	// Draw paddles (P1 cyan, P2/CPU magenta)
@@ -400,83 +413,56 @@ func (g *Game) Render(dst *core.Screen) {
 	paddle2X := dst.Width() - paddleOffset - paddleWidth
 
 	for i := range paddleHeight {
-		dst.SetWithColor(paddle1X, int(g.paddle1Y)+i, PaddleChar, core.ColorCyan)
-		dst.SetWithColor(paddle2X, int(g.paddle2Y)+i, PaddleChar, core.ColorMagenta)
+		dst.SetWithColor(paddle1X, int(g.paddle1Y)+i, PaddleChar, core.RolePlayer)
+		dst.SetWithColor(paddle2X, int(g.paddle2Y)+i, PaddleChar, core.RoleOpponent)
 	}
 
 	// Draw ball with bright white
 	if !g.serving || (g.serveDelay/10)%2 == 0 { // Blink during serve
-		dst.SetWithColor(int(g.ballX), int(g.ballY), BallChar, core.ColorBrightWhite)
+		dst.SetWithColor(int(g.ballX), int(g.ballY), BallChar, core.RoleBall)
 	}
 
 	// Draw scores with cyan
 	score1Text := fmt.Sprintf("%d", g.score1)
 	score2Text := fmt.Sprintf("%d", g.score2)
-	dst.DrawTextWithColor(centerX-5, 0, score1Text, core.ColorCyan)
-	dst.DrawTextWithColor(centerX+4, 0, score2Text, core.ColorMagenta)
+	dst.DrawTextWithColor(centerX-5, 0, score1Text, core.RolePlayer)
+	dst.DrawTextWithColor(centerX+4, 0, score2Text, core.RoleOpponent)
 
 	// Draw labels based on mode with matching colors
-	dst.DrawTextWithColor(1, 0, "P1", core.ColorCyan)
-	if g.mode == ModeOnline {
-		dst.DrawTextWithColor(dst.Width()-3, 0, "P2", core.ColorMagenta)
+	dst.DrawTextWithColor(1, 0, "P1", core.RolePlayer)
+	if g.mode != ModeVsCPU {
+		dst.DrawTextWithColor(dst.Width()-3, 0, "P2", core.RoleOpponent)
 	} else {
-		dst.DrawTextWithColor(dst.Width()-4, 0, "CPU", core.ColorMagenta)
-	}
-
-	if g.paused {
-		g.drawCenteredMessage(dst, "PAUSED", "Press P to resume")
+		cpuLabel := "CPU " + strings.ToUpper(g.personality.Name)
+		dst.DrawTextWithColor(dst.Width()-len(cpuLabel)-1, 0, cpuLabel, core.RoleOpponent)
 	}
 
 	if g.gameOver {
-		var msg, subtitle string
+		var msg string
+		switch {
+		case g.mode == ModeVsCPU && g.winner == 1:
+			msg = "YOU WIN!"
+		case g.mode == ModeVsCPU:
+			msg = "CPU WINS!"
+		case g.winner == 1:
+			msg = "PLAYER 1 WINS!"
+		default:
+			msg = "PLAYER 2 WINS!"
+		}
+		hint := "Press R to restart"
 		if g.mode == ModeOnline {
-			if g.winner == 1 {
-				msg = "PLAYER 1 WINS!"
-			} else {
-				msg = "PLAYER 2 WINS!"
-			}
-			subtitle = fmt.Sprintf("%d - %d  |  Press Esc to exit", g.score1, g.score2)
-		} else {
-			if g.winner == 1 {
-				msg = "YOU WIN!"
-			} else {
-				msg = "CPU WINS!"
-			}
-			subtitle = fmt.Sprintf("%d - %d  |  Press R to restart", g.score1, g.score2)
+			hint = "Press Esc to exit"
 		}
-		g.drawCenteredMessage(dst, msg, subtitle)
+		subtitle := fmt.Sprintf("%d - %d  |  %s", g.score1, g.score2, hint)
+		dst.DrawMessageBox(msg, subtitle)
 	}
 }
 
-// drawCenteredMessage draws a message box in the center of the screen.
-func (g *Game) drawCenteredMessage(dst *core.Screen, title, subtitle string) {
-	w := dst.Width()
-	h := dst.Height()
-
-	// Calculate box dimensions
-	boxW := core.Max(len(title), len(subtitle)) + 4
-	boxH := 5
-	boxX := (w - boxW) / 2
-	boxY := (h - boxH) / 2
-
-	// Draw box
-	dst.DrawRect(core.NewRect(boxX, boxY, boxW, boxH), ' ')
-	dst.DrawBox(core.NewRect(boxX, boxY, boxW, boxH))
-
-	// Draw text
-	titleX := boxX + (boxW-len(title))/2
-	dst.DrawText(titleX, boxY+1, title)
-
-	subtitleX := boxX + (boxW-len(subtitle))/2
-	dst.DrawText(subtitleX, boxY+3, subtitle)
-}
-
 // State returns the current game state.
 func (g *Game) State() core.GameState {
 	return core.GameState{
 		Score:    g.score1, // Report player's score
 		GameOver: g.gameOver,
-		Paused:   g.paused,
 	}
 }
 
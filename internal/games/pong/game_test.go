@@ -0,0 +1,252 @@
+package pong
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+func testConfig() core.RuntimeConfig {
+	return core.RuntimeConfig{
+		ScreenW:  80,
+		ScreenH:  24,
+		TickRate: 60,
+		Seed:     42,
+	}
+}
+
+func TestReflectY(t *testing.T) {
+	tests := []struct {
+		name string
+		y    float64
+		want float64
+	}{
+		{"inside", 5, 5},
+		{"top edge", 1, 1},
+		{"bottom edge", 21, 21},
+		{"one bounce off bottom", 25, 17},
+		{"one bounce off top", -3, 5},
+		{"two bounces", 45, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reflectY(tt.y, 1, 21)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("reflectY(%v) = %v, want %v", tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPredictInterceptWithBounce(t *testing.T) {
+	g := New()
+	g.Reset(testConfig())
+
+	// Ball heading right and down steeply enough to bounce off the bottom wall
+	g.ballX = 40
+	g.ballY = 12
+	g.ballVX = 1
+	g.ballVY = 0.5
+
+	predicted := g.predictInterceptY()
+
+	// Simulate the ball until it reaches the CPU paddle column
+	paddle2X := float64(g.runtime.ScreenW - g.cfg.Paddles.Offset - g.cfg.Paddles.Width)
+	x, y, vy := g.ballX, g.ballY, g.ballVY
+	bottom := float64(g.runtime.ScreenH - 2)
+	for x < paddle2X {
+		x += g.ballVX
+		y += vy
+		if y <= 1 {
+			y = 1
+			vy = -vy
+		}
+		if y >= bottom {
+			y = bottom
+			vy = -vy
+		}
+	}
+
+	if math.Abs(predicted-y) > 1.0 {
+		t.Errorf("predicted intercept %.2f, simulated %.2f", predicted, y)
+	}
+}
+
+func TestPersonalityResolution(t *testing.T) {
+	if p := resolvePersonality("", "wall"); p.ID != "wall" {
+		t.Errorf("config personality: got %q, want wall", p.ID)
+	}
+
+	if p := resolvePersonality("Rookie", "wall"); p.ID != "rookie" {
+		t.Errorf("menu selection should override config: got %q, want rookie", p.ID)
+	}
+
+	if p := resolvePersonality("", "unknown"); p.ID != PersonalityPro.ID {
+		t.Errorf("unknown personality should fall back to pro, got %q", p.ID)
+	}
+}
+
+func TestWallReturnsBouncingShot(t *testing.T) {
+	g := New()
+	g.SetPersonality("wall")
+	g.Reset(testConfig())
+	g.serving = false
+	g.cpuSkill = g.cfg.CPU.MaxSkill
+
+	// Steep shot from the far side that bounces off the bottom wall before arriving
+	g.ballX = 10
+	g.ballY = 4
+	g.ballVX = 0.8
+	g.ballVY = 0.6
+	g.paddle2Y = 1 // CPU starts at the wrong end
+
+	for range 200 {
+		g.Step(core.NewInputFrame())
+		if g.ballVX < 0 {
+			return // Returned
+		}
+		if g.score1 > 0 {
+			t.Fatal("wall CPU missed a predictable bounce shot")
+		}
+	}
+	t.Fatal("ball never reached the CPU paddle")
+}
+
+func TestWallConcedesLessThanRookie(t *testing.T) {
+	// Count how many points the CPU concedes while player 1 returns everything with spin
+	conceded := func(personality string) int {
+		g := New()
+		g.SetPersonality(personality)
+		g.Reset(testConfig())
+
+		for range 6000 {
+			// Meet the ball near the paddle edge so returns pick up spin
+			g.paddle1Y = core.ClampF(g.ballY-float64(g.cfg.Paddles.Height)*0.9,
+				1, float64(g.runtime.ScreenH-g.cfg.Paddles.Height-1))
+			g.Step(core.NewInputFrame())
+			if g.gameOver {
+				break
+			}
+		}
+		return g.score1
+	}
+
+	if rookie, wall := conceded("rookie"), conceded("wall"); wall > rookie {
+		t.Errorf("wall conceded more points (%d) than rookie (%d)", wall, rookie)
+	}
+}
+
+func TestCPUDeterminism(t *testing.T) {
+	run := func() (int, int, float64) {
+		g := New()
+		g.Reset(testConfig())
+		for range 3000 {
+			in := core.NewInputFrame()
+			g.Step(in)
+			if g.gameOver {
+				break
+			}
+		}
+		return g.score1, g.score2, g.paddle2Y
+	}
+
+	s1a, s2a, pa := run()
+	s1b, s2b, pb := run()
+	if s1a != s1b || s2a != s2b || pa != pb {
+		t.Errorf("CPU not deterministic: (%d,%d,%.2f) vs (%d,%d,%.2f)", s1a, s2a, pa, s1b, s2b, pb)
+	}
+}
+
+func TestPaddleFollowsMouse(t *testing.T) {
+	g := New()
+	g.Reset(testConfig())
+	speed := g.cfg.Physics.PaddleSpeed
+	start := g.paddle1Y
+
+	// The paddle heads for the pointer at paddle speed, even after the
+	// pointer stops moving
+	in := core.NewInputFrame()
+	in.Mouse = core.MouseState{X: 2, Y: 20, Active: true, Moved: true}
+	g.Step(in)
+	in.Mouse.Moved = false
+	g.Step(in)
+	if expected := start + 2*speed; g.paddle1Y != expected {
+		t.Errorf("paddle Y after 2 ticks = %.2f, expected %.2f", g.paddle1Y, expected)
+	}
+
+	for range 30 {
+		g.Step(in)
+	}
+	target := 20 - float64(g.cfg.Paddles.Height-1)/2
+	if g.paddle1Y != target {
+		t.Errorf("paddle Y = %.2f, expected it centered on the pointer at %.2f", g.paddle1Y, target)
+	}
+
+	// Keys take over until the mouse moves again
+	keys := core.NewInputFrame()
+	keys.Mouse = in.Mouse
+	keys.SetHeld(core.ActionUp)
+	g.Step(keys)
+	y := g.paddle1Y
+	g.Step(in)
+	if g.paddle1Y != y {
+		t.Errorf("paddle Y = %.2f after keys steered it, expected it to stay at %.2f", g.paddle1Y, y)
+	}
+}
+
+func TestTwoPlayerMovesBothPaddles(t *testing.T) {
+	g := New()
+	g.SetTwoPlayer(true)
+	g.Reset(testConfig())
+	speed := g.cfg.Physics.PaddleSpeed
+	start1, start2 := g.paddle1Y, g.paddle2Y
+
+	in := core.NewMultiInputFrame()
+	p1 := core.NewInputFrame()
+	p1.SetHeld(core.ActionUp)
+	p2 := core.NewInputFrame()
+	p2.SetHeld(core.ActionDown)
+	in.SetPlayer(core.Player1, p1)
+	in.SetPlayer(core.Player2, p2)
+	for range 3 {
+		g.StepMulti(in)
+	}
+
+	if expected := start1 - 3*speed; g.paddle1Y != expected {
+		t.Errorf("paddle 1 Y = %.2f, expected %.2f", g.paddle1Y, expected)
+	}
+	if expected := start2 + 3*speed; g.paddle2Y != expected {
+		t.Errorf("paddle 2 Y = %.2f, expected %.2f", g.paddle2Y, expected)
+	}
+
+	g.SetTwoPlayer(false)
+	if g.TwoPlayer() {
+		t.Error("TwoPlayer() = true after switching back to the CPU, expected false")
+	}
+}
+
+func TestAutopilotReturnsBall(t *testing.T) {
+	g := New()
+	g.Reset(core.RuntimeConfig{ScreenW: 80, ScreenH: 24, TickRate: 60, Seed: 1})
+
+	for range 60 * 30 {
+		g.Step(g.Autopilot())
+	}
+	if g.score2 > 0 {
+		t.Errorf("Autopilot should keep the ball out of its goal for 30 seconds, conceded %d", g.score2)
+	}
+}
+
+func TestPersonalityPerGame(t *testing.T) {
+	rookie, wall := New(), New()
+	rookie.SetPersonality("rookie")
+	wall.SetPersonality("wall")
+	rookie.Reset(testConfig())
+	wall.Reset(testConfig())
+
+	if rookie.personality.ID != "rookie" || wall.personality.ID != "wall" {
+		t.Errorf("personalities leaked between games: got %q and %q", rookie.personality.ID, wall.personality.ID)
+	}
+}
@@ -0,0 +1,123 @@
+package snake
+
+import "github.com/vovakirdan/tui-arcade/internal/core"
+
+// directions are the directions the autopilot tries, in order.
+var directions = []Direction{DirUp, DirRight, DirDown, DirLeft}
+
+// directionActions are the actions that turn the snake, by direction.
+var directionActions = map[Direction]core.Action{
+	DirUp:    core.ActionUp,
+	DirDown:  core.ActionDown,
+	DirLeft:  core.ActionLeft,
+	DirRight: core.ActionRight,
+}
+
+// Autopilot returns the input of the built-in player for the next step. It
+// takes the shortest path to the food; when there is none, it heads for the
+// neighbor with the most room around it.
+func (g *Game) Autopilot() core.InputFrame {
+	in := core.NewInputFrame()
+	if g.gameOver || g.won || g.levelCleared || len(g.snake) == 0 {
+		return in
+	}
+	if g.moveTicker+1 < g.moveEveryTicks {
+		return in // Decided just before the snake moves
+	}
+
+	head := g.snake[0]
+	dir, ok := g.pathToFood(head)
+	if !ok {
+		most := -1
+		for _, d := range directions {
+			next := neighbor(head, d)
+			if !g.autopilotFree(next) {
+				continue
+			}
+			if room := g.room(next); room > most {
+				dir, most = d, room
+			}
+		}
+		if most < 0 {
+			return in // Trapped
+		}
+	}
+	if dir != g.nextDir {
+		in.Set(directionActions[dir])
+	}
+	return in
+}
+
+// pathToFood returns the first move of a shortest path from head to the
+// food, found breadth first.
+func (g *Game) pathToFood(head Point) (Direction, bool) {
+	first := map[Point]Direction{}
+	queue := []Point{}
+	for _, d := range directions {
+		if next := neighbor(head, d); g.autopilotFree(next) {
+			first[next] = d
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p == g.food {
+			return first[p], true
+		}
+		for _, d := range directions {
+			next := neighbor(p, d)
+			if _, seen := first[next]; !seen && next != head && g.autopilotFree(next) {
+				first[next] = first[p]
+				queue = append(queue, next)
+			}
+		}
+	}
+	return 0, false
+}
+
+// room counts the free cells reachable from p.
+func (g *Game) room(p Point) int {
+	seen := map[Point]bool{p: true}
+	queue := []Point{p}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range directions {
+			if next := neighbor(p, d); !seen[next] && g.autopilotFree(next) {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return len(seen)
+}
+
+// autopilotFree reports whether the snake can move onto p. The tail counts
+// as free, since it moves away.
+func (g *Game) autopilotFree(p Point) bool {
+	if p.X < 0 || p.X >= g.mapWidth || p.Y < 0 || p.Y >= g.mapHeight || g.walls[p] {
+		return false
+	}
+	for _, s := range g.snake[:len(g.snake)-1] {
+		if s == p {
+			return false
+		}
+	}
+	return true
+}
+
+// neighbor returns the cell next to p in direction d.
+func neighbor(p Point, d Direction) Point {
+	switch d {
+	case DirUp:
+		p.Y--
+	case DirDown:
+		p.Y++
+	case DirLeft:
+		p.X--
+	case DirRight:
+		p.X++
+	}
+	return p
+}
@@ -35,10 +35,11 @@ type Point struct {
 
 // Game implements the Snake game.
 type Game struct {
-	mode Mode
-	rng  *rand.Rand
-	cfg  config.SnakeConfig
-	tick uint64
+	mode   Mode
+	rng    *rand.Rand
+	cfg    config.SnakeConfig
+	preset config.DifficultyPreset // From SetDifficulty; empty uses cfg's difficulty
+	tick   uint64
 
 	score          int
 	foodEaten      int // Food eaten in current level
@@ -69,17 +70,15 @@ type Game struct {
 	gameOver     bool
 	levelCleared bool
 	won          bool
-	paused       bool
 	tooSmall     bool
 
 	// Level clear animation
 	levelClearTicks int
 }
 
-// Package-level variables for config/difficulty
+// Package-level variables for config and start level
 var (
 	configPath         string
-	difficultyPreset   string
 	selectedStartLevel int
 )
 
@@ -88,11 +87,6 @@ func SetConfigPath(path string) {
 	configPath = path
 }
 
-// SetDifficultyPreset sets the difficulty preset.
-func SetDifficultyPreset(preset string) {
-	difficultyPreset = preset
-}
-
 // SetStartLevel sets the starting level (1-10). 0 means start from beginning.
 func SetStartLevel(level int) {
 	selectedStartLevel = level
@@ -126,6 +120,12 @@ func init() {
 	})
 }
 
+// SetDifficulty selects a difficulty preset by name for this game, taking
+// effect on the next Reset. Empty or unknown names keep the config's.
+func (g *Game) SetDifficulty(preset string) {
+	g.preset = config.ParseDifficultyPreset(preset)
+}
+
 // ID returns the game identifier.
 func (g *Game) ID() string {
 	if g.mode == ModeEndless {
@@ -142,6 +142,29 @@ func (g *Game) Title() string {
 	return "Snake"
 }
 
+// Help explains how Snake is played.
+func (g *Game) Help() registry.Help {
+	objective := "Steer the snake to the food; each bite makes it longer. Eat enough to clear the level, without running into a wall or yourself."
+	if g.mode == ModeEndless {
+		objective = "Steer the snake to the food; each bite makes it longer. Grow as long as you can without running into a wall or yourself."
+	}
+	return registry.Help{
+		Objective: objective,
+		Controls: []registry.HelpControl{
+			{Action: core.ActionUp, Text: "Turn up"},
+			{Action: core.ActionDown, Text: "Turn down"},
+			{Action: core.ActionLeft, Text: "Turn left"},
+			{Action: core.ActionRight, Text: "Turn right"},
+		},
+		Legend: []registry.HelpSymbol{
+			{Glyph: 'O', Color: core.RolePlayer, Text: "Snake's head"},
+			{Glyph: 'o', Color: core.RolePlayer, Text: "Snake's body"},
+			{Glyph: '*', Color: core.RolePickup, Text: "Food"},
+			{Glyph: '#', Color: core.RoleWall, Text: "Wall"},
+		},
+	}
+}
+
 // Reset initializes/restarts the game.
 func (g *Game) Reset(cfg core.RuntimeConfig) {
 	g.rng = rand.New(rand.NewSource(cfg.Seed))
@@ -151,7 +174,6 @@ func (g *Game) Reset(cfg core.RuntimeConfig) {
 	g.gameOver = false
 	g.levelCleared = false
 	g.won = false
-	g.paused = false
 	g.tooSmall = false
 	g.levelClearTicks = 0
 	g.screenW = cfg.ScreenW
@@ -165,21 +187,8 @@ func (g *Game) Reset(cfg core.RuntimeConfig) {
 	}
 
 	// Apply difficulty preset
-	if difficultyPreset != "" {
-		var preset config.DifficultyPreset
-		switch difficultyPreset {
-		case "easy":
-			preset = config.DifficultyEasy
-		case "normal":
-			preset = config.DifficultyNormal
-		case "hard":
-			preset = config.DifficultyHard
-		case "fixed":
-			preset = config.DifficultyFixed
-		}
-		if preset != "" {
-			config.ApplySnakePreset(&gameCfg, preset)
-		}
+	if g.preset != "" {
+		config.ApplySnakePreset(&gameCfg, g.preset)
 	}
 	g.cfg = gameCfg
 
@@ -499,13 +508,8 @@ func (g *Game) Step(input core.InputFrame) core.StepResult {
 		return core.StepResult{State: g.State()}
 	}
 
-	// Handle pause toggle
-	if input.Has(core.ActionPause) {
-		g.paused = !g.paused
-	}
-
-	// Don't process if game over, paused, too small, or level cleared animation
-	if g.gameOver || g.won || g.paused || g.tooSmall {
+	// Don't process if game over or too small
+	if g.gameOver || g.won || g.tooSmall {
 		return core.StepResult{State: g.State()}
 	}
 
@@ -671,7 +675,7 @@ func (g *Game) Render(dst *core.Screen) {
 		fx := g.mapOffsetX + g.food.X
 		fy := g.mapOffsetY + g.food.Y
 		if fx >= 0 && fx < dst.Width() && fy >= 0 && fy < dst.Height() {
-			dst.SetWithColor(fx, fy, '*', core.ColorRed)
+			dst.SetWithColor(fx, fy, '*', core.RolePickup)
 		}
 	}
 
@@ -687,8 +691,6 @@ func (g *Game) Render(dst *core.Screen) {
 		g.renderOverlay(dst, "You Win!", fmt.Sprintf("Final Score: %d", g.score))
 	case g.gameOver:
 		g.renderOverlay(dst, "Game Over", "Press R to restart")
-	case g.paused:
-		g.renderOverlay(dst, "Paused", "Press P to continue")
 	}
 }
 
@@ -704,11 +706,11 @@ func (g *Game) renderHUD(dst *core.Screen) {
 	}
 
 	// Draw HUD line with cyan color
-	dst.DrawTextWithColor(0, 0, hud, core.ColorCyan)
+	dst.DrawTextWithColor(0, 0, hud, core.RoleHUD)
 
 	// Draw separator with gray color
 	for x := range dst.Width() {
-		dst.SetWithColor(x, 1, '─', core.ColorGray)
+		dst.SetWithColor(x, 1, '─', core.RoleMuted)
 	}
 }
 
@@ -718,7 +720,7 @@ func (g *Game) renderMap(dst *core.Screen) {
 		wx := g.mapOffsetX + wall.X
 		wy := g.mapOffsetY + wall.Y
 		if wx >= 0 && wx < dst.Width() && wy >= 0 && wy < dst.Height() {
-			dst.SetWithColor(wx, wy, '#', core.ColorGray)
+			dst.SetWithColor(wx, wy, '#', core.RoleWall)
 		}
 	}
 }
@@ -730,9 +732,9 @@ func (g *Game) renderSnake(dst *core.Screen) {
 		sy := g.mapOffsetY + seg.Y
 		if sx >= 0 && sx < dst.Width() && sy >= 0 && sy < dst.Height() {
 			if i == 0 {
-				dst.SetWithColor(sx, sy, 'O', core.ColorBrightGreen) // Head
+				dst.SetWithColor(sx, sy, 'O', core.RolePlayer) // Head
 			} else {
-				dst.SetWithColor(sx, sy, 'o', core.ColorGreen) // Body
+				dst.SetWithColor(sx, sy, 'o', core.RolePlayer) // Body
 			}
 		}
 	}
@@ -798,7 +800,6 @@ func (g *Game) State() core.GameState {
 	return core.GameState{
 		Score:    g.score,
 		GameOver: g.gameOver || g.won,
-		Paused:   g.paused,
 	}
 }
 
@@ -829,6 +830,6 @@ func (g *Game) DebugState() string {
 	if len(g.snake) > 0 {
 		b.WriteString(fmt.Sprintf("Head: (%d, %d), Food: (%d, %d)\n", g.snake[0].X, g.snake[0].Y, g.food.X, g.food.Y))
 	}
-	b.WriteString(fmt.Sprintf("GameOver: %v, Won: %v, Paused: %v\n", g.gameOver, g.won, g.paused))
+	b.WriteString(fmt.Sprintf("GameOver: %v, Won: %v\n", g.gameOver, g.won))
 	return b.String()
 }
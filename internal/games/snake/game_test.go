@@ -410,3 +410,18 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestAutopilotEats(t *testing.T) {
+	g := New()
+	g.Reset(core.RuntimeConfig{ScreenW: 80, ScreenH: 24, TickRate: 60, Seed: 1})
+
+	for range 60 * 30 {
+		g.Step(g.Autopilot())
+	}
+	if g.gameOver {
+		t.Error("Autopilot should not crash in 30 seconds")
+	}
+	if g.score < 5 {
+		t.Errorf("Autopilot should eat at least 5 food, ate %d", g.score)
+	}
+}
@@ -0,0 +1,49 @@
+package t2048
+
+import "github.com/vovakirdan/tui-arcade/internal/core"
+
+// autopilotPace is how many ticks the autopilot waits between moves, so
+// each slide can be followed.
+const autopilotPace = 15
+
+// autopilotMoves are the moves the autopilot weighs, with the actions that
+// make them, in order of preference: it keeps the big tiles in the bottom
+// left corner and only slides up when nothing else moves.
+var autopilotMoves = []struct {
+	dir    Direction
+	action core.Action
+	bias   int
+}{
+	{DirDown, core.ActionDown, 2},
+	{DirLeft, core.ActionLeft, 2},
+	{DirRight, core.ActionRight, 0},
+	{DirUp, core.ActionUp, -1000},
+}
+
+// Autopilot returns the input of the built-in player for the next step. It
+// slides the way that merges the most and leaves the board the emptiest.
+func (g *Game) Autopilot() core.InputFrame {
+	in := core.NewInputFrame()
+	if g.gameOver || g.won || g.levelCleared || g.animating || g.tick%autopilotPace != 0 {
+		return in
+	}
+
+	best, bestValue := core.ActionNone, 0
+	for _, m := range autopilotMoves {
+		board, score, changed := Slide(g.board, m.dir)
+		if !changed {
+			continue
+		}
+		value := score + 8*len(EmptyCells(board)) + m.bias
+		if board[BoardSize-1][0] == MaxTile(board) {
+			value += MaxTile(board)
+		}
+		if best == core.ActionNone || value > bestValue {
+			best, bestValue = m.action, value
+		}
+	}
+	if best != core.ActionNone {
+		in.Set(best)
+	}
+	return in
+}
@@ -53,7 +53,6 @@ type Game struct {
 	gameOver        bool
 	levelCleared    bool
 	won             bool
-	paused          bool
 	tooSmall        bool
 	moveProcessed   bool // Prevent multiple moves per tick
 	levelClearTicks int  // Animation ticks for level clear
@@ -113,6 +112,24 @@ func (g *Game) Title() string {
 	return "2048"
 }
 
+// Help explains how 2048 is played.
+func (g *Game) Help() registry.Help {
+	objective := "Slide the tiles; two equal tiles that meet merge into their sum. Reach the level's target tile before the board fills up."
+	if g.mode == ModeEndless {
+		objective = "Slide the tiles; two equal tiles that meet merge into their sum. Score as much as you can before the board fills up."
+	}
+	return registry.Help{
+		Objective: objective,
+		Controls: []registry.HelpControl{
+			{Action: core.ActionUp, Text: "Slide up"},
+			{Action: core.ActionDown, Text: "Slide down"},
+			{Action: core.ActionLeft, Text: "Slide left"},
+			{Action: core.ActionRight, Text: "Slide right"},
+			{Keys: "Mouse", Text: "Drag to slide"},
+		},
+	}
+}
+
 // Reset initializes/restarts the game.
 func (g *Game) Reset(cfg core.RuntimeConfig) {
 	g.rng = rand.New(rand.NewSource(cfg.Seed))
@@ -123,7 +140,6 @@ func (g *Game) Reset(cfg core.RuntimeConfig) {
 	g.gameOver = false
 	g.levelCleared = false
 	g.won = false
-	g.paused = false
 	g.moveProcessed = false
 	g.levelClearTicks = 0
 
@@ -248,15 +264,6 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 		return core.StepResult{State: g.State()}
 	}
 
-	// Handle pause
-	if in.Has(core.ActionPause) {
-		g.paused = !g.paused
-	}
-
-	if g.paused {
-		return core.StepResult{State: g.State()}
-	}
-
 	// Handle restart
 	if in.Has(core.ActionRestart) && (g.gameOver || g.won) {
 		// Will be reset by platform
@@ -302,6 +309,8 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 	case in.Has(core.ActionRight):
 		dir = DirRight
 		moved = true
+	default:
+		dir, moved = swipeDirection(in.Mouse)
 	}
 
 	if moved && !g.moveProcessed {
@@ -312,6 +321,29 @@ func (g *Game) Step(in core.InputFrame) core.StepResult {
 	return core.StepResult{State: g.State()}
 }
 
+// swipeDirection returns the direction of a mouse drag that ended this
+// frame. Cells are about twice as tall as they are wide, so a drag must
+// cover two columns or one row, and columns count half.
+func swipeDirection(m core.MouseState) (Direction, bool) {
+	if !m.Released {
+		return 0, false
+	}
+	dx, dy := m.X-m.DragX, m.Y-m.DragY
+	switch {
+	case core.Abs(dx) < 2 && dy == 0:
+		return 0, false
+	case core.Abs(dx) >= 2*core.Abs(dy):
+		if dx < 0 {
+			return DirLeft, true
+		}
+		return DirRight, true
+	case dy < 0:
+		return DirUp, true
+	default:
+		return DirDown, true
+	}
+}
+
 // processMove handles a move in the given direction.
 func (g *Game) processMove(dir Direction) {
 	newBoard, moves, scoreGained, changed := SlideWithTracking(g.board, dir)
@@ -399,6 +431,6 @@ func (g *Game) State() core.GameState {
 	return core.GameState{
 		Score:    g.score,
 		GameOver: g.gameOver || g.won,
-		Paused:   g.paused || g.tooSmall || g.levelCleared,
+		Paused:   g.tooSmall || g.levelCleared,
 	}
 }
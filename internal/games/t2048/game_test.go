@@ -207,6 +207,30 @@ func TestNoChangeNoSpawn(t *testing.T) {
 	}
 }
 
+func TestSwipeDirection(t *testing.T) {
+	tests := []struct {
+		name   string
+		mouse  core.MouseState
+		dir    Direction
+		swiped bool
+	}{
+		{"left", core.MouseState{X: 10, Y: 5, DragX: 20, DragY: 6, Released: true}, DirLeft, true},
+		{"right", core.MouseState{X: 12, Y: 5, DragX: 10, DragY: 5, Released: true}, DirRight, true},
+		{"up", core.MouseState{X: 10, Y: 2, DragX: 11, DragY: 5, Released: true}, DirUp, true},
+		{"down", core.MouseState{X: 10, Y: 6, DragX: 10, DragY: 5, Released: true}, DirDown, true},
+		{"columns count half", core.MouseState{X: 13, Y: 7, DragX: 10, DragY: 5, Released: true}, DirDown, true},
+		{"click", core.MouseState{X: 10, Y: 5, DragX: 10, DragY: 5, Released: true}, DirUp, false},
+		{"too short", core.MouseState{X: 11, Y: 5, DragX: 10, DragY: 5, Released: true}, DirUp, false},
+		{"still dragging", core.MouseState{X: 20, Y: 5, DragX: 10, DragY: 5, Down: true}, DirUp, false},
+	}
+	for _, tt := range tests {
+		dir, swiped := swipeDirection(tt.mouse)
+		if swiped != tt.swiped || (swiped && dir != tt.dir) {
+			t.Errorf("%s: swipeDirection = (%v, %v), expected (%v, %v)", tt.name, dir, swiped, tt.dir, tt.swiped)
+		}
+	}
+}
+
 func TestGameOver(t *testing.T) {
 	// Board with no empty cells and no possible merges
 	board := Board{
@@ -429,3 +453,15 @@ func TestLevelNames(t *testing.T) {
 		t.Errorf("First level name = %s, want Warm-up", names[0])
 	}
 }
+
+func TestAutopilotMerges(t *testing.T) {
+	g := NewEndless()
+	g.Reset(core.RuntimeConfig{ScreenW: 80, ScreenH: 30, TickRate: 60, Seed: 1})
+
+	for range 60 * 30 {
+		g.Step(g.Autopilot())
+	}
+	if MaxTile(g.board) < 64 {
+		t.Errorf("Autopilot should reach a 64 tile in 30 seconds, best %d", MaxTile(g.board))
+	}
+}
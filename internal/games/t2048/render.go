@@ -58,11 +58,11 @@ func (g *Game) renderHUD(dst *core.Screen, boardX, boardW int) {
 	// Title with bright yellow
 	title := "2048"
 	titleX := boardX + (boardW-len(title))/2
-	dst.DrawTextWithColor(titleX, 0, title, core.ColorBrightYellow)
+	dst.DrawTextWithColor(titleX, 0, title, core.RoleTitle)
 
 	// Score with cyan
 	scoreStr := fmt.Sprintf("Score: %d", g.score)
-	dst.DrawTextWithColor(boardX, 1, scoreStr, core.ColorCyan)
+	dst.DrawTextWithColor(boardX, 1, scoreStr, core.RoleHUD)
 
 	// Level/Target info (campaign) or Max tile (endless)
 	var infoStr string
@@ -76,7 +76,7 @@ func (g *Game) renderHUD(dst *core.Screen, boardX, boardW int) {
 	if infoX < boardX {
 		infoX = boardX
 	}
-	dst.DrawTextWithColor(infoX, 1, infoStr, core.ColorCyan)
+	dst.DrawTextWithColor(infoX, 1, infoStr, core.RoleHUD)
 
 	// Mode indicator with gray
 	modeStr := "Campaign"
@@ -84,7 +84,7 @@ func (g *Game) renderHUD(dst *core.Screen, boardX, boardW int) {
 		modeStr = "Endless"
 	}
 	modeX := boardX + (boardW-len(modeStr))/2
-	dst.DrawTextWithColor(modeX, 2, modeStr, core.ColorGray)
+	dst.DrawTextWithColor(modeX, 2, modeStr, core.RoleMuted)
 }
 
 // renderBoardGrid draws the 4x4 grid borders (without tiles).
@@ -117,19 +117,19 @@ func (g *Game) renderBoardGrid(dst *core.Screen, boardX, boardY int) {
 			default:
 				corner = '┼'
 			}
-			dst.SetWithColor(px, py, corner, core.ColorGray)
+			dst.SetWithColor(px, py, corner, core.RoleMuted)
 
 			// Draw horizontal line to the right
 			if x < BoardSize {
 				for i := 1; i < g.cellWidth; i++ {
-					dst.SetWithColor(px+i, py, '─', core.ColorGray)
+					dst.SetWithColor(px+i, py, '─', core.RoleMuted)
 				}
 			}
 
 			// Draw vertical line down
 			if y < BoardSize {
 				for i := 1; i < g.cellHeight; i++ {
-					dst.SetWithColor(px, py+i, '│', core.ColorGray)
+					dst.SetWithColor(px, py+i, '│', core.RoleMuted)
 				}
 			}
 		}
@@ -191,36 +191,39 @@ func (g *Game) renderAnimatedTiles(dst *core.Screen, boardX, boardY int) {
 	}
 }
 
-// renderTileAt draws a tile value at a logical grid position.
+// renderTileAt draws a tile at a logical grid position.
 func (g *Game) renderTileAt(dst *core.Screen, boardX, boardY, cellX, cellY, val int) {
-	// Calculate pixel position for center of cell
+	// Top-left corner of the cell interior, inside the grid lines
 	px := boardX + cellX*g.cellWidth + 1
-	py := boardY + cellY*g.cellHeight + g.cellHeight/2
+	py := boardY + cellY*g.cellHeight + 1
 
-	// Format and center value
-	valStr := strconv.Itoa(val)
-	padLeft := (g.cellWidth - 1 - len(valStr)) / 2
-	if padLeft < 0 {
-		padLeft = 0
-	}
-
-	dst.DrawTextWithColor(px+padLeft, py, valStr, tileColor(val))
+	g.drawTile(dst, px, py, val)
 }
 
-// renderTileAtFloat draws a tile value at interpolated float position.
+// renderTileAtFloat draws a tile at interpolated float position.
 func (g *Game) renderTileAtFloat(dst *core.Screen, boardX, boardY int, cellX, cellY float64, val int) {
 	// Calculate pixel position with float interpolation
 	px := boardX + int(cellX*float64(g.cellWidth)+0.5) + 1
-	py := boardY + int(cellY*float64(g.cellHeight)+0.5) + g.cellHeight/2
+	py := boardY + int(cellY*float64(g.cellHeight)+0.5) + 1
+
+	g.drawTile(dst, px, py, val)
+}
+
+// drawTile fills a tile's interior with its color and centers the value on it.
+// (px, py) is the top-left corner of the interior.
+func (g *Game) drawTile(dst *core.Screen, px, py, val int) {
+	innerW := g.cellWidth - 1
+	innerH := g.cellHeight - 1
+	dst.FillRect(core.Rect{X: px, Y: py, W: innerW, H: innerH}, ' ', core.ColorDefault, tileColor(val))
 
 	// Format and center value
 	valStr := strconv.Itoa(val)
-	padLeft := (g.cellWidth - 1 - len(valStr)) / 2
+	padLeft := (innerW - len(valStr)) / 2
 	if padLeft < 0 {
 		padLeft = 0
 	}
 
-	dst.DrawTextWithColor(px+padLeft, py, valStr, tileColor(val))
+	dst.DrawTextStyled(px+padLeft, py+g.cellHeight/2-1, valStr, core.RoleTierText, tileColor(val), core.AttrBold)
 }
 
 // renderOverlays draws game state overlays.
@@ -228,11 +231,6 @@ func (g *Game) renderOverlays(dst *core.Screen, boardX, boardY, boardW, boardH i
 	centerX := boardX + boardW/2
 	centerY := boardY + boardH/2
 
-	if g.paused {
-		g.drawOverlay(dst, centerX, centerY, "PAUSED", "Press P to resume")
-		return
-	}
-
 	if g.levelCleared {
 		targetStr := fmt.Sprintf("Target %d reached!", g.currentTarget)
 		if g.levelIndex >= LevelCount()-1 {
@@ -272,12 +270,8 @@ func (g *Game) drawOverlay(dst *core.Screen, centerX, centerY int, lines ...stri
 	boxX := centerX - boxW/2
 	boxY := centerY - boxH/2
 
-	// Clear area behind overlay
-	for y := boxY; y < boxY+boxH; y++ {
-		for x := boxX; x < boxX+boxW; x++ {
-			dst.Set(x, y, ' ')
-		}
-	}
+	// Clear area behind overlay, including tile fills
+	dst.FillRect(core.Rect{X: boxX, Y: boxY, W: boxW, H: boxH}, ' ', core.ColorDefault, core.ColorDefault)
 
 	// Draw border
 	dst.DrawBox(core.Rect{X: boxX, Y: boxY, W: boxW, H: boxH})
@@ -294,20 +288,23 @@ func (g *Game) Controls() string {
 	return "Arrow keys/WASD: Move | P: Pause | R: Restart | Q: Quit"
 }
 
-// tileColor returns the color for a tile based on its value.
+// tileTiers are the fill colors for tiles 2, 4, 8, ... 128; larger tiles use the last one.
+var tileTiers = []core.Color{
+	core.RoleTier1,
+	core.RoleTier2,
+	core.RoleTier3,
+	core.RoleTier4,
+	core.RoleTier5,
+	core.RoleTier6,
+	core.RoleTier7,
+	core.RoleTier8,
+}
+
+// tileColor returns the fill color for a tile based on its value.
 func tileColor(val int) core.Color {
-	switch {
-	case val <= 4:
-		return core.ColorWhite
-	case val <= 16:
-		return core.ColorYellow
-	case val <= 64:
-		return core.ColorOrange
-	case val <= 256:
-		return core.ColorRed
-	case val <= 1024:
-		return core.ColorMagenta
-	default:
-		return core.ColorBrightMagenta
+	tier := 0
+	for v := val; v > 2 && tier < len(tileTiers)-1; v /= 2 {
+		tier++
 	}
+	return tileTiers[tier]
 }
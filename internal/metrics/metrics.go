@@ -0,0 +1,170 @@
+// Package metrics provides a minimal Prometheus text-format exporter.
+// It covers the handful of counters and gauges the arcade server needs
+// without pulling in the full Prometheus client library.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metric types as named in the Prometheus exposition format.
+const (
+	TypeCounter = "counter"
+	TypeGauge   = "gauge"
+)
+
+// Label is a single name/value pair attached to a sample.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is one value of a metric family, optionally labelled.
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// Family is a named group of samples sharing a type and help text.
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// WriteFamily writes a metric family in Prometheus text exposition format.
+func WriteFamily(w io.Writer, f Family) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.Name, escapeHelp(f.Help), f.Name, f.Type); err != nil {
+		return err
+	}
+
+	for _, s := range f.Samples {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", f.Name, formatLabels(s.Labels), formatValue(s.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Gauge is a convenience constructor for a single unlabelled gauge family.
+func Gauge(name, help string, value float64) Family {
+	return Family{Name: name, Help: help, Type: TypeGauge, Samples: []Sample{{Value: value}}}
+}
+
+// Counter is a convenience constructor for a single unlabelled counter family.
+func Counter(name, help string, value float64) Family {
+	return Family{Name: name, Help: help, Type: TypeCounter, Samples: []Sample{{Value: value}}}
+}
+
+// CounterVec is a monotonic counter partitioned by a single label.
+// Safe for concurrent use.
+type CounterVec struct {
+	name  string
+	help  string
+	label string
+
+	mu     sync.Mutex
+	values map[string]uint64
+}
+
+// NewCounterVec creates a counter partitioned by the given label name.
+func NewCounterVec(name, help, label string) *CounterVec {
+	return &CounterVec{
+		name:   name,
+		help:   help,
+		label:  label,
+		values: make(map[string]uint64),
+	}
+}
+
+// Inc increments the counter for the given label value.
+func (c *CounterVec) Inc(labelValue string) {
+	c.Add(labelValue, 1)
+}
+
+// Add adds n to the counter for the given label value.
+func (c *CounterVec) Add(labelValue string, n uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[labelValue] += n
+}
+
+// Value returns the current count for the given label value.
+func (c *CounterVec) Value(labelValue string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[labelValue]
+}
+
+// Family returns a snapshot of the counter as a metric family, sorted by label value.
+func (c *CounterVec) Family() Family {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	samples := make([]Sample, 0, len(keys))
+	for _, k := range keys {
+		samples = append(samples, Sample{
+			Labels: []Label{{Name: c.label, Value: k}},
+			Value:  float64(c.values[k]),
+		})
+	}
+
+	return Family{Name: c.name, Help: c.help, Type: TypeCounter, Samples: samples}
+}
+
+// formatLabels renders a label set as {a="x",b="y"}, or an empty string if there are none.
+func formatLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.Name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(l.Value))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// formatValue renders a sample value the way Prometheus expects.
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escapeLabelValue escapes backslashes, quotes and newlines in label values.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// escapeHelp escapes backslashes and newlines in help text.
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
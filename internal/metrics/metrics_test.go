@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriteFamilyGauge(t *testing.T) {
+	var b strings.Builder
+	if err := WriteFamily(&b, Gauge("arcade_sessions", "Active sessions.", 3)); err != nil {
+		t.Fatalf("WriteFamily() failed: %v", err)
+	}
+
+	want := "# HELP arcade_sessions Active sessions.\n" +
+		"# TYPE arcade_sessions gauge\n" +
+		"arcade_sessions 3\n"
+	if b.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteFamilyLabelsEscaped(t *testing.T) {
+	f := Family{
+		Name: "arcade_test",
+		Help: "Line one\nline two",
+		Type: TypeCounter,
+		Samples: []Sample{
+			{Labels: []Label{{Name: "game", Value: `we"ird\name`}}, Value: 1.5},
+		},
+	}
+
+	var b strings.Builder
+	if err := WriteFamily(&b, f); err != nil {
+		t.Fatalf("WriteFamily() failed: %v", err)
+	}
+
+	out := b.String()
+	if !strings.Contains(out, `# HELP arcade_test Line one\nline two`) {
+		t.Errorf("help text not escaped: %q", out)
+	}
+	if !strings.Contains(out, `arcade_test{game="we\"ird\\name"} 1.5`) {
+		t.Errorf("label value not escaped: %q", out)
+	}
+}
+
+func TestCounterVec(t *testing.T) {
+	c := NewCounterVec("arcade_games_started_total", "Games started.", "game")
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.Inc("snake")
+		}()
+		go func() {
+			defer wg.Done()
+			c.Inc("flappy")
+		}()
+	}
+	wg.Wait()
+	c.Add("pong", 7)
+
+	if got := c.Value("snake"); got != 50 {
+		t.Errorf("snake = %d, want 50", got)
+	}
+
+	f := c.Family()
+	if len(f.Samples) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(f.Samples))
+	}
+
+	// Samples are sorted by label value
+	order := []string{"flappy", "pong", "snake"}
+	for i, s := range f.Samples {
+		if s.Labels[0].Value != order[i] {
+			t.Errorf("sample %d label = %q, want %q", i, s.Labels[0].Value, order[i])
+		}
+	}
+	if f.Samples[1].Value != 7 {
+		t.Errorf("pong = %v, want 7", f.Samples[1].Value)
+	}
+}
@@ -0,0 +1,109 @@
+package multiplayer
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxChatLen is the longest chat message, in characters. Longer messages are cut.
+const MaxChatLen = 120
+
+// chatHistory is how many hall messages new sessions are shown.
+const chatHistory = 10
+
+// ChatScope selects who hears a chat message.
+type ChatScope int
+
+const (
+	ChatScopeHall ChatScope = iota // Everyone on the server, the "arcade hall"
+	ChatScopeRoom                  // The players and spectators of the sender's lobby or match
+)
+
+// ChatFilter checks a chat message before it is sent. It returns the text to
+// send, for example with rude words masked, and false to drop the message.
+type ChatFilter func(text string) (string, bool)
+
+// NewWordFilter returns a chat filter that masks the given words, in any
+// case, with asterisks. Only whole words are masked, in any script: a word
+// ends where letters, digits, marks and underscores do.
+func NewWordFilter(words []string) ChatFilter {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	// Alternation takes the first word that matches, so longer words go
+	// first or "ass" would hide "asshole"
+	slices.SortFunc(quoted, func(a, b string) int { return len(b) - len(a) })
+	// Go's \b only knows ASCII word characters, so the start of a word is
+	// matched by hand and its end checked after the match
+	re := regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{M}\p{N}_])(` + strings.Join(quoted, "|") + `)`)
+	return func(text string) (string, bool) {
+		var b strings.Builder
+		done := 0
+		for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[2], m[3]
+			if next, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isWordRune(next) {
+				continue
+			}
+			b.WriteString(text[done:start])
+			b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[start:end])))
+			done = end
+		}
+		b.WriteString(text[done:])
+		return b.String(), true
+	}
+}
+
+// isWordRune reports whether r is part of a word for NewWordFilter.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.In(r, unicode.L, unicode.M, unicode.N)
+}
+
+// cleanChat trims a chat message, drops characters that could upset a
+// terminal and cuts it to MaxChatLen.
+func cleanChat(text string) string {
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == '\n':
+			return ' '
+		case !unicode.IsPrint(r):
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(text))
+	if utf8.RuneCountInString(text) > MaxChatLen {
+		text = string([]rune(text)[:MaxChatLen])
+	}
+	return strings.TrimSpace(text)
+}
+
+// chatBucket limits how fast a session chats: each message takes a token,
+// and tokens come back one per ChatInterval up to ChatBurst.
+type chatBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take reports whether a message may be sent at now, using up a token.
+func (b *chatBucket) take(now time.Time, burst int, interval time.Duration) bool {
+	if b.last.IsZero() {
+		b.tokens = float64(burst)
+	} else if interval > 0 {
+		b.tokens = min(float64(burst), b.tokens+float64(now.Sub(b.last))/float64(interval))
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,106 @@
+package multiplayer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanChat(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hello", "hello"},
+		{"trimmed", "  hello \n", "hello"},
+		{"tabs and newlines", "a\tb\nc", "a b c"},
+		{"escape sequences", "\x1b[2Jboom\x1b[0m", "[2Jboom[0m"},
+		{"control characters", "bell\a back\b nul\x00", "bell back nul"},
+		{"bidi override", "abc\u202edef", "abcdef"},
+		{"unicode kept", "привет 👋", "привет 👋"},
+		{"only junk", "\x1b\a\t\n", ""},
+		{"long", strings.Repeat("é", MaxChatLen+10), strings.Repeat("é", MaxChatLen)},
+		{"trailing space after cut", strings.Repeat("a", MaxChatLen-1) + " b", strings.Repeat("a", MaxChatLen-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanChat(tt.in); got != tt.want {
+				t.Errorf("cleanChat(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordFilter(t *testing.T) {
+	filter := NewWordFilter([]string{"bad", " Darn ", "", "a.b", "плохо", "ass", "asshole"})
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"nothing here", "nothing here"},
+		{"bad", "***"},
+		{"this is BAD!", "this is ***!"},
+		{"bad bad,bad", "*** ***,***"},
+		{"darn it", "**** it"},
+		{"badge abad bad_ bad2", "badge abad bad_ bad2"}, // Not whole words
+		{"a.b axb", "*** axb"},                           // Quoted, not a pattern
+		{"это плохо", "это *****"},
+		{"ПЛОХО!", "*****!"},
+		{"плохой неплохо", "плохой неплохо"},
+		{"ébad badé", "ébad badé"},
+		{"ass asshole asshat", "*** ******* asshat"}, // Longer words win
+	}
+
+	for _, tt := range tests {
+		got, ok := filter(tt.in)
+		if !ok || got != tt.want {
+			t.Errorf("filter(%q) = %q, %v, want %q", tt.in, got, ok, tt.want)
+		}
+	}
+
+	if NewWordFilter([]string{" ", ""}) != nil {
+		t.Error("filter without words should be nil")
+	}
+}
+
+func TestChatBucket(t *testing.T) {
+	const burst, interval = 3, 2 * time.Second
+	var b chatBucket
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A full burst goes through at once
+	for i := range burst {
+		if !b.take(now, burst, interval) {
+			t.Fatalf("message %d of the burst refused", i+1)
+		}
+	}
+	if b.take(now, burst, interval) {
+		t.Fatal("message past the burst allowed")
+	}
+
+	// Tokens come back one per interval
+	if b.take(now.Add(interval/2), burst, interval) {
+		t.Error("message allowed before a token came back")
+	}
+	now = now.Add(interval)
+	if !b.take(now, burst, interval) {
+		t.Error("message refused after a token came back")
+	}
+	if b.take(now, burst, interval) {
+		t.Error("second message allowed on one token")
+	}
+
+	// But never more than the burst
+	now = now.Add(time.Hour)
+	for i := range burst {
+		if !b.take(now, burst, interval) {
+			t.Fatalf("message %d after a long wait refused", i+1)
+		}
+	}
+	if b.take(now, burst, interval) {
+		t.Error("tokens grew past the burst")
+	}
+}
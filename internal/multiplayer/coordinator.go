@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/base32"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -25,6 +26,8 @@ type CoordinatorConfig struct {
 	LobbyTimeout  time.Duration // How long before an empty lobby expires
 	TickRate      int           // Game tick rate (Hz)
 	CleanupPeriod time.Duration // How often to clean up expired lobbies
+	ChatBurst     int           // Chat messages a session can send at once
+	ChatInterval  time.Duration // Time for a session to get another chat message
 }
 
 // DefaultCoordinatorConfig returns sensible defaults.
@@ -33,6 +36,8 @@ func DefaultCoordinatorConfig() CoordinatorConfig {
 		LobbyTimeout:  2 * time.Minute,
 		TickRate:      60,
 		CleanupPeriod: 30 * time.Second,
+		ChatBurst:     5,
+		ChatInterval:  2 * time.Second,
 	}
 }
 
@@ -64,6 +69,7 @@ type Coordinator struct {
 	gameFactory GameFactory
 	sessions    *SessionRegistry
 	resultSaver MatchResultSaver // Optional, can be nil
+	chatFilter  ChatFilter       // Optional, can be nil
 
 	mu      sync.RWMutex
 	lobbies map[string]*Lobby        // code -> lobby
@@ -72,6 +78,15 @@ type Coordinator struct {
 	// Track which session is in which lobby/match
 	sessionLobby map[SessionID]string  // sessionID -> lobby code
 	sessionMatch map[SessionID]MatchID // sessionID -> matchID
+	sessionWatch map[SessionID]MatchID // spectator sessionID -> matchID
+
+	// Chat rate limits and the latest hall messages
+	chatBuckets map[SessionID]*chatBucket
+	hallLog     []ChatEvent
+
+	// Health counters carried over from ended matches
+	retiredOverruns      uint64
+	retiredDroppedInputs uint64
 
 	// Message channel for async processing
 	msgChan chan CoordinatorMessage
@@ -88,6 +103,8 @@ func NewCoordinator(cfg CoordinatorConfig, factory GameFactory, sessions *Sessio
 		matches:      make(map[MatchID]*OnlineMatch),
 		sessionLobby: make(map[SessionID]string),
 		sessionMatch: make(map[SessionID]MatchID),
+		sessionWatch: make(map[SessionID]MatchID),
+		chatBuckets:  make(map[SessionID]*chatBucket),
 		msgChan:      make(chan CoordinatorMessage, 256),
 		done:         make(chan struct{}),
 	}
@@ -99,6 +116,11 @@ func (c *Coordinator) SetResultSaver(saver MatchResultSaver) {
 	c.resultSaver = saver
 }
 
+// SetChatFilter sets the optional filter every chat message passes through.
+func (c *Coordinator) SetChatFilter(filter ChatFilter) {
+	c.chatFilter = filter
+}
+
 // Start begins the coordinator's background processing.
 func (c *Coordinator) Start() {
 	go c.processMessages()
@@ -144,8 +166,18 @@ func (c *Coordinator) handleMessage(msg CoordinatorMessage) {
 		c.handleLeaveMatch(m)
 	case PlayerInputMsg:
 		c.handlePlayerInput(m)
+	case WatchMatchMsg:
+		c.handleWatchMatch(m)
+	case StopWatchingMsg:
+		c.handleStopWatching(m)
+	case EndMatchMsg:
+		c.handleEndMatch(m)
 	case SessionDisconnectedMsg:
 		c.handleSessionDisconnected(m)
+	case ChatMsg:
+		c.handleChat(m)
+	case JoinHallMsg:
+		c.handleJoinHall(m)
 	case ReadyForRematchMsg:
 		// TODO: Implement rematch logic
 	}
@@ -330,6 +362,10 @@ func (c *Coordinator) handleMatchEnded(matchID MatchID, result MatchResult) {
 		}()
 	}
 
+	// Keep health counters monotonic after the match is gone
+	c.retiredOverruns += match.Overruns()
+	c.retiredDroppedInputs += match.DroppedInputs()
+
 	// Clean up session tracking
 	for _, sessionID := range []SessionID{match.player1Session.ID(), match.player2Session.ID()} {
 		delete(c.sessionMatch, sessionID)
@@ -348,6 +384,10 @@ func (c *Coordinator) handleMatchEnded(matchID MatchID, result MatchResult) {
 	}
 	match.player1Session.Send(endEvent)
 	match.player2Session.Send(endEvent)
+	for _, s := range match.Spectators() {
+		delete(c.sessionWatch, s.ID())
+		s.Send(endEvent)
+	}
 }
 
 func (c *Coordinator) handleCancelLobby(msg CancelLobbyMsg) {
@@ -417,6 +457,67 @@ func (c *Coordinator) handleLeaveMatch(msg LeaveMatchMsg) {
 	match.PlayerDisconnected(msg.SessionID)
 }
 
+func (c *Coordinator) handleWatchMatch(msg WatchMatchMsg) {
+	session, ok := c.sessions.Get(msg.SessionID)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match, exists := c.matches[msg.MatchID]
+	if !exists {
+		session.Send(WatchErrorEvent{Message: "Match not found"})
+		return
+	}
+	if _, playing := c.sessionMatch[msg.SessionID]; playing {
+		session.Send(WatchErrorEvent{Message: "Cannot watch while playing"})
+		return
+	}
+
+	// Only one match at a time
+	if prev, watching := c.sessionWatch[msg.SessionID]; watching {
+		if prevMatch, found := c.matches[prev]; found {
+			prevMatch.RemoveSpectator(msg.SessionID)
+		}
+	}
+
+	match.AddSpectator(session)
+	c.sessionWatch[msg.SessionID] = msg.MatchID
+	session.Send(WatchStartedEvent{MatchID: match.ID(), GameID: match.GameID(), Code: match.Code()})
+}
+
+func (c *Coordinator) handleStopWatching(msg StopWatchingMsg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopWatching(msg.SessionID)
+}
+
+// stopWatching removes a session from the match it spectates. Must be called with lock held.
+func (c *Coordinator) stopWatching(sessionID SessionID) {
+	matchID, watching := c.sessionWatch[sessionID]
+	if !watching {
+		return
+	}
+	if match, exists := c.matches[matchID]; exists {
+		match.RemoveSpectator(sessionID)
+	}
+	delete(c.sessionWatch, sessionID)
+}
+
+func (c *Coordinator) handleEndMatch(msg EndMatchMsg) {
+	c.mu.RLock()
+	match, exists := c.matches[msg.MatchID]
+	c.mu.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	match.Cancel()
+}
+
 func (c *Coordinator) handlePlayerInput(msg PlayerInputMsg) {
 	c.mu.RLock()
 	match, exists := c.matches[msg.MatchID]
@@ -433,6 +534,8 @@ func (c *Coordinator) handleSessionDisconnected(msg SessionDisconnectedMsg) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	delete(c.chatBuckets, msg.SessionID)
+
 	// Check if in lobby
 	if code, inLobby := c.sessionLobby[msg.SessionID]; inLobby {
 		if lobby, exists := c.lobbies[code]; exists {
@@ -452,6 +555,9 @@ func (c *Coordinator) handleSessionDisconnected(msg SessionDisconnectedMsg) {
 		delete(c.sessionLobby, msg.SessionID)
 	}
 
+	// Stop spectating
+	c.stopWatching(msg.SessionID)
+
 	// Check if in match
 	if matchID, inMatch := c.sessionMatch[msg.SessionID]; inMatch {
 		if match, exists := c.matches[matchID]; exists {
@@ -460,6 +566,117 @@ func (c *Coordinator) handleSessionDisconnected(msg SessionDisconnectedMsg) {
 	}
 }
 
+func (c *Coordinator) handleChat(msg ChatMsg) {
+	session, ok := c.sessions.Get(msg.SessionID)
+	if !ok {
+		return
+	}
+
+	text := cleanChat(msg.Text)
+	if text == "" {
+		return
+	}
+	if c.chatFilter != nil {
+		var allowed bool
+		if text, allowed = c.chatFilter(text); !allowed {
+			session.Send(ChatErrorEvent{Message: "Message not sent"})
+			return
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bucket, exists := c.chatBuckets[msg.SessionID]
+	if !exists {
+		bucket = &chatBucket{}
+		c.chatBuckets[msg.SessionID] = bucket
+	}
+	if !bucket.take(time.Now(), c.config.ChatBurst, c.config.ChatInterval) {
+		session.Send(ChatErrorEvent{Message: "You're chatting too fast"})
+		return
+	}
+
+	evt := ChatEvent{Scope: msg.Scope, From: cleanChat(msg.Name), Text: text, At: time.Now()}
+	if msg.Scope == ChatScopeHall {
+		c.hallLog = append(c.hallLog, evt)
+		if len(c.hallLog) > chatHistory {
+			c.hallLog = c.hallLog[len(c.hallLog)-chatHistory:]
+		}
+		c.sessions.Broadcast(evt)
+		return
+	}
+
+	members := c.roomMembers(msg.SessionID)
+	if len(members) == 0 {
+		session.Send(ChatErrorEvent{Message: "Not in a lobby or match"})
+		return
+	}
+	evt.Room = c.roomCode(msg.SessionID)
+	for _, s := range members {
+		s.Send(evt)
+	}
+}
+
+// roomCode returns the code of the lobby or match a session is in, or
+// watches. Must be called with lock held.
+func (c *Coordinator) roomCode(sessionID SessionID) string {
+	if code, inLobby := c.sessionLobby[sessionID]; inLobby {
+		return code
+	}
+	if matchID, inMatch := c.sessionMatch[sessionID]; inMatch {
+		if match, exists := c.matches[matchID]; exists {
+			return match.Code()
+		}
+	}
+	if matchID, watching := c.sessionWatch[sessionID]; watching {
+		if match, exists := c.matches[matchID]; exists {
+			return match.Code()
+		}
+	}
+	return ""
+}
+
+// roomMembers returns everyone who hears a session's room messages: the
+// players of its lobby, or the players and spectators of its match. Must be
+// called with lock held.
+func (c *Coordinator) roomMembers(sessionID SessionID) []SessionHandle {
+	if code, inLobby := c.sessionLobby[sessionID]; inLobby {
+		lobby, exists := c.lobbies[code]
+		if !exists {
+			return nil
+		}
+		members := []SessionHandle{lobby.Host}
+		if lobby.Joiner != nil {
+			members = append(members, lobby.Joiner)
+		}
+		return members
+	}
+
+	matchID, inMatch := c.sessionMatch[sessionID]
+	if !inMatch {
+		matchID, inMatch = c.sessionWatch[sessionID]
+	}
+	match, exists := c.matches[matchID]
+	if !inMatch || !exists {
+		return nil
+	}
+	return append([]SessionHandle{match.player1Session, match.player2Session}, match.Spectators()...)
+}
+
+func (c *Coordinator) handleJoinHall(msg JoinHallMsg) {
+	session, ok := c.sessions.Get(msg.SessionID)
+	if !ok {
+		return
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, evt := range c.hallLog {
+		session.Send(evt)
+	}
+}
+
 func (c *Coordinator) cleanupLoop() {
 	ticker := time.NewTicker(c.config.CleanupPeriod)
 	defer ticker.Stop()
@@ -540,3 +757,62 @@ func (c *Coordinator) MatchCount() int {
 	defer c.mu.RUnlock()
 	return len(c.matches)
 }
+
+// MatchInfo describes an active match for monitoring and moderation.
+type MatchInfo struct {
+	ID            MatchID
+	Code          string
+	GameID        string
+	Player1       SessionID
+	Player2       SessionID
+	Spectators    int
+	Overruns      uint64
+	DroppedInputs uint64
+}
+
+// Matches returns information about all active matches, sorted by ID.
+func (c *Coordinator) Matches() []MatchInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	infos := make([]MatchInfo, 0, len(c.matches))
+	for id, m := range c.matches {
+		infos = append(infos, MatchInfo{
+			ID:            id,
+			Code:          m.Code(),
+			GameID:        m.GameID(),
+			Player1:       m.player1Session.ID(),
+			Player2:       m.player2Session.ID(),
+			Spectators:    len(m.Spectators()),
+			Overruns:      m.Overruns(),
+			DroppedInputs: m.DroppedInputs(),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// TickOverruns returns the total number of overrun ticks across all matches, past and present.
+func (c *Coordinator) TickOverruns() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	total := c.retiredOverruns
+	for _, m := range c.matches {
+		total += m.Overruns()
+	}
+	return total
+}
+
+// DroppedInputs returns the total number of dropped player inputs across all matches, past and present.
+func (c *Coordinator) DroppedInputs() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	total := c.retiredDroppedInputs
+	for _, m := range c.matches {
+		total += m.DroppedInputs()
+	}
+	return total
+}
@@ -1,6 +1,10 @@
 package multiplayer
 
-import "github.com/vovakirdan/tui-arcade/internal/core"
+import (
+	"time"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
 
 // SessionEvent represents an event sent from the coordinator to a session.
 type SessionEvent interface {
@@ -95,6 +99,55 @@ type SnapshotEvent struct {
 
 func (SnapshotEvent) sessionEvent() {}
 
+// WatchStartedEvent is sent when a session starts spectating a match.
+type WatchStartedEvent struct {
+	MatchID MatchID
+	GameID  string
+	Code    string
+}
+
+func (WatchStartedEvent) sessionEvent() {}
+
+// WatchErrorEvent is sent when a session can't spectate the requested match.
+type WatchErrorEvent struct {
+	Message string
+}
+
+func (WatchErrorEvent) sessionEvent() {}
+
+// BannerEvent carries a server-wide announcement to display to the player.
+type BannerEvent struct {
+	Message string
+}
+
+func (BannerEvent) sessionEvent() {}
+
+// KickedEvent tells a session it has been removed by an admin.
+// The server closes the connection shortly after sending it.
+type KickedEvent struct {
+	Reason string
+}
+
+func (KickedEvent) sessionEvent() {}
+
+// ChatEvent carries a chat message to the sessions that hear it.
+type ChatEvent struct {
+	Scope ChatScope
+	Room  string // Code of the lobby or match, for room messages
+	From  string // Name of the sender
+	Text  string
+	At    time.Time
+}
+
+func (ChatEvent) sessionEvent() {}
+
+// ChatErrorEvent tells the sender why a chat message wasn't sent.
+type ChatErrorEvent struct {
+	Message string
+}
+
+func (ChatErrorEvent) sessionEvent() {}
+
 // GameSnapshot is the interface for game-specific snapshot data.
 type GameSnapshot interface {
 	IsGameSnapshot() // Marker method for type safety
@@ -163,6 +216,47 @@ type ReadyForRematchMsg struct {
 
 func (ReadyForRematchMsg) coordinatorMessage() {}
 
+// WatchMatchMsg requests spectating an active match.
+type WatchMatchMsg struct {
+	SessionID SessionID
+	MatchID   MatchID
+}
+
+func (WatchMatchMsg) coordinatorMessage() {}
+
+// StopWatchingMsg stops spectating a match.
+type StopWatchingMsg struct {
+	SessionID SessionID
+	MatchID   MatchID
+}
+
+func (StopWatchingMsg) coordinatorMessage() {}
+
+// EndMatchMsg requests that an active match be cancelled (e.g., by an admin).
+type EndMatchMsg struct {
+	MatchID MatchID
+}
+
+func (EndMatchMsg) coordinatorMessage() {}
+
+// ChatMsg sends a chat message to the hall or to the sender's lobby or match.
+type ChatMsg struct {
+	SessionID SessionID
+	Name      string // Name shown to the others, cleaned like the text
+	Scope     ChatScope
+	Text      string
+}
+
+func (ChatMsg) coordinatorMessage() {}
+
+// JoinHallMsg asks for the latest hall messages, sent to a session that has
+// just connected.
+type JoinHallMsg struct {
+	SessionID SessionID
+}
+
+func (JoinHallMsg) coordinatorMessage() {}
+
 // SessionDisconnectedMsg is sent when a session disconnects.
 type SessionDisconnectedMsg struct {
 	SessionID SessionID
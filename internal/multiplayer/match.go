@@ -2,6 +2,7 @@ package multiplayer
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/vovakirdan/tui-arcade/internal/core"
@@ -52,6 +53,10 @@ type OnlineMatch struct {
 	player1Session SessionHandle
 	player2Session SessionHandle
 
+	// Spectators receive snapshots but send no input
+	spectatorsMu sync.RWMutex
+	spectators   map[SessionID]SessionHandle
+
 	// Input handling
 	inputMu    sync.Mutex
 	lastInput1 core.InputFrame
@@ -64,8 +69,16 @@ type OnlineMatch struct {
 	done     chan struct{}
 	doneOnce sync.Once
 
+	// Health counters (read concurrently by metrics)
+	overruns      atomic.Uint64 // Ticks whose simulation took longer than the tick interval
+	droppedInputs atomic.Uint64 // Inputs dropped because the input channel was full
+
 	// Disconnect handling
 	disconnectChan chan SessionID
+
+	// Cancellation (e.g., ended by an admin)
+	cancelChan chan struct{}
+	cancelOnce sync.Once
 }
 
 type playerInput struct {
@@ -89,6 +102,7 @@ func NewOnlineMatch(
 		game:           game,
 		player1Session: p1Session,
 		player2Session: p2Session,
+		spectators:     make(map[SessionID]SessionHandle),
 		lastInput1:     core.NewInputFrame(),
 		lastInput2:     core.NewInputFrame(),
 		inputChan:      make(chan playerInput, 64),
@@ -96,6 +110,7 @@ func NewOnlineMatch(
 		tickRate:       tickRate,
 		done:           make(chan struct{}),
 		disconnectChan: make(chan SessionID, 2),
+		cancelChan:     make(chan struct{}),
 	}
 }
 
@@ -114,6 +129,41 @@ func (m *OnlineMatch) GameID() string {
 	return m.gameID
 }
 
+// Overruns returns how many ticks took longer to simulate than the tick interval.
+func (m *OnlineMatch) Overruns() uint64 {
+	return m.overruns.Load()
+}
+
+// DroppedInputs returns how many player inputs were dropped because the input buffer was full.
+func (m *OnlineMatch) DroppedInputs() uint64 {
+	return m.droppedInputs.Load()
+}
+
+// AddSpectator starts sending snapshots to a spectating session.
+func (m *OnlineMatch) AddSpectator(session SessionHandle) {
+	m.spectatorsMu.Lock()
+	defer m.spectatorsMu.Unlock()
+	m.spectators[session.ID()] = session
+}
+
+// RemoveSpectator stops sending snapshots to a spectating session.
+func (m *OnlineMatch) RemoveSpectator(id SessionID) {
+	m.spectatorsMu.Lock()
+	defer m.spectatorsMu.Unlock()
+	delete(m.spectators, id)
+}
+
+// Spectators returns the sessions currently spectating.
+func (m *OnlineMatch) Spectators() []SessionHandle {
+	m.spectatorsMu.RLock()
+	defer m.spectatorsMu.RUnlock()
+	list := make([]SessionHandle, 0, len(m.spectators))
+	for _, s := range m.spectators {
+		list = append(list, s)
+	}
+	return list
+}
+
 // SendInput sends player input to the match.
 // Non-blocking, uses a buffered channel.
 func (m *OnlineMatch) SendInput(player PlayerID, input core.InputFrame) {
@@ -121,6 +171,7 @@ func (m *OnlineMatch) SendInput(player PlayerID, input core.InputFrame) {
 	case m.inputChan <- playerInput{player: player, input: input}:
 	default:
 		// Channel full, drop input (rare under normal conditions)
+		m.droppedInputs.Add(1)
 	}
 }
 
@@ -132,6 +183,14 @@ func (m *OnlineMatch) PlayerDisconnected(sessionID SessionID) {
 	}
 }
 
+// Cancel ends the match without a winner.
+// Unlike Stop, the completion callback still runs so players are notified.
+func (m *OnlineMatch) Cancel() {
+	m.cancelOnce.Do(func() {
+		close(m.cancelChan)
+	})
+}
+
 // Run starts the authoritative match loop.
 // The callback is called when the match ends.
 func (m *OnlineMatch) Run(onComplete func(MatchResult)) {
@@ -151,7 +210,11 @@ func (m *OnlineMatch) Run(onComplete func(MatchResult)) {
 	for {
 		select {
 		case <-ticker.C:
+			start := time.Now()
 			result, done := m.runTick()
+			if time.Since(start) > tickDuration {
+				m.overruns.Add(1)
+			}
 			if done {
 				if onComplete != nil {
 					onComplete(result)
@@ -166,6 +229,18 @@ func (m *OnlineMatch) Run(onComplete func(MatchResult)) {
 			}
 			return
 
+		case <-m.cancelChan:
+			if onComplete != nil {
+				onComplete(MatchResult{
+					MatchID: m.id,
+					Reason:  MatchEndReasonCancelled,
+					Score1:  m.game.Score1(),
+					Score2:  m.game.Score2(),
+					Ticks:   m.tick,
+				})
+			}
+			return
+
 		case <-m.done:
 			return
 		}
@@ -181,9 +256,9 @@ func (m *OnlineMatch) runTick() (MatchResult, bool) {
 	multiInput := core.NewMultiInputFrame()
 	multiInput.SetPlayer(Player1, m.lastInput1.Clone())
 	multiInput.SetPlayer(Player2, m.lastInput2.Clone())
-	// Clear inputs after use (they're "consumed" this tick)
-	m.lastInput1.Clear()
-	m.lastInput2.Clear()
+	// Presses and releases are consumed this tick; held keys stay held until released
+	m.lastInput1.ClearEdges()
+	m.lastInput2.ClearEdges()
 	m.inputMu.Unlock()
 
 	// Run game simulation
@@ -199,6 +274,9 @@ func (m *OnlineMatch) runTick() (MatchResult, bool) {
 	}
 	m.player1Session.Send(snapshotEvent)
 	m.player2Session.Send(snapshotEvent)
+	for _, s := range m.Spectators() {
+		s.Send(snapshotEvent)
+	}
 
 	// Check for game over
 	if m.game.IsGameOver() {
@@ -223,18 +301,9 @@ func (m *OnlineMatch) drainInputs() {
 		select {
 		case pi := <-m.inputChan:
 			if pi.player == Player1 {
-				// Merge inputs (OR together actions)
-				for action, pressed := range pi.input.Actions {
-					if pressed {
-						m.lastInput1.Set(action)
-					}
-				}
+				mergeInput(&m.lastInput1, pi.input)
 			} else {
-				for action, pressed := range pi.input.Actions {
-					if pressed {
-						m.lastInput2.Set(action)
-					}
-				}
+				mergeInput(&m.lastInput2, pi.input)
 			}
 		default:
 			return
@@ -242,6 +311,27 @@ func (m *OnlineMatch) drainInputs() {
 	}
 }
 
+// mergeInput adds input sent by a player to their input for the next tick.
+// Presses are ORed together; a held action stays held until it is released.
+func mergeInput(last *core.InputFrame, input core.InputFrame) {
+	for action, pressed := range input.Actions {
+		if pressed {
+			last.Set(action)
+		}
+	}
+	for action, held := range input.HeldActions {
+		if held {
+			last.SetHeld(action)
+		}
+	}
+	for action, released := range input.ReleasedActions {
+		if released {
+			delete(last.HeldActions, action)
+			last.SetReleased(action)
+		}
+	}
+}
+
 func (m *OnlineMatch) handleDisconnect(sessionID SessionID) MatchResult {
 	var winner PlayerID
 	var reason MatchEndReason
@@ -1,6 +1,9 @@
 package multiplayer
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // SessionHandle is the transport-neutral interface for communicating with a session.
 // It allows the coordinator and matches to send events without depending on Wish/Bubble Tea.
@@ -23,6 +26,7 @@ type ChannelSession struct {
 	events   chan SessionEvent
 	done     chan struct{}
 	doneOnce sync.Once
+	dropped  atomic.Uint64 // Events dropped because the buffer was full
 }
 
 // NewChannelSession creates a new channel-based session handle.
@@ -61,16 +65,23 @@ func (s *ChannelSession) Send(evt SessionEvent) {
 		select {
 		case <-s.events:
 			// Dropped oldest
+			s.dropped.Add(1)
 		default:
 		}
 		// Try again (best effort)
 		select {
 		case s.events <- evt:
 		default:
+			s.dropped.Add(1)
 		}
 	}
 }
 
+// Dropped returns how many events were dropped because the buffer was full.
+func (s *ChannelSession) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
 // Events returns the channel to receive events from.
 // The TUI layer reads from this channel.
 func (s *ChannelSession) Events() <-chan SessionEvent {
@@ -90,11 +101,19 @@ func (s *ChannelSession) Close() {
 	})
 }
 
+// droppedCounter is implemented by sessions that count dropped events.
+type droppedCounter interface {
+	Dropped() uint64
+}
+
 // SessionRegistry tracks active sessions.
 // Thread-safe for concurrent access.
 type SessionRegistry struct {
 	mu       sync.RWMutex
 	sessions map[SessionID]SessionHandle
+
+	// retiredDropped accumulates dropped events from unregistered sessions
+	retiredDropped uint64
 }
 
 // NewSessionRegistry creates a new session registry.
@@ -115,6 +134,9 @@ func (r *SessionRegistry) Register(session SessionHandle) {
 func (r *SessionRegistry) Unregister(id SessionID) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if dc, ok := r.sessions[id].(droppedCounter); ok {
+		r.retiredDropped += dc.Dropped()
+	}
 	delete(r.sessions, id)
 }
 
@@ -126,9 +148,43 @@ func (r *SessionRegistry) Get(id SessionID) (SessionHandle, bool) {
 	return s, ok
 }
 
+// IDs returns the IDs of all registered sessions.
+func (r *SessionRegistry) IDs() []SessionID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]SessionID, 0, len(r.sessions))
+	for id := range r.sessions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Broadcast sends an event to every registered session.
+func (r *SessionRegistry) Broadcast(evt SessionEvent) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, s := range r.sessions {
+		s.Send(evt)
+	}
+}
+
 // Count returns the number of registered sessions.
 func (r *SessionRegistry) Count() int {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	return len(r.sessions)
 }
+
+// DroppedEvents returns the total number of events dropped across all sessions,
+// including sessions that have since been unregistered.
+func (r *SessionRegistry) DroppedEvents() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	total := r.retiredDropped
+	for _, s := range r.sessions {
+		if dc, ok := s.(droppedCounter); ok {
+			total += dc.Dropped()
+		}
+	}
+	return total
+}
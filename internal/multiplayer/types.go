@@ -35,6 +35,9 @@ const (
 	// MatchModeOnlinePvP is reserved for future player vs player over network.
 	// Not implemented in v0.2 but the type exists for API stability.
 	MatchModeOnlinePvP
+
+	// MatchModeLocal2P is two players sharing one keyboard.
+	MatchModeLocal2P
 )
 
 // String returns a human-readable name for the match mode.
@@ -46,6 +49,8 @@ func (m MatchMode) String() string {
 		return "vs CPU"
 	case MatchModeOnlinePvP:
 		return "Online PvP"
+	case MatchModeLocal2P:
+		return "Local 2P"
 	default:
 		return "Unknown"
 	}
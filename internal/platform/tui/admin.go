@@ -0,0 +1,416 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
+)
+
+// Admin console constants
+const (
+	adminRefreshInterval = 2 * time.Second
+	adminAuditRows       = 15  // Audit entries shown on the Audit tab
+	adminMaxMessageLen   = 120 // Max broadcast message length
+)
+
+// AdminTab identifies a tab in the admin console.
+type AdminTab int
+
+const (
+	AdminTabSessions AdminTab = iota
+	AdminTabMatches
+	AdminTabScores
+	AdminTabAudit
+	adminTabCount
+)
+
+// String returns the tab title.
+func (t AdminTab) String() string {
+	switch t {
+	case AdminTabSessions:
+		return "Sessions"
+	case AdminTabMatches:
+		return "Matches"
+	case AdminTabScores:
+		return "Scores"
+	case AdminTabAudit:
+		return "Audit"
+	default:
+		return "?"
+	}
+}
+
+// adminRefreshMsg triggers a periodic reload of the admin console lists.
+type adminRefreshMsg struct{}
+
+// AdminModel is the admin console shown to users listed in the admin key file.
+// It lists sessions and matches and performs moderation actions through the backend.
+type AdminModel struct {
+	backend adminBackend
+	width   int
+	height  int
+
+	tab    AdminTab
+	cursor int
+
+	sessions []AdminSession
+	matches  []multiplayer.MatchInfo
+	games    []registry.GameInfo
+	audit    []storage.AdminAction
+
+	confirming bool   // Waiting for y/n on a destructive action
+	banning    bool   // The pending session action is a ban rather than a kick
+	composing  bool   // Typing a broadcast message
+	message    string // Broadcast message being typed
+	status     string // Result of the last action
+
+	back     bool
+	quitting bool
+}
+
+// NewAdminModel creates the admin console.
+func NewAdminModel(backend adminBackend, width, height int) AdminModel {
+	m := AdminModel{
+		backend: backend,
+		width:   width,
+		height:  height,
+		games:   registry.List(),
+	}
+	m.refresh()
+	return m
+}
+
+// Init starts the periodic refresh.
+func (m AdminModel) Init() tea.Cmd {
+	return adminRefreshCmd()
+}
+
+// adminRefreshCmd schedules the next list refresh.
+func adminRefreshCmd() tea.Cmd {
+	return tea.Tick(adminRefreshInterval, func(time.Time) tea.Msg {
+		return adminRefreshMsg{}
+	})
+}
+
+// Update handles messages.
+func (m AdminModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case adminRefreshMsg:
+		m.refresh()
+		return m, adminRefreshCmd()
+	}
+	return m, nil
+}
+
+// refresh reloads sessions, matches and the audit log from the backend.
+func (m *AdminModel) refresh() {
+	m.sessions = m.backend.Sessions()
+	m.matches = m.backend.Matches()
+
+	if audit, err := m.backend.RecentActions(adminAuditRows); err == nil {
+		m.audit = audit
+	}
+
+	m.cursor = min(m.cursor, max(0, m.rowCount()-1))
+}
+
+// rowCount returns the number of selectable rows on the current tab.
+func (m AdminModel) rowCount() int {
+	switch m.tab {
+	case AdminTabSessions:
+		return len(m.sessions)
+	case AdminTabMatches:
+		return len(m.matches)
+	case AdminTabScores:
+		return len(m.games)
+	case AdminTabAudit:
+		return len(m.audit)
+	}
+	return 0
+}
+
+func (m AdminModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	if m.composing {
+		return m.handleComposeKey(msg)
+	}
+	if m.confirming {
+		return m.handleConfirmKey(msg)
+	}
+
+	switch msg.String() {
+	case "esc", "b":
+		m.back = true
+	case "q":
+		m.quitting = true
+		return m, tea.Quit
+	case "left", "h", "shift+tab":
+		m.tab = (m.tab + adminTabCount - 1) % adminTabCount
+		m.cursor = 0
+	case "right", "l", "tab":
+		m.tab = (m.tab + 1) % adminTabCount
+		m.cursor = 0
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < m.rowCount()-1 {
+			m.cursor++
+		}
+	case "r":
+		m.refresh()
+		m.status = ""
+	case "m":
+		m.composing = true
+		m.message = ""
+		m.status = ""
+	case "x", "enter":
+		if m.tab != AdminTabAudit && m.rowCount() > 0 {
+			m.confirming = true
+			m.banning = false
+			m.status = ""
+		}
+	case "B":
+		if m.tab == AdminTabSessions && m.rowCount() > 0 {
+			m.confirming = true
+			m.banning = true
+			m.status = ""
+		}
+	}
+
+	return m, nil
+}
+
+// handleConfirmKey runs or aborts the pending action for the selected row.
+func (m AdminModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirming = false
+	if msg.String() != "y" {
+		m.status = "Cancelled"
+		return m, nil
+	}
+	if m.cursor >= m.rowCount() {
+		// The list changed under the prompt (e.g., the player left)
+		m.status = "Selection is gone"
+		return m, nil
+	}
+
+	var err error
+	switch m.tab {
+	case AdminTabSessions:
+		s := m.sessions[m.cursor]
+		if m.banning {
+			err = m.backend.BanSession(s.ID)
+			m.status = fmt.Sprintf("Banned %s", s.User)
+		} else {
+			err = m.backend.KickSession(s.ID)
+			m.status = fmt.Sprintf("Kicked %s", s.User)
+		}
+	case AdminTabMatches:
+		match := m.matches[m.cursor]
+		err = m.backend.EndMatch(match.ID)
+		m.status = fmt.Sprintf("Ended match %s", match.Code)
+	case AdminTabScores:
+		g := m.games[m.cursor]
+		err = m.backend.ClearScores(g.ID)
+		m.status = fmt.Sprintf("Cleared %s leaderboard", g.Title)
+	}
+	if err != nil {
+		m.status = "Error: " + err.Error()
+	}
+
+	m.refresh()
+	return m, nil
+}
+
+// handleComposeKey edits and sends the broadcast message.
+func (m AdminModel) handleComposeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.composing = false
+		m.status = "Cancelled"
+	case tea.KeyEnter:
+		m.composing = false
+		if err := m.backend.Broadcast(strings.TrimSpace(m.message)); err != nil {
+			m.status = "Error: " + err.Error()
+		} else {
+			m.status = "Broadcast sent"
+		}
+		m.refresh()
+	case tea.KeyBackspace:
+		if r := []rune(m.message); len(r) > 0 {
+			m.message = string(r[:len(r)-1])
+		}
+	case tea.KeySpace:
+		m.message += " "
+	case tea.KeyRunes:
+		m.message += string(msg.Runes)
+	}
+
+	if r := []rune(m.message); len(r) > adminMaxMessageLen {
+		m.message = string(r[:adminMaxMessageLen])
+	}
+	return m, nil
+}
+
+// View renders the admin console.
+func (m AdminModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(centerText("ADMIN CONSOLE", m.width))
+	b.WriteString("\n\n")
+	b.WriteString(centerText(m.viewTabs(), m.width))
+	b.WriteString("\n\n")
+
+	rows := m.viewRows()
+	if len(rows) == 0 {
+		b.WriteString(centerText("(nothing here)", m.width))
+		b.WriteString("\n")
+	}
+
+	// Keep the list inside the screen, scrolling with the cursor
+	visible := max(1, m.height-12)
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	for i := start; i < len(rows) && i < start+visible; i++ {
+		cursor := "  "
+		if i == m.cursor && m.tab != AdminTabAudit {
+			cursor = "> "
+		}
+		b.WriteString("  ")
+		b.WriteString(cursor)
+		b.WriteString(rows[i])
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.composing:
+		b.WriteString(centerText(fmt.Sprintf("Broadcast: %s_", m.message), m.width))
+		b.WriteString("\n")
+		b.WriteString(centerText("Enter: Send  |  Esc: Cancel", m.width))
+	case m.confirming:
+		b.WriteString(centerText(m.confirmPrompt()+" (y/n)", m.width))
+	default:
+		if m.status != "" {
+			b.WriteString(centerText(m.status, m.width))
+			b.WriteString("\n")
+		}
+		b.WriteString(centerText(m.viewHelp(), m.width))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// viewTabs renders the tab bar with the active tab bracketed.
+func (m AdminModel) viewTabs() string {
+	parts := make([]string, 0, adminTabCount)
+	for t := range adminTabCount {
+		if t == m.tab {
+			parts = append(parts, "["+t.String()+"]")
+		} else {
+			parts = append(parts, " "+t.String()+" ")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// viewRows renders one line per row of the current tab.
+func (m AdminModel) viewRows() []string {
+	var rows []string
+	switch m.tab {
+	case AdminTabSessions:
+		for _, s := range m.sessions {
+			where := "menu"
+			if s.MatchID != "" {
+				where = "in match"
+			}
+			rows = append(rows, fmt.Sprintf("%s %-22s %-9s %s",
+				core.PadWidth(s.User, 16), s.Remote, where, time.Since(s.ConnectedAt).Truncate(time.Second)))
+		}
+	case AdminTabMatches:
+		for _, info := range m.matches {
+			rows = append(rows, fmt.Sprintf("%-6s %-8s %s vs %s",
+				info.Code, info.GameID, info.Player1, info.Player2))
+		}
+	case AdminTabScores:
+		for _, g := range m.games {
+			rows = append(rows, fmt.Sprintf("%-18s %s", g.ID, g.Title))
+		}
+	case AdminTabAudit:
+		for _, a := range m.audit {
+			rows = append(rows, fmt.Sprintf("%s  %s %-12s %s %s",
+				a.CreatedAt.Format("01-02 15:04"), core.PadWidth(a.Admin, 12), a.Action, a.Target, a.Details))
+		}
+	}
+	return rows
+}
+
+// confirmPrompt describes the pending action for the selected row.
+func (m AdminModel) confirmPrompt() string {
+	if m.cursor >= m.rowCount() {
+		return "Selection is gone, continue?"
+	}
+
+	switch m.tab {
+	case AdminTabSessions:
+		if m.banning {
+			return fmt.Sprintf("Ban %s (key, or IP if keyless) and disconnect?", m.sessions[m.cursor].User)
+		}
+		return fmt.Sprintf("Kick %s?", m.sessions[m.cursor].User)
+	case AdminTabMatches:
+		return fmt.Sprintf("End match %s?", m.matches[m.cursor].Code)
+	case AdminTabScores:
+		return fmt.Sprintf("Clear all %s scores?", m.games[m.cursor].Title)
+	}
+	return ""
+}
+
+// viewHelp returns the key help for the current tab.
+func (m AdminModel) viewHelp() string {
+	action := ""
+	switch m.tab {
+	case AdminTabSessions:
+		action = "X: Kick  |  Shift+B: Ban  |  "
+	case AdminTabMatches:
+		action = "X: End match  |  "
+	case AdminTabScores:
+		action = "X: Clear  |  "
+	}
+	return "Left/Right: Tabs  |  " + action + "M: Broadcast  |  R: Refresh  |  Esc: Back"
+}
+
+// WantsBack returns true if the admin wants to return to the menu.
+func (m AdminModel) WantsBack() bool {
+	return m.back
+}
+
+// IsQuitting returns true if the admin wants to quit entirely.
+func (m AdminModel) IsQuitting() bool {
+	return m.quitting
+}
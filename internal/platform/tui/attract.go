@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
+)
+
+// Attract mode timing.
+const (
+	defaultAttractAfter = time.Minute      // Idle time in the menu before the demos start
+	attractDemoTime     = 30 * time.Second // Longest a demo plays
+	attractOverTime     = 3 * time.Second  // How long a demo's game over stays up
+	attractScoresTime   = 6 * time.Second  // How long a high-score table stays up
+	attractBlink        = 500 * time.Millisecond
+)
+
+// attractScores is how many entries the high-score tables show.
+const attractScores = 5
+
+// attractIdleMsg asks a menu's attract mode whether the menu has been idle
+// long enough for the demos to start.
+type attractIdleMsg struct{ attract *attractMode }
+
+// attractTickMsg wakes a running attract mode.
+type attractTickMsg struct {
+	attract *attractMode
+	at      time.Time
+}
+
+// attractMode plays demos when nobody touches the menu, like an arcade
+// cabinet: the games play themselves with their autopilots, each followed
+// by its high-score table, with a blinking "PRESS ANY KEY". Any key stops
+// the demos and the menu comes back.
+//
+// Menus hold their attract mode by pointer, and its messages carry it, so
+// the timers of a menu that was replaced are ignored.
+type attractMode struct {
+	after     time.Duration // Idle time before the demos start; 0 never starts them
+	store     *storage.Store
+	cfg       core.RuntimeConfig
+	lastInput time.Time
+
+	running  bool
+	demos    []string // Games that play themselves
+	next     int      // Index into demos of the next demo
+	gameID   string   // Game shown in the current scene
+	game     registry.DemoGame
+	scores   []storage.ScoreEntry // High scores shown between demos
+	sceneEnd time.Time
+	loop     *frameLoop
+	screen   *core.Screen
+}
+
+// newAttractMode creates the attract mode of a menu, with demos starting
+// after it has been idle for after.
+func newAttractMode(store *storage.Store, cfg core.RuntimeConfig, after time.Duration) *attractMode {
+	return &attractMode{after: after, store: store, cfg: cfg, lastInput: time.Now()}
+}
+
+// attractDelay converts the attract setting, in seconds, to the idle time
+// before the demos start: 0 is the default and a negative value never
+// starts them.
+func attractDelay(seconds int) time.Duration {
+	switch {
+	case seconds < 0:
+		return 0
+	case seconds == 0:
+		return defaultAttractAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// wait returns the command that checks for idleness once the menu could
+// have been idle long enough.
+func (a *attractMode) wait() tea.Cmd {
+	if a.after <= 0 {
+		return nil
+	}
+	return tea.Tick(a.after-time.Since(a.lastInput), func(time.Time) tea.Msg {
+		return attractIdleMsg{a}
+	})
+}
+
+// touch records input, which stops the demos if they are running. It
+// returns whether they were, and the command that waits for idleness again.
+func (a *attractMode) touch() (bool, tea.Cmd) {
+	a.lastInput = time.Now()
+	if !a.running {
+		return false, nil
+	}
+	a.running = false
+	a.game = nil
+	a.scores = nil
+	return true, a.wait()
+}
+
+// idle starts the demos if there has been no input for long enough, and
+// otherwise waits some more.
+func (a *attractMode) idle() tea.Cmd {
+	if a.running || a.after <= 0 {
+		return nil
+	}
+	if time.Since(a.lastInput) < a.after {
+		return a.wait()
+	}
+
+	a.demos = a.demos[:0]
+	for _, id := range registry.Demos() {
+		if bindingsGameID(id) == id { // Endless variants play the same
+			a.demos = append(a.demos, id)
+		}
+	}
+	if len(a.demos) == 0 {
+		return nil
+	}
+	a.running = true
+	a.loop = newFrameLoop(a.cfg)
+	a.screen = core.NewScreen(a.cfg.ScreenW, a.cfg.ScreenH)
+	a.startDemo(time.Now())
+	a.loop.start()
+	return a.tick()
+}
+
+// tick schedules the next wake-up of the running demos.
+func (a *attractMode) tick() tea.Cmd {
+	return tea.Tick(a.loop.timestep.UntilNext(), func(t time.Time) tea.Msg {
+		return attractTickMsg{a, t}
+	})
+}
+
+// advance runs the current scene up to now and moves on to the next one
+// when it is over.
+func (a *attractMode) advance(now time.Time) tea.Cmd {
+	if !a.running {
+		return nil
+	}
+
+	a.loop.advance(now, a.step)
+	if a.game != nil && a.game.State().GameOver {
+		a.sceneEnd = minTime(a.sceneEnd, now.Add(attractOverTime))
+	}
+	if !now.Before(a.sceneEnd) {
+		if a.game != nil {
+			a.showScores(now)
+		} else {
+			a.startDemo(now)
+		}
+	}
+	if a.blinkOn(now) != a.blinkOn(now.Add(-a.loop.timestep.Step())) {
+		a.loop.invalidate()
+	}
+	return a.tick()
+}
+
+// step runs one step of the demo, if one is playing.
+func (a *attractMode) step() {
+	if a.game != nil && !a.game.State().GameOver {
+		a.game.Step(a.game.Autopilot())
+	}
+}
+
+// startDemo starts the next game in the rotation playing itself.
+func (a *attractMode) startDemo(now time.Time) {
+	a.gameID = a.demos[a.next%len(a.demos)]
+	a.next++
+	a.scores = nil
+	a.game = nil
+	a.sceneEnd = now.Add(attractDemoTime)
+
+	game, err := registry.Create(a.gameID)
+	if err != nil {
+		a.showScores(now)
+		return
+	}
+	demo, ok := game.(registry.DemoGame)
+	if !ok {
+		a.showScores(now)
+		return
+	}
+	cfg := a.cfg
+	cfg.Seed = now.UnixNano()
+	demo.Reset(cfg)
+	a.game = demo
+	a.loop.invalidate()
+}
+
+// showScores shows the high scores of the game just demonstrated.
+func (a *attractMode) showScores(now time.Time) {
+	a.game = nil
+	a.scores = nil
+	if a.store != nil {
+		if scores, err := a.store.TopScores(a.gameID, attractScores); err == nil {
+			a.scores = scores
+		}
+	}
+	a.sceneEnd = now.Add(attractScoresTime)
+	a.loop.invalidate()
+}
+
+// resize follows the terminal size, restarting a demo at the new size.
+func (a *attractMode) resize(w, h int) {
+	a.cfg.ScreenW, a.cfg.ScreenH = w, h
+	if !a.running {
+		return
+	}
+	a.screen.Resize(w, h)
+	if a.game != nil {
+		a.game.Reset(a.cfg)
+	}
+	a.loop.invalidate()
+}
+
+// view returns the current frame, turned into output with render.
+func (a *attractMode) view(render func(*core.Screen) string) string {
+	return a.loop.view(a.screen, a.render, render)
+}
+
+// render draws the current scene with the banner over it.
+func (a *attractMode) render(dst *core.Screen) {
+	text := ""
+	if a.game != nil {
+		a.game.Render(dst)
+		text = " DEMO - " + registry.Title(a.gameID) + " "
+	} else {
+		a.renderScores(dst)
+	}
+
+	if a.blinkOn(a.loop.now) {
+		text = " PRESS ANY KEY "
+	}
+	if text == "" {
+		return
+	}
+	x := max(0, (dst.Width()-core.StringWidth(text))/2)
+	dst.DrawTextStyled(x, dst.Height()-1, text, core.RoleHUD, core.ColorDefault, core.AttrBold|core.AttrReverse)
+}
+
+// renderScores draws the high-score table of the current game.
+func (a *attractMode) renderScores(dst *core.Screen) {
+	dst.Clear()
+	top := max(1, (dst.Height()-attractScores-6)/2)
+	dst.DrawTextCenteredWithColor(top, "A R C A D E", core.RoleTitle)
+	dst.DrawTextCenteredWithColor(top+2, strings.ToUpper(registry.Title(a.gameID))+" HIGH SCORES", core.RoleHUD)
+
+	if len(a.scores) == 0 {
+		dst.DrawTextCenteredWithColor(top+4, "No scores yet - be the first!", core.RoleMuted)
+		return
+	}
+	for i, s := range a.scores {
+		line := fmt.Sprintf("%d.  %8d  %s", i+1, s.Score, s.CreatedAt.Format("Jan 02"))
+		color := core.RoleHUD
+		if i == 0 {
+			color = core.RolePickup
+		}
+		dst.DrawTextCenteredWithColor(top+4+i, line, color)
+	}
+}
+
+// blinkOn reports whether the blinking half of the banner shows at t.
+func (a *attractMode) blinkOn(t time.Time) bool {
+	return t.UnixMilli()/attractBlink.Milliseconds()%2 == 0
+}
+
+// minTime returns the earlier of two times.
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
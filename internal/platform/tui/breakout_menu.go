@@ -73,6 +73,8 @@ func (m BreakoutModeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -90,6 +92,16 @@ func (m BreakoutModeModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m.handleModeSelectKey(action)
 }
 
+func (m BreakoutModeModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	var action MenuAction
+	if m.inLevelSelect {
+		m.levelCursor, action = menuMouse(msg, menuShortListRow, breakout.LevelCount(), m.levelCursor)
+		return m.handleLevelSelectKey(action)
+	}
+	m.cursor, action = menuMouse(msg, menuListRow, 3, m.cursor) // Campaign, Endless, Select Level
+	return m.handleModeSelectKey(action)
+}
+
 func (m BreakoutModeModel) handleModeSelectKey(action MenuAction) (tea.Model, tea.Cmd) {
 	switch action {
 	case MenuActionQuit:
@@ -256,6 +268,7 @@ func RunBreakoutModeSelector(cfg core.RuntimeConfig) (*BreakoutSelection, core.R
 	p := tea.NewProgram(
 		model,
 		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
 	)
 
 	finalModel, err := p.Run()
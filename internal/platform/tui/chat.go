@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
+)
+
+// chatKey starts typing a chat message in the menu, online lobbies and matches.
+const chatKey = "t"
+
+// chatLogSize is how many messages a chat log keeps.
+const chatLogSize = 50
+
+// chatShowFor is how long a message stays over a match.
+const chatShowFor = 10 * time.Second
+
+// chatMatchLines is how many messages show over a match at once.
+const chatMatchLines = 3
+
+// chatPanelWidth is the widest the hall panel under the menu gets.
+const chatPanelWidth = 64
+
+// chatHallLines is the most hall messages shown under the menu.
+const chatHallLines = 5
+
+// quickChats are the emotes sent with the number keys 1-9 during online matches.
+var quickChats = []string{
+	"Good game!", "Nice shot!", "Whoops!", "Well played.", "So close!",
+	"Rematch?", "Hi!", "Thanks!", "Bye!",
+}
+
+// chatLog keeps the latest messages of a chat channel. Sessions hold it by
+// pointer, so the copies Bubble Tea makes share it.
+type chatLog struct {
+	events []multiplayer.ChatEvent
+}
+
+// add appends a message, dropping the oldest once the log is full.
+func (l *chatLog) add(evt multiplayer.ChatEvent) {
+	l.events = append(l.events, evt)
+	if len(l.events) > chatLogSize {
+		l.events = l.events[len(l.events)-chatLogSize:]
+	}
+}
+
+// last returns up to n of the latest messages sent after since.
+func (l *chatLog) last(n int, since time.Time) []multiplayer.ChatEvent {
+	start := max(0, len(l.events)-n)
+	for start < len(l.events) && !l.events[start].At.After(since) {
+		start++
+	}
+	return l.events[start:]
+}
+
+// clear forgets every message.
+func (l *chatLog) clear() {
+	l.events = nil
+}
+
+// chatInput is a chat message being typed.
+type chatInput struct {
+	scope multiplayer.ChatScope
+	text  string
+}
+
+// handleKey edits the message. It returns the message to send when Enter
+// is pressed, and whether typing is over.
+func (c *chatInput) handleKey(msg tea.KeyMsg) (send string, done bool) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		return strings.TrimSpace(c.text), true
+	case tea.KeyEsc:
+		return "", true
+	case tea.KeyBackspace:
+		if _, size := utf8.DecodeLastRuneInString(c.text); size > 0 {
+			c.text = c.text[:len(c.text)-size]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		for _, r := range msg.Runes {
+			if unicode.IsPrint(r) && utf8.RuneCountInString(c.text) < multiplayer.MaxChatLen {
+				c.text += string(r)
+			}
+		}
+	}
+	return "", false
+}
+
+// prompt returns the message being typed as shown on screen, cut from the
+// left to fit width.
+func (c *chatInput) prompt(width int) string {
+	label := "Say: "
+	if c.scope == multiplayer.ChatScopeHall {
+		label = "Say to the hall: "
+	}
+	text := c.text + "_"
+	for len(text) > 0 && core.StringWidth(label+text) > width {
+		_, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+	}
+	return label + text
+}
+
+// chatText formats a message for display.
+func chatText(evt multiplayer.ChatEvent) string {
+	return fmt.Sprintf("%s: %s", evt.From, evt.Text)
+}
+
+// drawMatchChat draws the latest messages of a match, and the one being
+// typed, over the bottom of its screen.
+func drawMatchChat(dst *core.Screen, log *chatLog, input *chatInput, now time.Time) {
+	width := dst.Width() - 4
+	y := dst.Height() - 2
+	if input != nil {
+		dst.DrawTextStyled(2, y, core.TruncateWidth(input.prompt(width), width), core.RoleTitle, core.ColorDefault, core.AttrBold)
+		y--
+	}
+
+	events := log.last(chatMatchLines, now.Add(-chatShowFor))
+	for i := len(events) - 1; i >= 0 && y > 0; i-- {
+		dst.DrawTextWithColor(2, y, core.TruncateWidth(chatText(events[i]), width), core.RoleHUD)
+		y--
+	}
+}
+
+// overlayChat fills the free rows below a view with the latest messages of
+// a chat channel, under its title, and the one being typed.
+func overlayChat(view, title string, log *chatLog, input *chatInput, width, height int) string {
+	lines := strings.Split(strings.TrimSuffix(view, "\n"), "\n")
+	free := height - len(lines) - 1
+	if free < 2 && input == nil {
+		return view
+	}
+
+	panelW := min(chatPanelWidth, width)
+	indent := strings.Repeat(" ", max(0, (width-panelW)/2))
+	header := title + "  |  T: Chat"
+	if input != nil {
+		header = title + "  |  Enter: Send  |  Esc: Cancel"
+	}
+	panel := []string{indent + core.TruncateWidth("── "+header+" ", panelW)}
+
+	rows := free - 1
+	if input != nil {
+		rows--
+	}
+	for _, evt := range log.last(min(max(0, rows), chatHallLines), time.Time{}) {
+		panel = append(panel, indent+core.TruncateWidth(chatText(evt), panelW))
+	}
+	if input != nil {
+		panel = append(panel, indent+input.prompt(panelW))
+	}
+
+	// The panel sits at the bottom of the screen, covering the view if it must
+	for len(lines)+len(panel) < height {
+		lines = append(lines, "")
+	}
+	lines = append(lines[:max(0, height-len(panel))], panel...)
+	return strings.Join(lines, "\n")
+}
+
+// loadChatFilter reads a list of words to mask in chat, one per line, with
+// # starting a comment.
+func loadChatFilter(path string) (multiplayer.ChatFilter, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from server configuration
+	if err != nil {
+		return nil, fmt.Errorf("cannot read chat filter: %w", err)
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		word, _, _ := strings.Cut(scanner.Text(), "#")
+		if word = strings.TrimSpace(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read chat filter: %w", err)
+	}
+	return multiplayer.NewWordFilter(words), nil
+}
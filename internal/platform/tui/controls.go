@@ -0,0 +1,407 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+)
+
+// controlsTab is one page of the controls screen.
+type controlsTab struct {
+	title  string
+	gameID string        // Game whose overrides are edited; empty for all games
+	menu   bool          // Menu bindings instead of game bindings
+	player core.PlayerID // Keys of a player sharing the keyboard; 0 for none
+	mouse  bool          // The game can be played with the mouse
+}
+
+// playerActions are the actions two players sharing the keyboard each have
+// their own keys for, in display order.
+var playerActions = []core.Action{
+	core.ActionUp, core.ActionDown, core.ActionLeft, core.ActionRight, core.ActionJump, core.ActionDuck,
+}
+
+// ControlsModel shows the key bindings and lets the user rebind them and
+// switch mouse control on or off per game. Every change is checked for
+// conflicts and saved right away.
+type ControlsModel struct {
+	bindings *KeyBindings
+	save     func(*KeyBindings) error
+	savedTo  string // Where changes are saved, shown in the footer
+
+	tabs      []controlsTab
+	tab       int
+	cursor    int
+	capturing bool   // The next key is added to the action under the cursor
+	message   string // Result of the last change
+	done      bool
+	quitting  bool
+}
+
+// newControlsModel creates the controls screen for a copy of bindings.
+// save is called with the bindings after every change.
+func newControlsModel(bindings *KeyBindings, save func(*KeyBindings) error, savedTo string) ControlsModel {
+	tabs := []controlsTab{{title: "All games"}, {title: "Menus", menu: true}}
+	for _, p := range localPlayers {
+		tabs = append(tabs, controlsTab{title: fmt.Sprintf("2P: P%d", p), player: p})
+	}
+	for _, g := range registry.List() {
+		// Endless modes share their game's bindings
+		if bindingsGameID(g.ID) != g.ID {
+			continue
+		}
+		tabs = append(tabs, controlsTab{title: g.Title, gameID: g.ID, mouse: mouseSupported(g.ID)})
+	}
+	return ControlsModel{
+		bindings: bindings.Clone(),
+		save:     save,
+		savedTo:  savedTo,
+		tabs:     tabs,
+	}
+}
+
+// showGame switches to the page of a game's overrides, or to the page of
+// all games if the game has none.
+func (m *ControlsModel) showGame(gameID string) {
+	m.tab, m.cursor = 0, 0
+	for i, tab := range m.tabs {
+		if gameID != "" && tab.gameID == bindingsGameID(gameID) {
+			m.tab = i
+		}
+	}
+}
+
+// rows returns how many actions the current tab lists.
+func (m ControlsModel) rows() int {
+	if m.tabs[m.tab].menu {
+		return len(menuActions)
+	}
+	return len(m.actions())
+}
+
+// actions returns the game actions the current tab lists.
+func (m ControlsModel) actions() []core.Action {
+	if m.tabs[m.tab].player != 0 {
+		return playerActions
+	}
+	return gameActions
+}
+
+// actionName returns the name of the action under the cursor.
+func (m ControlsModel) actionName() string {
+	if m.tabs[m.tab].menu {
+		return menuActions[m.cursor].String()
+	}
+	return m.actions()[m.cursor].String()
+}
+
+// keys returns the keys of the action under the cursor.
+func (m ControlsModel) keys() []string {
+	tab := m.tabs[m.tab]
+	switch {
+	case tab.menu:
+		return m.bindings.menu[menuActions[m.cursor]]
+	case tab.player != 0:
+		return m.bindings.players[tab.player][playerActions[m.cursor]]
+	}
+	keys, _ := m.bindings.gameKeys(tab.gameID, gameActions[m.cursor])
+	return keys
+}
+
+// handleKey processes a key and returns the updated screen.
+func (m ControlsModel) handleKey(msg tea.KeyMsg) ControlsModel {
+	key := msg.String()
+
+	if m.capturing {
+		m.capturing = false
+		if key == "esc" {
+			m.message = ""
+			return m
+		}
+		return m.addKey(key)
+	}
+
+	if key == "m" && m.tabs[m.tab].mouse {
+		return m.toggleMouse()
+	}
+
+	switch key {
+	case "ctrl+c":
+		m.quitting = true
+		return m
+	case "left", "h":
+		m.tab = (m.tab + len(m.tabs) - 1) % len(m.tabs)
+		m.cursor = min(m.cursor, m.rows()-1)
+		return m
+	case "right", "l":
+		m.tab = (m.tab + 1) % len(m.tabs)
+		m.cursor = min(m.cursor, m.rows()-1)
+		return m
+	case "backspace":
+		return m.removeKey()
+	case "delete":
+		return m.resetAction()
+	}
+
+	switch m.bindings.menuAction(key) {
+	case MenuActionQuit:
+		m.quitting = true
+	case MenuActionBack:
+		m.done = true
+	case MenuActionUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case MenuActionDown:
+		if m.cursor < m.rows()-1 {
+			m.cursor++
+		}
+	case MenuActionSelect:
+		m.capturing = true
+		m.message = fmt.Sprintf("Press a key for %s (Esc: cancel)", m.actionName())
+	}
+	return m
+}
+
+// addKey binds key to the action under the cursor, unless it already
+// triggers another action on this tab.
+func (m ControlsModel) addKey(key string) ControlsModel {
+	tab := m.tabs[m.tab]
+	updated := m.bindings.Clone()
+
+	if !tab.menu && slices.Contains(reservedKeys, key) {
+		m.message = fmt.Sprintf("%s is reserved for screenshots, recording and stats", keyName(key))
+		return m
+	}
+	switch {
+	case tab.menu:
+		a := menuActions[m.cursor]
+		if other := m.bindings.menuAction(key); other != MenuActionNone {
+			m.message = m.conflictMessage(key, other.String(), other == a)
+			return m
+		}
+		updated.menu[a] = append(slices.Clone(m.keys()), key)
+	case tab.player != 0:
+		a := playerActions[m.cursor]
+		for _, p := range localPlayers {
+			if other := m.bindings.playerAction(p, key); other != core.ActionNone {
+				m.message = m.conflictMessage(key, fmt.Sprintf("%s's %s", p, other), p == tab.player && other == a)
+				return m
+			}
+		}
+		updated.setPlayerKeys(tab.player, a, append(slices.Clone(m.keys()), key))
+	default:
+		a := gameActions[m.cursor]
+		if other := m.bindings.gameAction(tab.gameID, key); other != core.ActionNone {
+			m.message = m.conflictMessage(key, other.String(), other == a)
+			return m
+		}
+		updated.setGameKeys(tab.gameID, a, append(slices.Clone(m.keys()), key))
+	}
+	return m.commit(updated)
+}
+
+// conflictMessage explains why key can't be added.
+func (m ControlsModel) conflictMessage(key, other string, same bool) string {
+	if same {
+		return fmt.Sprintf("%s is already bound to %s", keyName(key), other)
+	}
+	return fmt.Sprintf("%s is already bound to %s; remove it there first", keyName(key), other)
+}
+
+// removeKey unbinds the last key of the action under the cursor.
+// Menu actions keep at least one key so the menus stay usable.
+func (m ControlsModel) removeKey() ControlsModel {
+	keys := m.keys()
+	tab := m.tabs[m.tab]
+	switch {
+	case len(keys) == 0:
+		m.message = fmt.Sprintf("%s has no keys", m.actionName())
+		return m
+	case tab.menu && len(keys) == 1:
+		m.message = fmt.Sprintf("Menus need a key for %s", m.actionName())
+		return m
+	}
+
+	updated := m.bindings.Clone()
+	keys = slices.Clone(keys[:len(keys)-1])
+	switch {
+	case tab.menu:
+		updated.menu[menuActions[m.cursor]] = keys
+	case tab.player != 0:
+		updated.setPlayerKeys(tab.player, playerActions[m.cursor], keys)
+	default:
+		updated.setGameKeys(tab.gameID, gameActions[m.cursor], keys)
+	}
+	return m.commit(updated)
+}
+
+// resetAction makes the action under the cursor use the keys of all games
+// again, on a game's tab.
+func (m ControlsModel) resetAction() ControlsModel {
+	tab := m.tabs[m.tab]
+	if tab.gameID == "" {
+		return m
+	}
+	a := gameActions[m.cursor]
+	if _, overridden := m.bindings.gameKeys(tab.gameID, a); !overridden {
+		m.message = fmt.Sprintf("%s already uses the keys of all games", a)
+		return m
+	}
+
+	updated := m.bindings.Clone()
+	delete(updated.games[tab.gameID], a)
+	for _, key := range updated.game[a] {
+		if other := updated.gameAction(tab.gameID, key); other != a {
+			m.message = m.conflictMessage(key, other.String(), false)
+			return m
+		}
+	}
+	return m.commit(updated)
+}
+
+// toggleMouse switches mouse control on or off for the game of the tab.
+func (m ControlsModel) toggleMouse() ControlsModel {
+	tab := m.tabs[m.tab]
+	updated := m.bindings.Clone()
+	on := !updated.mouseEnabled(tab.gameID)
+	updated.mouse[bindingsGameID(tab.gameID)] = on
+	m.bindings = updated
+
+	state := onOff(on)
+	if err := m.save(updated); err != nil {
+		m.message = fmt.Sprintf("Mouse turned %s, but saving failed: %v", state, err)
+		return m
+	}
+	m.message = fmt.Sprintf("Mouse turned %s for %s", state, tab.title)
+	return m
+}
+
+// onOff returns "on" or "off".
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// commit saves changed bindings and makes them current.
+func (m ControlsModel) commit(updated *KeyBindings) ControlsModel {
+	if err := updated.validate(); err != nil {
+		m.message = err.Error()
+		return m
+	}
+	m.bindings = updated
+
+	keys := "no keys"
+	if names := keyNames(m.keys()); len(names) > 0 {
+		keys = strings.Join(names, ", ")
+	}
+	if err := m.save(updated); err != nil {
+		m.message = fmt.Sprintf("%s set to %s, but saving failed: %v", m.actionName(), keys, err)
+		return m
+	}
+	m.message = fmt.Sprintf("%s set to %s", m.actionName(), keys)
+	return m
+}
+
+// setGameKeys binds keys to a game action, for all games or as an override
+// for one game.
+func (b *KeyBindings) setGameKeys(gameID string, a core.Action, keys []string) {
+	if gameID == "" {
+		b.game[a] = keys
+		return
+	}
+	gameID = bindingsGameID(gameID)
+	if b.games[gameID] == nil {
+		b.games[gameID] = make(map[core.Action][]string)
+	}
+	b.games[gameID][a] = keys
+}
+
+// setPlayerKeys binds keys to an action of a player sharing the keyboard.
+func (b *KeyBindings) setPlayerKeys(p core.PlayerID, a core.Action, keys []string) {
+	if b.players[p] == nil {
+		b.players[p] = make(map[core.Action][]string)
+	}
+	b.players[p][a] = keys
+}
+
+// View renders the controls screen.
+func (m ControlsModel) View(width int) string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(centerText("CONTROLS", width))
+	b.WriteString("\n\n")
+
+	titles := make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		titles[i] = " " + tab.title + " "
+		if i == m.tab {
+			titles[i] = "[" + tab.title + "]"
+		}
+	}
+	b.WriteString(centerText(strings.Join(titles, " "), width))
+	b.WriteString("\n\n")
+
+	tab := m.tabs[m.tab]
+	for i := range m.rows() {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+
+		var name, note string
+		var keys []string
+		switch {
+		case tab.menu:
+			name, keys = menuActions[i].String(), m.bindings.menu[menuActions[i]]
+		case tab.player != 0:
+			name, keys = playerActions[i].String(), m.bindings.players[tab.player][playerActions[i]]
+		default:
+			var overridden bool
+			keys, overridden = m.bindings.gameKeys(tab.gameID, gameActions[i])
+			name = gameActions[i].String()
+			if tab.gameID != "" && !overridden {
+				note = "(all games)"
+			}
+		}
+
+		list := strings.Join(keyNames(keys), ", ")
+		if list == "" {
+			list = "-"
+		}
+		line := fmt.Sprintf("%s%-12s %-28s %-11s", cursor, name, list, note)
+		b.WriteString(centerText(line, width))
+		b.WriteString("\n")
+	}
+	if tab.mouse {
+		line := fmt.Sprintf("  %-12s %-28s %-11s", "Mouse", onOff(m.bindings.mouseEnabled(tab.gameID)), "(M: toggle)")
+		b.WriteString(centerText(line, width))
+		b.WriteString("\n")
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		b.WriteString(centerText(m.message, width))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(centerText(fmt.Sprintf("Saved to: %s", m.savedTo), width))
+	b.WriteString("\n")
+	help := "Left/Right: Page  |  Enter: Add key  |  Backspace: Remove key  |  Esc: Back"
+	if tab.gameID != "" {
+		help = "Left/Right: Page  |  Enter: Add key  |  Backspace: Remove key  |  Del: Use all games  |  Esc: Back"
+	}
+	b.WriteString(centerText(help, width))
+	b.WriteString("\n")
+
+	return b.String()
+}
@@ -0,0 +1,306 @@
+package tui
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/screenshot"
+)
+
+// Renderer names accepted by the --renderer flags.
+const (
+	RendererStandard = "standard" // Bubble Tea repaints every changed line of the full frame
+	RendererDiff     = "diff"     // Only changed cells are sent, using cursor positioning
+)
+
+// Escape sequences written by the diff renderer.
+const (
+	escClearScreen = "\x1b[H\x1b[2J"
+	escResetStyle  = "\x1b[0m"
+	escEnterScreen = "\x1b[?1049h\x1b[?25l" + escMouseOn // Alternate screen, hidden cursor, mouse
+	escLeaveScreen = escMouseOff + "\x1b[0m\x1b[?25h\x1b[?1049l"
+)
+
+// DiffRenderer turns screens into terminal output that only repaints changed cells.
+// It keeps the previous frame and emits cursor-positioning escapes followed by the
+// new cells; the first frame, a resize, or a theme change redraws everything.
+// A DiffRenderer is not safe for concurrent use.
+type DiffRenderer struct {
+	screen *ScreenRenderer // Theme and color profile
+
+	prev    []core.Cell
+	width   int
+	height  int
+	theme   *core.Theme
+	valid   bool   // prev matches what the terminal shows
+	text    string // Last plain view drawn by Text
+	hasText bool
+
+	sgr map[cellStyle]string // SGR sequences by style
+	buf []byte
+}
+
+// NewDiffRenderer creates a diff renderer that styles cells like r.
+// A nil r renders for the local terminal.
+func NewDiffRenderer(r *ScreenRenderer) *DiffRenderer {
+	if r == nil {
+		r = defaultScreenRenderer()
+	}
+	return &DiffRenderer{
+		screen: r,
+		sgr:    make(map[cellStyle]string),
+	}
+}
+
+// Invalidate forces the next frame to redraw the whole screen,
+// e.g. after something else has drawn on the terminal.
+func (d *DiffRenderer) Invalidate() {
+	d.valid = false
+	d.hasText = false
+}
+
+// Frame returns the output that turns the previous frame into s.
+// The result is empty if nothing changed, and is only valid until the next call.
+func (d *DiffRenderer) Frame(s *core.Screen) []byte {
+	theme := d.screen.Theme()
+	profile := d.screen.activeProfile(theme)
+	w, h := s.Width(), s.Height()
+
+	full := !d.valid || w != d.width || h != d.height || theme != d.theme
+	d.buf = d.buf[:0]
+	if full {
+		d.buf = append(d.buf, escClearScreen...)
+		if len(d.prev) != w*h {
+			d.prev = make([]core.Cell, w*h)
+		}
+	}
+
+	blank := core.Cell{Rune: ' '}
+	var current cellStyle
+	styled := false
+	cursorX, cursorY := -1, -1
+
+	for y := range h {
+		for x := range w {
+			cell := s.GetCell(x, y)
+			i := y*w + x
+			if full {
+				d.prev[i] = cell
+				if cell == blank {
+					continue // Already cleared
+				}
+			} else {
+				if cell == d.prev[i] {
+					continue
+				}
+				d.prev[i] = cell
+			}
+			if cell.IsContinuation() {
+				continue // Drawn with the wide character to its left, which changed too
+			}
+
+			if x != cursorX || y != cursorY {
+				d.buf = appendCursorPosition(d.buf, x, y)
+			}
+			if style := styleKey(cell, theme, profile); !styled || style != current {
+				d.buf = append(d.buf, d.sgrFor(style)...)
+				current, styled = style, true
+			}
+			d.buf = utf8.AppendRune(d.buf, glyph(cell, profile))
+			d.buf = append(d.buf, cell.Combining...)
+
+			// Writing the last column leaves the cursor in a pending-wrap state
+			cursorX, cursorY = x+1, y
+			if cell.Wide {
+				cursorX++
+			}
+			if cursorX >= w {
+				cursorX = -1
+			}
+		}
+	}
+	if styled {
+		d.buf = append(d.buf, escResetStyle...)
+	}
+
+	d.width, d.height = w, h
+	d.theme = theme
+	d.valid = true
+	d.hasText = false
+	return d.buf
+}
+
+// Text returns the output that shows a plain view, such as a menu.
+// Views are drawn whole; the result is empty if the view did not change.
+func (d *DiffRenderer) Text(view string) []byte {
+	if d.hasText && view == d.text {
+		return nil
+	}
+
+	d.buf = append(d.buf[:0], escClearScreen...)
+	d.buf = append(d.buf, strings.ReplaceAll(view, "\n", "\r\n")...)
+	d.buf = append(d.buf, escResetStyle...)
+
+	d.text = view
+	d.hasText = true
+	d.valid = false
+	return d.buf
+}
+
+// sgrFor returns the SGR sequence that selects a style from scratch.
+func (d *DiffRenderer) sgrFor(style cellStyle) string {
+	if seq, ok := d.sgr[style]; ok {
+		return seq
+	}
+
+	seq := screenshot.AppendSGR(nil, style.fg, style.bg, style.attrs)
+	d.sgr[style] = string(seq)
+	return d.sgr[style]
+}
+
+// appendCursorPosition appends an escape that moves the cursor to the zero-based cell (x, y).
+func appendCursorPosition(buf []byte, x, y int) []byte {
+	buf = append(buf, "\x1b["...)
+	buf = strconv.AppendInt(buf, int64(y+1), 10)
+	buf = append(buf, ';')
+	buf = strconv.AppendInt(buf, int64(x+1), 10)
+	return append(buf, 'H')
+}
+
+// screenViewer is implemented by models that can show their current frame as a screen.
+// ViewScreen returns nil when the model is showing a plain view instead.
+type screenViewer interface {
+	ViewScreen() *core.Screen
+}
+
+// diffModel runs a model with the diff renderer instead of Bubble Tea's renderer.
+// The program must be started with tea.WithoutRenderer; after every update the
+// model's screen (or its plain view) is written to out.
+type diffModel struct {
+	model  tea.Model
+	frames *DiffRenderer
+	out    io.Writer
+}
+
+// newDiffModel wraps a model for diff rendering to out.
+func newDiffModel(model tea.Model, frames *DiffRenderer, out io.Writer) diffModel {
+	return diffModel{model: model, frames: frames, out: out}
+}
+
+// Init enters the alternate screen and draws the first frame.
+func (m diffModel) Init() tea.Cmd {
+	io.WriteString(m.out, escEnterScreen) //nolint:errcheck // A broken output ends the program anyway
+	cmd := m.model.Init()
+	m.present()
+	return cmd
+}
+
+// Update forwards the message and draws the result.
+func (m diffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.WindowSizeMsg); ok {
+		m.frames.Invalidate()
+	}
+
+	next, cmd := m.model.Update(msg)
+	m.model = next
+	m.present()
+	return m, cmd
+}
+
+// View is unused: frames are written by Update.
+func (m diffModel) View() string {
+	return ""
+}
+
+// present writes the changes since the last frame.
+func (m diffModel) present() {
+	if sv, ok := m.model.(screenViewer); ok {
+		if s := sv.ViewScreen(); s != nil {
+			m.write(m.frames.Frame(s))
+			return
+		}
+	}
+	m.write(m.frames.Text(m.model.View()))
+}
+
+// write sends output to the terminal.
+func (m diffModel) write(out []byte) {
+	if len(out) > 0 {
+		m.out.Write(out) //nolint:errcheck // A broken output ends the program anyway
+	}
+}
+
+// Unwrap returns the wrapped model.
+func (m diffModel) Unwrap() tea.Model {
+	return m.model
+}
+
+// diffRendering selects the diff renderer for local games.
+var diffRendering bool
+
+// SetRenderer selects how local games are drawn: RendererStandard or RendererDiff.
+func SetRenderer(name string) {
+	diffRendering = name == RendererDiff
+}
+
+// localResizeInterval is how often the local terminal size is checked in diff mode.
+const localResizeInterval = 250 * time.Millisecond
+
+// runDiff runs a model on the local terminal with the diff renderer.
+// Without Bubble Tea's renderer the program neither sets up the terminal nor
+// reports resizes, so both are done here.
+func runDiff(model tea.Model) (tea.Model, error) {
+	fd := int(os.Stdin.Fd()) //nolint:gosec // File descriptors fit in an int
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return model, err
+	}
+	defer term.Restore(fd, state) //nolint:errcheck // Best-effort restore
+
+	p := tea.NewProgram(
+		newDiffModel(model, NewDiffRenderer(nil), os.Stdout),
+		tea.WithoutRenderer(),
+	)
+
+	done := make(chan struct{})
+	defer close(done)
+	go watchLocalSize(p, done)
+
+	final, err := p.Run()
+	os.Stdout.WriteString(escLeaveScreen) //nolint:errcheck // Best-effort restore
+
+	if dm, ok := final.(diffModel); ok {
+		return dm.Unwrap(), err
+	}
+	return final, err
+}
+
+// watchLocalSize sends a WindowSizeMsg whenever the local terminal is resized.
+func watchLocalSize(p *tea.Program, done <-chan struct{}) {
+	fd := int(os.Stdout.Fd()) //nolint:gosec // File descriptors fit in an int
+	lastW, lastH, _ := term.GetSize(fd)
+
+	ticker := time.NewTicker(localResizeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			w, h, err := term.GetSize(fd)
+			if err != nil || (w == lastW && h == lastH) {
+				continue
+			}
+			lastW, lastH = w, h
+			p.Send(tea.WindowSizeMsg{Width: w, Height: h})
+		}
+	}
+}
@@ -0,0 +1,472 @@
+package tui
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// shadowCell is a cell of the emulated terminal.
+type shadowCell struct {
+	text string // Character with any combining marks; "" for the right half of a wide character
+	wide bool
+	sgr  string // SGR sequence the cell was written with; "" for the default style
+}
+
+// shadowTerm applies the diff renderer's output to a grid, the way an
+// xterm-compatible terminal would: writing the last column leaves the cursor
+// there with a wrap pending, and overwriting half of a wide character blanks
+// the other half.
+type shadowTerm struct {
+	t     *testing.T
+	w, h  int
+	cells []shadowCell
+	x, y  int
+	wrap  bool // The last column was written; the next character goes on the next line
+	sgr   string
+	lastX int // Cell the last character went to, for combining marks
+	lastY int
+}
+
+func newShadowTerm(t *testing.T, w, h int) *shadowTerm {
+	term := &shadowTerm{t: t}
+	term.resize(w, h)
+	return term
+}
+
+// resize changes the grid size, losing its content like a terminal would
+// before the renderer redraws it.
+func (s *shadowTerm) resize(w, h int) {
+	s.w, s.h = w, h
+	s.cells = make([]shadowCell, w*h)
+	s.clear()
+}
+
+func (s *shadowTerm) clear() {
+	for i := range s.cells {
+		s.cells[i] = shadowCell{text: " "}
+	}
+}
+
+func (s *shadowTerm) cell(x, y int) *shadowCell {
+	return &s.cells[y*s.w+x]
+}
+
+// apply interprets renderer output.
+func (s *shadowTerm) apply(out []byte) {
+	s.t.Helper()
+	for len(out) > 0 {
+		switch out[0] {
+		case '\x1b':
+			out = s.escape(out)
+		case '\r':
+			s.x, s.wrap = 0, false
+			out = out[1:]
+		case '\n':
+			s.y++
+			out = out[1:]
+		default:
+			r, size := utf8.DecodeRune(out)
+			s.print(r)
+			out = out[size:]
+		}
+	}
+}
+
+// escape interprets the CSI sequence at the start of out and returns the rest.
+func (s *shadowTerm) escape(out []byte) []byte {
+	s.t.Helper()
+	if len(out) < 2 || out[1] != '[' {
+		s.t.Fatalf("unexpected escape %q", out)
+	}
+	end := 2
+	for end < len(out) && (out[end] < 0x40 || out[end] > 0x7e) {
+		end++
+	}
+	if end == len(out) {
+		s.t.Fatalf("unterminated escape %q", out)
+	}
+	seq, params := string(out[:end+1]), string(out[2:end])
+
+	switch out[end] {
+	case 'H':
+		row, col := 1, 1
+		if params != "" {
+			r, c, _ := strings.Cut(params, ";")
+			row, _ = strconv.Atoi(r)
+			col, _ = strconv.Atoi(c)
+		}
+		s.x, s.y, s.wrap = col-1, row-1, false
+		if s.x < 0 || s.x >= s.w || s.y < 0 || s.y >= s.h {
+			s.t.Fatalf("cursor moved off screen to %d;%d", row, col)
+		}
+	case 'J':
+		if params != "2" {
+			s.t.Fatalf("unexpected erase %q", seq)
+		}
+		s.clear()
+	case 'm':
+		s.sgr = seq
+		if seq == escResetStyle {
+			s.sgr = ""
+		}
+	default:
+		s.t.Fatalf("unexpected escape %q", seq)
+	}
+	return out[end+1:]
+}
+
+// print writes a character at the cursor.
+func (s *shadowTerm) print(r rune) {
+	s.t.Helper()
+	width := core.RuneWidth(r)
+	if width == 0 {
+		s.cell(s.lastX, s.lastY).text += string(r)
+		return
+	}
+
+	if s.wrap {
+		s.x, s.y, s.wrap = 0, s.y+1, false
+	}
+	if s.y >= s.h {
+		s.t.Fatalf("wrote %q below the screen", r)
+	}
+	if s.x+width > s.w {
+		s.t.Fatalf("wide %q written in the last column of row %d", r, s.y)
+	}
+
+	for x := s.x; x < s.x+width; x++ {
+		s.breakWide(x)
+	}
+	*s.cell(s.x, s.y) = shadowCell{text: string(r), wide: width == 2, sgr: s.sgr}
+	if width == 2 {
+		*s.cell(s.x+1, s.y) = shadowCell{sgr: s.sgr}
+	}
+	s.lastX, s.lastY = s.x, s.y
+
+	s.x += width
+	if s.x >= s.w {
+		s.x, s.wrap = s.w-1, true
+	}
+}
+
+// breakWide blanks the other half of a wide character about to be
+// partly overwritten at column x of the cursor's row.
+func (s *shadowTerm) breakWide(x int) {
+	c := s.cell(x, s.y)
+	switch {
+	case c.wide && x+1 < s.w:
+		*s.cell(x+1, s.y) = shadowCell{text: " "}
+	case c.text == "" && x > 0:
+		*s.cell(x-1, s.y) = shadowCell{text: " "}
+	}
+}
+
+// check fails the test if the emulated terminal does not show s as d draws it.
+func (s *shadowTerm) check(d *DiffRenderer, screen *core.Screen) {
+	s.t.Helper()
+	if screen.Width() != s.w || screen.Height() != s.h {
+		s.t.Fatalf("terminal is %dx%d, screen %dx%d", s.w, s.h, screen.Width(), screen.Height())
+	}
+	theme := d.screen.Theme()
+	profile := d.screen.activeProfile(theme)
+
+	for y := range s.h {
+		for x := range s.w {
+			want := screen.GetCell(x, y)
+			got := *s.cell(x, y)
+			if want.IsContinuation() {
+				if got.text != "" {
+					s.t.Fatalf("cell %d,%d: got %q, want the right half of a wide character", x, y, got.text)
+				}
+				continue
+			}
+
+			text := string(glyph(want, profile)) + want.Combining
+			sgr := d.sgrFor(styleKey(want, theme, profile))
+			if sgr == escResetStyle {
+				sgr = ""
+			}
+			if got.text != text || got.wide != want.Wide || got.sgr != sgr {
+				s.t.Fatalf("cell %d,%d: got %q (wide %v, style %q), want %q (wide %v, style %q)",
+					x, y, got.text, got.wide, got.sgr, text, want.Wide, sgr)
+			}
+		}
+	}
+}
+
+// newTestDiff returns a diff renderer and an emulated terminal of the given size.
+func newTestDiff(t *testing.T, w, h int) (*DiffRenderer, *shadowTerm) {
+	return NewDiffRenderer(benchScreenRenderer()), newShadowTerm(t, w, h)
+}
+
+// cursorMoves counts the cursor-positioning escapes in out.
+func cursorMoves(out []byte) int {
+	n := 0
+	for i := 0; i < len(out); i++ {
+		if out[i] != '\x1b' {
+			continue
+		}
+		j := i + 2
+		for j < len(out) && (out[j] < 0x40 || out[j] > 0x7e) {
+			j++
+		}
+		if j < len(out) && out[j] == 'H' {
+			n++
+		}
+		i = j
+	}
+	return n
+}
+
+func TestDiffFirstFrameDrawsEverything(t *testing.T) {
+	d, term := newTestDiff(t, 20, 5)
+	s := core.NewScreen(20, 5)
+	s.DrawTextWithColor(0, 0, "Score: 42", core.RoleHUD)
+	s.DrawTextStyled(3, 2, "GAME OVER", core.RoleAlert, core.ColorDefault, core.AttrBold)
+	s.SetBackground(10, 4, core.RGB(40, 80, 120))
+
+	out := d.Frame(s)
+	if !bytes.HasPrefix(out, []byte(escClearScreen)) {
+		t.Errorf("first frame should start by clearing the screen: %q", out)
+	}
+	term.apply(out)
+	term.check(d, s)
+
+	if out := d.Frame(s); len(out) != 0 {
+		t.Errorf("unchanged frame should be empty, got %q", out)
+	}
+}
+
+func TestDiffSingleCell(t *testing.T) {
+	d, term := newTestDiff(t, 20, 5)
+	s := core.NewScreen(20, 5)
+	s.DrawText(0, 0, "Lives: 3")
+	term.apply(d.Frame(s))
+
+	s.Set(7, 0, '2')
+	out := d.Frame(s)
+	if bytes.Contains(out, []byte(escClearScreen)) {
+		t.Fatalf("a one-cell change should not clear the screen: %q", out)
+	}
+	if want := "\x1b[1;8H"; !bytes.HasPrefix(out, []byte(want)) || cursorMoves(out) != 1 {
+		t.Errorf("expected one cursor move to %q, got %q", want, out)
+	}
+	term.apply(out)
+	term.check(d, s)
+
+	// A style change alone is a change too
+	s.SetColor(7, 0, core.RoleAlert)
+	term.apply(d.Frame(s))
+	term.check(d, s)
+}
+
+func TestDiffRunsAndLines(t *testing.T) {
+	d, term := newTestDiff(t, 30, 6)
+	s := core.NewScreen(30, 6)
+	for y := range 6 {
+		s.DrawText(0, y, strings.Repeat(".", 30))
+	}
+	term.apply(d.Frame(s))
+
+	// A run of adjacent changes needs a single cursor move
+	s.DrawTextWithColor(5, 2, "##########", core.RoleWall)
+	out := d.Frame(s)
+	if n := cursorMoves(out); n != 1 {
+		t.Errorf("run of changes: %d cursor moves, want 1: %q", n, out)
+	}
+	term.apply(out)
+	term.check(d, s)
+
+	// Two runs on one line need two
+	s.DrawText(1, 3, "ab")
+	s.DrawText(20, 3, "cd")
+	out = d.Frame(s)
+	if n := cursorMoves(out); n != 2 {
+		t.Errorf("two runs: %d cursor moves, want 2: %q", n, out)
+	}
+	term.apply(out)
+	term.check(d, s)
+
+	// A whole line is rewritten in one go, without clearing the screen
+	s.DrawTextWithColor(0, 4, strings.Repeat("=", 30), core.RoleGround)
+	out = d.Frame(s)
+	if n := cursorMoves(out); n != 1 || bytes.Contains(out, []byte(escClearScreen)) {
+		t.Errorf("full line: %d cursor moves, want 1 and no clear: %q", n, out)
+	}
+	term.apply(out)
+	term.check(d, s)
+
+	// Several full lines, each written to the last column
+	for y := range 6 {
+		s.DrawText(0, y, strings.Repeat(string(rune('A'+y)), 30))
+	}
+	term.apply(d.Frame(s))
+	term.check(d, s)
+}
+
+func TestDiffWideCharacters(t *testing.T) {
+	d, term := newTestDiff(t, 12, 3)
+	s := core.NewScreen(12, 3)
+	s.DrawText(0, 0, "abcdefghijkl")
+	term.apply(d.Frame(s))
+
+	steps := []struct {
+		name string
+		draw func()
+	}{
+		{"wide over narrow", func() { s.DrawText(2, 0, "漢字") }},
+		{"narrow over wide", func() { s.DrawText(2, 0, "xy") }},
+		{"wide again", func() { s.DrawText(2, 0, "漢字") }},
+		{"shifted by one", func() { s.DrawText(3, 0, "漢字") }},
+		{"narrow over the right half", func() { s.Set(4, 0, 'z') }},
+		{"wide in the last two columns", func() { s.DrawText(10, 0, "字") }},
+		{"wide that does not fit", func() { s.DrawText(11, 1, "字") }},
+		{"change past an unchanged cell", func() { s.DrawText(0, 1, "漢"); s.Set(3, 1, 'q') }},
+		{"combining mark", func() { s.DrawText(0, 2, "é") }},
+		{"colored wide", func() { s.DrawTextWithColor(5, 2, "漢", core.RolePlayer) }},
+		{"wide to blank", func() { s.Erase(core.Rect{X: 0, Y: 0, W: 12, H: 1}) }},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			term.t = t
+			step.draw()
+			term.apply(d.Frame(s))
+			term.check(d, s)
+		})
+	}
+}
+
+func TestDiffLastColumn(t *testing.T) {
+	d, term := newTestDiff(t, 10, 4)
+	s := core.NewScreen(10, 4)
+	term.apply(d.Frame(s))
+
+	// After the last column the cursor waits to wrap; the next change must be positioned
+	s.Set(9, 0, '|')
+	s.Set(9, 1, '|')
+	s.Set(1, 2, '-')
+	s.Set(9, 2, '|')
+	s.Set(0, 3, '+')
+	out := d.Frame(s)
+	if n := cursorMoves(out); n != 5 {
+		t.Errorf("%d cursor moves, want 5: %q", n, out)
+	}
+	term.apply(out)
+	term.check(d, s)
+
+	// Bottom-right corner, the spot that scrolls some terminals when misused
+	s.Set(9, 3, '#')
+	term.apply(d.Frame(s))
+	term.check(d, s)
+}
+
+func TestDiffResizeRedraws(t *testing.T) {
+	d, term := newTestDiff(t, 20, 5)
+	s := core.NewScreen(20, 5)
+	s.DrawText(0, 0, "before the resize")
+	term.apply(d.Frame(s))
+
+	s.Resize(14, 7)
+	s.DrawText(0, 6, "bottom")
+	term.resize(14, 7)
+	out := d.Frame(s)
+	if !bytes.HasPrefix(out, []byte(escClearScreen)) {
+		t.Fatalf("frame after a resize should redraw everything: %q", out)
+	}
+	term.apply(out)
+	term.check(d, s)
+}
+
+func TestDiffThemeChangeRedraws(t *testing.T) {
+	d, term := newTestDiff(t, 20, 5)
+	s := core.NewScreen(20, 5)
+	s.DrawTextWithColor(0, 0, "@@@", core.RolePlayer)
+	s.DrawText(0, 1, "plain")
+	term.apply(d.Frame(s))
+
+	theme := core.NewTheme("test")
+	theme.Set(core.RolePlayer, core.RGB(255, 0, 255))
+	d.screen.SetTheme(theme)
+	out := d.Frame(s)
+	if !bytes.HasPrefix(out, []byte(escClearScreen)) {
+		t.Fatalf("frame after a theme change should redraw everything: %q", out)
+	}
+	term.apply(out)
+	term.check(d, s)
+}
+
+func TestDiffInvalidateAndText(t *testing.T) {
+	d, term := newTestDiff(t, 20, 5)
+	s := core.NewScreen(20, 5)
+	s.DrawText(0, 0, "game")
+	term.apply(d.Frame(s))
+
+	// A plain view replaces the frame
+	view := "Menu\n> Pong\n  Snake"
+	out := d.Text(view)
+	term.apply(out)
+	for y, line := range strings.Split(view, "\n") {
+		for x, r := range []rune(line) {
+			if got := term.cell(x, y).text; got != string(r) {
+				t.Fatalf("view cell %d,%d: got %q, want %q", x, y, got, string(r))
+			}
+		}
+	}
+	if out := d.Text(view); out != nil {
+		t.Errorf("unchanged view should be empty, got %q", out)
+	}
+
+	// The next frame redraws everything over the view
+	out = d.Frame(s)
+	if !bytes.HasPrefix(out, []byte(escClearScreen)) {
+		t.Fatalf("frame after a view should redraw everything: %q", out)
+	}
+	term.apply(out)
+	term.check(d, s)
+
+	// So does the one after Invalidate
+	d.Invalidate()
+	out = d.Frame(s)
+	if !bytes.HasPrefix(out, []byte(escClearScreen)) {
+		t.Fatalf("frame after Invalidate should redraw everything: %q", out)
+	}
+	term.apply(out)
+	term.check(d, s)
+}
+
+func TestDiffRandomFrames(t *testing.T) {
+	const w, h = 24, 8
+	d, term := newTestDiff(t, w, h)
+	s := core.NewScreen(w, h)
+	rng := rand.New(rand.NewSource(1))
+
+	texts := []string{"a", "bc", "漢", "字x", "é", "#", " ", "ｗｉｄｅ"}
+	colors := []core.Color{core.ColorDefault, core.RolePlayer, core.RoleWall, core.RGB(10, 200, 30), core.Color256(33)}
+	attrs := []core.Attr{0, core.AttrBold, core.AttrReverse, core.AttrUnderline | core.AttrDim}
+
+	for frame := range 300 {
+		for range rng.Intn(6) {
+			x, y := rng.Intn(w), rng.Intn(h)
+			switch rng.Intn(4) {
+			case 0:
+				s.Erase(core.Rect{X: x, Y: y, W: rng.Intn(6) + 1, H: 1})
+			case 1:
+				s.SetBackground(x, y, colors[rng.Intn(len(colors))])
+			default:
+				s.DrawTextStyled(x, y, texts[rng.Intn(len(texts))],
+					colors[rng.Intn(len(colors))], core.ColorDefault, attrs[rng.Intn(len(attrs))])
+			}
+		}
+		term.apply(d.Frame(s))
+		if t.Failed() {
+			t.Fatalf("frame %d", frame)
+		}
+		term.check(d, s)
+	}
+}
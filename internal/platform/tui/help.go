@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+)
+
+// helpKey opens the help of the current game.
+const helpKey = "?"
+
+// helpMaxWidth is the widest the help box gets on a large terminal.
+const helpMaxWidth = 64
+
+// helpKeysWidth is the width of the keys column of the controls.
+const helpKeysWidth = 16
+
+// helpLine is a line of the help: a heading, a line of text, or a legend
+// entry with its glyph drawn in the game's color.
+type helpLine struct {
+	text    string
+	heading bool
+	glyph   rune // Legend entries only
+	color   core.Color
+}
+
+// helpOverlay shows how the current game is played over the paused game:
+// its objective, the keys bound to its controls and what the symbols on
+// screen mean. Any key closes it.
+type helpOverlay struct {
+	title string
+	help  registry.Help
+	keys  *KeyMapper // Keys bound to the controls, so rebinding shows
+}
+
+// newHelpOverlay creates the help of a game played with the keys of mapper.
+func newHelpOverlay(game registry.Game, mapper *KeyMapper) *helpOverlay {
+	help, _ := registry.HelpFor(game.ID())
+	return &helpOverlay{title: game.Title(), help: help, keys: mapper}
+}
+
+// lines returns the help laid out for text width wide.
+func (h *helpOverlay) lines(width int) []helpLine {
+	var lines []helpLine
+	for _, line := range wrapText(h.help.Objective, width) {
+		lines = append(lines, helpLine{text: line})
+	}
+
+	if len(lines) > 0 {
+		lines = append(lines, helpLine{})
+	}
+	lines = append(lines, helpLine{text: "Controls", heading: true})
+	for _, c := range h.help.Controls {
+		keys := c.Keys
+		if c.Action != core.ActionNone {
+			keys = h.actionKeys(c.Action)
+		}
+		if keys == "" {
+			continue // Unbound
+		}
+		lines = append(lines, helpLine{text: fmt.Sprintf("%-*s %s", helpKeysWidth, keys, c.Text)})
+	}
+	for _, c := range []struct {
+		keys string
+		text string
+	}{
+		{h.actionKeys(core.ActionPause), "Pause menu"},
+		{helpKey, "This help"},
+	} {
+		lines = append(lines, helpLine{text: fmt.Sprintf("%-*s %s", helpKeysWidth, c.keys, c.text)})
+	}
+
+	if len(h.help.Legend) > 0 {
+		lines = append(lines, helpLine{}, helpLine{text: "Legend", heading: true})
+		for _, s := range h.help.Legend {
+			lines = append(lines, helpLine{text: s.Text, glyph: s.Glyph, color: s.Color})
+		}
+	}
+	return lines
+}
+
+// actionKeys returns the names of the keys bound to a game action. When two
+// players share the keyboard, the keys of their sections are named per
+// player.
+func (h *helpOverlay) actionKeys(a core.Action) string {
+	b := h.keys.bindings
+	if h.keys.twoPlayer {
+		var parts []string
+		for _, p := range localPlayers {
+			if keys := b.players[p][a]; len(keys) > 0 {
+				parts = append(parts, fmt.Sprintf("P%d %s", p, strings.Join(keyNames(keys), "/")))
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, ", ")
+		}
+	}
+	keys, _ := b.gameKeys(h.keys.gameID, a)
+	return strings.Join(keyNames(keys), "/")
+}
+
+// render draws the help over the game, centered on dst.
+func (h *helpOverlay) render(dst *core.Screen) {
+	const footer = "Press any key to return"
+
+	w := min(helpMaxWidth, dst.Width())
+	lines := h.lines(w - 6)
+	box := core.NewRect(0, 0, w, min(len(lines)+6, dst.Height()))
+	box.X = (dst.Width() - box.W) / 2
+	box.Y = (dst.Height() - box.H) / 2
+
+	dst.DrawRect(box, ' ')
+	dst.DrawBox(box)
+	dst.DrawTextCenteredWithColor(box.Y+1, h.title, core.RoleTitle)
+	for i, line := range lines {
+		x, y := box.X+3, box.Y+3+i
+		if y >= box.Bottom()-3 {
+			break // Cut off on a short terminal
+		}
+		switch {
+		case line.heading:
+			dst.DrawTextStyled(x, y, line.text, core.RoleHUD, core.ColorDefault, core.AttrBold)
+		case line.glyph != 0:
+			dst.SetWithColor(x, y, line.glyph, line.color)
+			dst.DrawText(x+2, y, line.text)
+		default:
+			dst.DrawText(x, y, line.text)
+		}
+	}
+	dst.DrawTextCenteredWithColor(box.Bottom()-2, footer, core.RoleMuted)
+}
+
+// wrapText breaks text into lines at most width wide, between words.
+func wrapText(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case core.StringWidth(line)+1+core.StringWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
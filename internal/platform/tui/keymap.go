@@ -1,55 +1,545 @@
 package tui
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/vovakirdan/tui-arcade/internal/config"
 	"github.com/vovakirdan/tui-arcade/internal/core"
-	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
 )
 
-// KeyMapper translates Bubble Tea key messages to game actions.
-// This centralizes key bindings and makes them testable.
-type KeyMapper struct{}
+// keysSettingKey is the user setting holding a player's key bindings as
+// keys.yaml, for players on the SSH server.
+const keysSettingKey = "keys"
+
+// reservedKeys are handled by the game loop before key bindings, so they
+// can't be bound to game actions.
+var reservedKeys = []string{screenshotKey, recordKey, statsKey, helpKey, chatKey}
+
+// gameActions are the rebindable game actions, in display order.
+var gameActions = []core.Action{
+	core.ActionUp, core.ActionDown, core.ActionLeft, core.ActionRight,
+	core.ActionJump, core.ActionDuck, core.ActionConfirm, core.ActionBack,
+	core.ActionPause, core.ActionRestart, core.ActionQuit,
+}
+
+// localPlayers are the players that can share one keyboard.
+var localPlayers = []core.PlayerID{core.Player1, core.Player2}
+
+// KeyBindings maps keys to game and menu actions and says which games are
+// played with the mouse. The built-in bindings come from the embedded
+// keys.yaml; ~/.arcade/keys.yaml or a player's profile override them action
+// by action.
+type KeyBindings struct {
+	game    map[core.Action][]string
+	menu    map[MenuAction][]string
+	games   map[string]map[core.Action][]string        // Per-game overrides of game
+	players map[core.PlayerID]map[core.Action][]string // Keys of each player sharing the keyboard
+	mouse   map[string]bool                            // Games with mouse control on
+}
+
+// DefaultKeyBindings returns the built-in key bindings.
+func DefaultKeyBindings() *KeyBindings {
+	b := &KeyBindings{
+		game:    make(map[core.Action][]string),
+		menu:    make(map[MenuAction][]string),
+		games:   make(map[string]map[core.Action][]string),
+		players: make(map[core.PlayerID]map[core.Action][]string),
+		mouse:   make(map[string]bool),
+	}
+	if err := b.apply(config.DefaultKeys()); err != nil {
+		panic(fmt.Errorf("built-in key bindings: %w", err))
+	}
+	return b
+}
+
+// keyBindings are the bindings of the local terminal and the base of every
+// SSH player's bindings. Nil until first used or set.
+var keyBindings *KeyBindings
+
+// currentKeyBindings returns the bindings set with SetKeyBindings, or the
+// built-in ones.
+func currentKeyBindings() *KeyBindings {
+	if keyBindings == nil {
+		keyBindings = DefaultKeyBindings()
+	}
+	return keyBindings
+}
+
+// SetKeyBindings sets the key bindings used by models created afterwards.
+func SetKeyBindings(b *KeyBindings) {
+	keyBindings = b
+}
+
+// LoadKeyBindings returns the built-in bindings overridden by
+// ~/.arcade/keys.yaml. If the file can't be used, the built-in bindings are
+// returned along with the error.
+func LoadKeyBindings() (*KeyBindings, error) {
+	b := DefaultKeyBindings()
+	file, err := config.LoadUserKeys()
+	if err != nil || file == nil {
+		return b, err
+	}
+	user := b.Clone()
+	err = checkKeysGames(*file)
+	if err == nil {
+		err = user.apply(*file)
+	}
+	if err != nil {
+		return b, fmt.Errorf("key bindings %s: %w", config.UserKeysPath(), err)
+	}
+	return user, nil
+}
 
-// NewKeyMapper creates a new key mapper with default bindings.
+// LoadPlayerKeyBindings returns the bindings an SSH player saved in their
+// profile, on top of the server's bindings. Bindings that no longer load are
+// ignored. profile is the player's key fingerprint; see LoadSettings.
+func LoadPlayerKeyBindings(store *storage.Store, profile string) *KeyBindings {
+	base := currentKeyBindings()
+	if store == nil || profile == "" {
+		return base
+	}
+	data, ok, err := store.GetUserSetting(profile, keysSettingKey)
+	if err != nil || !ok {
+		return base
+	}
+	file, err := config.ParseKeys([]byte(data))
+	if err != nil {
+		return base
+	}
+	b := base.Clone()
+	if err := b.apply(file); err != nil {
+		return base
+	}
+	return b
+}
+
+// Clone returns a copy of the bindings that can be changed independently.
+func (b *KeyBindings) Clone() *KeyBindings {
+	clone := &KeyBindings{
+		game:    make(map[core.Action][]string, len(b.game)),
+		menu:    make(map[MenuAction][]string, len(b.menu)),
+		games:   make(map[string]map[core.Action][]string, len(b.games)),
+		players: make(map[core.PlayerID]map[core.Action][]string, len(b.players)),
+		mouse:   maps.Clone(b.mouse),
+	}
+	for a, keys := range b.game {
+		clone.game[a] = slices.Clone(keys)
+	}
+	for a, keys := range b.menu {
+		clone.menu[a] = slices.Clone(keys)
+	}
+	for id, over := range b.games {
+		clone.games[id] = make(map[core.Action][]string, len(over))
+		for a, keys := range over {
+			clone.games[id][a] = slices.Clone(keys)
+		}
+	}
+	for p, actions := range b.players {
+		clone.players[p] = make(map[core.Action][]string, len(actions))
+		for a, keys := range actions {
+			clone.players[p][a] = slices.Clone(keys)
+		}
+	}
+	return clone
+}
+
+// apply overrides the bindings of every action listed in file and checks
+// the result for keys bound twice.
+func (b *KeyBindings) apply(file config.KeysFile) error {
+	for name, keys := range file.Game {
+		a, ok := core.ActionByName(name)
+		if !ok {
+			return fmt.Errorf("game: unknown action %q", name)
+		}
+		b.game[a] = normalizeKeys(keys)
+	}
+	for name, keys := range file.Menu {
+		a, ok := menuActionByName(name)
+		if !ok {
+			return fmt.Errorf("menu: unknown action %q", name)
+		}
+		b.menu[a] = normalizeKeys(keys)
+	}
+	for id, actions := range file.Games {
+		id = bindingsGameID(id)
+		if b.games[id] == nil {
+			b.games[id] = make(map[core.Action][]string)
+		}
+		for name, keys := range actions {
+			a, ok := core.ActionByName(name)
+			if !ok {
+				return fmt.Errorf("games.%s: unknown action %q", id, name)
+			}
+			b.games[id][a] = normalizeKeys(keys)
+		}
+	}
+	for p, actions := range playerSections(file) {
+		if b.players[p] == nil {
+			b.players[p] = make(map[core.Action][]string)
+		}
+		for name, keys := range actions {
+			a, ok := core.ActionByName(name)
+			if !ok {
+				return fmt.Errorf("%s: unknown action %q", playerSection(p), name)
+			}
+			b.players[p][a] = normalizeKeys(keys)
+		}
+	}
+	for id, on := range file.Mouse {
+		b.mouse[bindingsGameID(id)] = on
+	}
+	return b.validate()
+}
+
+// playerSections returns the player sections of a keys file by player.
+func playerSections(file config.KeysFile) map[core.PlayerID]map[string][]string {
+	return map[core.PlayerID]map[string][]string{
+		core.Player1: file.Player1,
+		core.Player2: file.Player2,
+	}
+}
+
+// playerSection returns the name of a player's section in keys.yaml.
+func playerSection(p core.PlayerID) string {
+	return fmt.Sprintf("player%d", p)
+}
+
+// checkKeysGames reports a per-game section or mouse setting for a game that
+// doesn't exist, which would otherwise be ignored silently.
+func checkKeysGames(file config.KeysFile) error {
+	for id := range file.Games {
+		if !registry.Exists(id) {
+			return fmt.Errorf("games: unknown game %q", id)
+		}
+	}
+	for id := range file.Mouse {
+		if !registry.Exists(id) {
+			return fmt.Errorf("mouse: unknown game %q", id)
+		}
+	}
+	return nil
+}
+
+// validate reports a key bound to two actions of one section, or a game
+// action bound to a reserved key.
+func (b *KeyBindings) validate() error {
+	if err := checkSection("game", b.game); err != nil {
+		return err
+	}
+	for id, over := range b.games {
+		if err := checkSection("games."+id, over); err != nil {
+			return err
+		}
+	}
+	if err := b.checkPlayers(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]MenuAction)
+	for _, a := range menuActions {
+		for _, key := range b.menu[a] {
+			if other, ok := seen[key]; ok {
+				return fmt.Errorf("menu: %s is bound to both %s and %s", keyName(key), other, a)
+			}
+			seen[key] = a
+		}
+	}
+	return nil
+}
+
+// checkSection reports a key bound to two game actions, or a reserved key.
+func checkSection(section string, actions map[core.Action][]string) error {
+	seen := make(map[string]core.Action)
+	for _, a := range gameActions {
+		for _, key := range actions[a] {
+			if slices.Contains(reservedKeys, key) {
+				return fmt.Errorf("%s: %s is reserved and can't be bound to %s", section, keyName(key), a)
+			}
+			if other, ok := seen[key]; ok {
+				return fmt.Errorf("%s: %s is bound to both %s and %s", section, keyName(key), other, a)
+			}
+			seen[key] = a
+		}
+	}
+	return nil
+}
+
+// checkPlayers reports a key bound twice in a player section, or given to
+// both players.
+func (b *KeyBindings) checkPlayers() error {
+	owner := make(map[string]core.PlayerID)
+	for _, p := range localPlayers {
+		if err := checkSection(playerSection(p), b.players[p]); err != nil {
+			return err
+		}
+		for _, a := range gameActions {
+			for _, key := range b.players[p][a] {
+				if other, ok := owner[key]; ok && other != p {
+					return fmt.Errorf("%s: %s is also bound for %s", playerSection(p), keyName(key), other)
+				}
+				owner[key] = p
+			}
+		}
+	}
+	return nil
+}
+
+// diff returns the bindings that differ from base, as a keys file that
+// recreates b when applied to base.
+func (b *KeyBindings) diff(base *KeyBindings) config.KeysFile {
+	var file config.KeysFile
+	for _, a := range gameActions {
+		if !slices.Equal(b.game[a], base.game[a]) {
+			if file.Game == nil {
+				file.Game = make(map[string][]string)
+			}
+			file.Game[actionKey(a)] = keyNames(b.game[a])
+		}
+	}
+	for _, a := range menuActions {
+		if !slices.Equal(b.menu[a], base.menu[a]) {
+			if file.Menu == nil {
+				file.Menu = make(map[string][]string)
+			}
+			file.Menu[strings.ToLower(a.String())] = keyNames(b.menu[a])
+		}
+	}
+	for id, over := range b.games {
+		for _, a := range gameActions {
+			keys, ok := over[a]
+			baseKeys, baseOK := base.games[id][a]
+			if !ok || (baseOK && slices.Equal(keys, baseKeys)) {
+				continue
+			}
+			if file.Games == nil {
+				file.Games = make(map[string]map[string][]string)
+			}
+			if file.Games[id] == nil {
+				file.Games[id] = make(map[string][]string)
+			}
+			file.Games[id][actionKey(a)] = keyNames(keys)
+		}
+	}
+	for _, p := range localPlayers {
+		for _, a := range gameActions {
+			keys, ok := b.players[p][a]
+			baseKeys, baseOK := base.players[p][a]
+			if !ok || (baseOK && slices.Equal(keys, baseKeys)) {
+				continue
+			}
+			section := &file.Player1
+			if p == core.Player2 {
+				section = &file.Player2
+			}
+			if *section == nil {
+				*section = make(map[string][]string)
+			}
+			(*section)[actionKey(a)] = keyNames(keys)
+		}
+	}
+	for id, on := range b.mouse {
+		if baseOn, ok := base.mouse[id]; ok && baseOn == on {
+			continue
+		}
+		if file.Mouse == nil {
+			file.Mouse = make(map[string]bool)
+		}
+		file.Mouse[id] = on
+	}
+	return file
+}
+
+// mouseEnabled reports whether a game is played with the mouse.
+func (b *KeyBindings) mouseEnabled(gameID string) bool {
+	return b.mouse[bindingsGameID(gameID)]
+}
+
+// mouseSupported reports whether a game can be played with the mouse, which
+// the built-in bindings say by listing it.
+func mouseSupported(gameID string) bool {
+	_, ok := config.DefaultKeys().Mouse[bindingsGameID(gameID)]
+	return ok
+}
+
+// gameKeys returns the keys bound to a game action, and whether they are a
+// per-game override. An empty gameID returns the bindings of all games.
+func (b *KeyBindings) gameKeys(gameID string, a core.Action) ([]string, bool) {
+	if keys, ok := b.games[bindingsGameID(gameID)][a]; ok {
+		return keys, true
+	}
+	return b.game[a], false
+}
+
+// gameAction returns the game action bound to key in a game. Per-game
+// overrides take precedence over the bindings of all games.
+func (b *KeyBindings) gameAction(gameID, key string) core.Action {
+	if a := b.overrideAction(gameID, key); a != core.ActionNone {
+		return a
+	}
+	over := b.games[bindingsGameID(gameID)]
+	for _, a := range gameActions {
+		if _, overridden := over[a]; !overridden && slices.Contains(b.game[a], key) {
+			return a
+		}
+	}
+	return core.ActionNone
+}
+
+// overrideAction returns the game action bound to key by a game's
+// overrides, or ActionNone.
+func (b *KeyBindings) overrideAction(gameID, key string) core.Action {
+	over := b.games[bindingsGameID(gameID)]
+	for _, a := range gameActions {
+		if slices.Contains(over[a], key) {
+			return a
+		}
+	}
+	return core.ActionNone
+}
+
+// playerAction returns the game action bound to key for a player sharing
+// the keyboard.
+func (b *KeyBindings) playerAction(p core.PlayerID, key string) core.Action {
+	for _, a := range gameActions {
+		if slices.Contains(b.players[p][a], key) {
+			return a
+		}
+	}
+	return core.ActionNone
+}
+
+// menuAction returns the menu action bound to key.
+func (b *KeyBindings) menuAction(key string) MenuAction {
+	for _, a := range menuActions {
+		if slices.Contains(b.menu[a], key) {
+			return a
+		}
+	}
+	return MenuActionNone
+}
+
+// Mapper returns a key mapper using these bindings, with the overrides of
+// the given game. An empty gameID maps with the bindings of all games.
+func (b *KeyBindings) Mapper(gameID string) *KeyMapper {
+	return &KeyMapper{bindings: b, gameID: gameID}
+}
+
+// TwoPlayerMapper returns a key mapper for two players sharing the keyboard
+// in a game: the player sections split the keys between them.
+func (b *KeyBindings) TwoPlayerMapper(gameID string) *KeyMapper {
+	return &KeyMapper{bindings: b, gameID: gameID, twoPlayer: true}
+}
+
+// gameMapper returns the key mapper for a game, split between two players
+// if they share the keyboard in it.
+func (b *KeyBindings) gameMapper(game registry.Game) *KeyMapper {
+	if _, ok := localTwoPlayer(game); ok {
+		return b.TwoPlayerMapper(game.ID())
+	}
+	return b.Mapper(game.ID())
+}
+
+// localTwoPlayer returns the game if two players share the keyboard in it.
+func localTwoPlayer(game registry.Game) (registry.TwoPlayerGame, bool) {
+	tp, ok := game.(registry.TwoPlayerGame)
+	return tp, ok && tp.TwoPlayer()
+}
+
+// bindingsGameID returns the game whose bindings a game uses.
+// Endless variants share the bindings of their game.
+func bindingsGameID(gameID string) string {
+	return strings.TrimSuffix(gameID, "_endless")
+}
+
+// actionKey returns the name of a game action in keys.yaml.
+func actionKey(a core.Action) string {
+	return strings.ToLower(a.String())
+}
+
+// normalizeKeys converts key names from keys.yaml to the names Bubble Tea
+// reports, dropping duplicates.
+func normalizeKeys(names []string) []string {
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		key := name
+		if key == "space" {
+			key = " "
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// keyName returns the name of a key as written in keys.yaml and shown on screen.
+func keyName(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// keyNames returns the names of keys as written in keys.yaml.
+func keyNames(keys []string) []string {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = keyName(key)
+	}
+	return names
+}
+
+// KeyMapper translates Bubble Tea key messages to game and menu actions
+// using a set of key bindings.
+type KeyMapper struct {
+	bindings  *KeyBindings
+	gameID    string // Game whose per-game overrides apply; empty for none
+	twoPlayer bool   // Two players share the keyboard
+}
+
+// NewKeyMapper creates a key mapper with the current bindings and no
+// per-game overrides.
 func NewKeyMapper() *KeyMapper {
-	return &KeyMapper{}
+	return currentKeyBindings().Mapper("")
 }
 
 // MapKey translates a key message to actions for Player1.
 // Returns the action (may be ActionNone) and whether it's a quit request.
 func (km *KeyMapper) MapKey(msg tea.KeyMsg) (action core.Action, isQuit bool) {
-	key := msg.String()
-
-	// Global quit keys
-	switch key {
-	case "ctrl+c", "q":
-		return core.ActionQuit, true
-	}
-
-	// Game/menu actions
-	switch key {
-	case "w", "up":
-		return core.ActionUp, false
-	case "s", "down":
-		return core.ActionDown, false
-	case "a", "left":
-		return core.ActionLeft, false
-	case "d", "right":
-		return core.ActionRight, false
-	case " ": // Space for jump (runners)
-		return core.ActionJump, false
-	case "enter":
-		return core.ActionConfirm, false
-	case "b", "esc":
-		return core.ActionBack, false
-	case "p":
-		return core.ActionPause, false
-	case "r":
-		return core.ActionRestart, false
-	}
-
-	return core.ActionNone, false
+	action = km.bindings.gameAction(km.gameID, msg.String())
+	return action, action == core.ActionQuit
+}
+
+// MapPlayerKey translates a key message to an action and the player it is
+// for. When two players share the keyboard, the keys of the player sections
+// act for their player and the other keys for Player1. A game's overrides
+// still decide what a player's key does, so W jumps in Flappy Bird.
+func (km *KeyMapper) MapPlayerKey(msg tea.KeyMsg) (player core.PlayerID, action core.Action, isQuit bool) {
+	if km.twoPlayer {
+		key := msg.String()
+		for _, p := range localPlayers {
+			if a := km.bindings.playerAction(p, key); a != core.ActionNone {
+				if over := km.bindings.overrideAction(km.gameID, key); over != core.ActionNone {
+					a = over
+				}
+				return p, a, a == core.ActionQuit
+			}
+		}
+	}
+	action, isQuit = km.MapKey(msg)
+	return core.Player1, action, isQuit
+}
+
+// Mouse reports whether the mapper's game is played with the mouse.
+func (km *KeyMapper) Mouse() bool {
+	return km.bindings.mouseEnabled(km.gameID)
 }
 
 // MapKeyToFrame updates an input frame based on a key message.
@@ -62,14 +552,15 @@ func (km *KeyMapper) MapKeyToFrame(msg tea.KeyMsg, frame *core.InputFrame) bool
 	return isQuit
 }
 
-// MapKeyToMultiFrame updates a multi-input frame for Player1 based on a key message.
+// MapKeyToMultiFrame updates a multi-input frame for the player a key is
+// for, Player1 unless two players share the keyboard.
 // Returns true if the key was a quit request.
 func (km *KeyMapper) MapKeyToMultiFrame(msg tea.KeyMsg, frame *core.MultiInputFrame) bool {
-	action, isQuit := km.MapKey(msg)
+	player, action, isQuit := km.MapPlayerKey(msg)
 	if action != core.ActionNone {
-		p1 := frame.Player(multiplayer.Player1)
-		p1.Set(action)
-		frame.SetPlayer(multiplayer.Player1, p1)
+		in := frame.Player(player)
+		in.Set(action)
+		frame.SetPlayer(player, in)
 	}
 	return isQuit
 }
@@ -87,24 +578,44 @@ const (
 	MenuActionScoreboard
 )
 
-// MapKeyToMenuAction translates a key to a menu action.
-func (km *KeyMapper) MapKeyToMenuAction(msg tea.KeyMsg) MenuAction {
-	k := msg.String()
-
-	switch k {
-	case "ctrl+c", "q":
-		return MenuActionQuit
-	case "w", "up", "k": // vim-style k for up
-		return MenuActionUp
-	case "s", "down", "j": // vim-style j for down
-		return MenuActionDown
-	case "enter", " ":
-		return MenuActionSelect
-	case "b", "esc":
-		return MenuActionBack
-	case "tab": // Open scoreboard
-		return MenuActionScoreboard
+// menuActions are the rebindable menu actions, in display order.
+var menuActions = []MenuAction{
+	MenuActionUp, MenuActionDown, MenuActionSelect, MenuActionBack, MenuActionQuit, MenuActionScoreboard,
+}
+
+// String returns a human-readable name for the menu action.
+func (a MenuAction) String() string {
+	switch a {
+	case MenuActionNone:
+		return "None"
+	case MenuActionUp:
+		return "Up"
+	case MenuActionDown:
+		return "Down"
+	case MenuActionSelect:
+		return "Select"
+	case MenuActionBack:
+		return "Back"
+	case MenuActionQuit:
+		return "Quit"
+	case MenuActionScoreboard:
+		return "Scoreboard"
+	default:
+		return "Unknown"
 	}
+}
 
-	return MenuActionNone
+// menuActionByName returns the menu action whose String is name, ignoring case.
+func menuActionByName(name string) (MenuAction, bool) {
+	for _, a := range menuActions {
+		if strings.EqualFold(a.String(), name) {
+			return a, true
+		}
+	}
+	return MenuActionNone, false
+}
+
+// MapKeyToMenuAction translates a key to a menu action.
+func (km *KeyMapper) MapKeyToMenuAction(msg tea.KeyMsg) MenuAction {
+	return km.bindings.menuAction(msg.String())
 }
@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// Terminals only report key presses, repeated while a key is held after the
+// OS repeat delay. A key is taken as held from its first repeat, and as
+// released once repeats stop for longer than a few repeat intervals.
+const (
+	defaultRepeatGap = 100 * time.Millisecond // Before the repeat interval has been measured
+	minRepeatGap     = 40 * time.Millisecond
+	maxRepeatGap     = 150 * time.Millisecond
+)
+
+// heldKey is the state of an action whose key is down.
+type heldKey struct {
+	last    time.Time // Last press or repeat
+	repeats int       // Repeats since the press
+}
+
+// playerAction is an action of one of the players sharing the keyboard.
+type playerAction struct {
+	player core.PlayerID
+	action core.Action
+}
+
+// keyTracker turns key events into pressed, held and released actions of
+// each player. With the Kitty keyboard protocol the terminal reports
+// releases; otherwise holds are inferred from key repeat timing.
+type keyTracker struct {
+	keys     map[playerAction]*heldKey
+	released []playerAction // Released since the last apply
+	releases bool           // The terminal reports key releases
+	interval time.Duration  // Measured key repeat interval, 0 until seen
+}
+
+// newKeyTracker creates a tracker with no keys down.
+func newKeyTracker() *keyTracker {
+	return &keyTracker{keys: make(map[playerAction]*heldKey)}
+}
+
+// repeatGap is how long after its last repeat a key still counts as held.
+func (t *keyTracker) repeatGap() time.Duration {
+	if t.interval == 0 {
+		return defaultRepeatGap
+	}
+	return min(max(t.interval*5/2, minRepeatGap), maxRepeatGap)
+}
+
+// press records a key event for a player's action at now. It returns true
+// for a new press and false for a repeat of a key that is already down.
+func (t *keyTracker) press(p core.PlayerID, a core.Action, now time.Time) bool {
+	pa := playerAction{p, a}
+	if k := t.keys[pa]; k != nil {
+		since := now.Sub(k.last)
+		if t.releases || since <= t.repeatGap() {
+			if !t.releases {
+				// A smoothed estimate, so one late repeat doesn't end a hold
+				if t.interval == 0 {
+					t.interval = since
+				} else {
+					t.interval = (3*t.interval + since) / 4
+				}
+			}
+			k.repeats++
+			k.last = now
+			return false
+		}
+		// The first repeat comes after the OS repeat delay, which can't be
+		// told apart from pressing the key again, so it counts as a press.
+	}
+	t.keys[pa] = &heldKey{last: now}
+	return true
+}
+
+// release records a key release reported by the terminal.
+func (t *keyTracker) release(p core.PlayerID, a core.Action) {
+	pa := playerAction{p, a}
+	if _, ok := t.keys[pa]; ok {
+		delete(t.keys, pa)
+		t.released = append(t.released, pa)
+	}
+}
+
+// apply marks the actions of player p held at now and those released since
+// the last call on frame.
+func (t *keyTracker) apply(p core.PlayerID, frame *core.InputFrame, now time.Time) {
+	gap := t.repeatGap()
+	for pa, k := range t.keys {
+		if pa.player != p {
+			continue
+		}
+		switch {
+		case t.releases:
+			frame.SetHeld(pa.action)
+		case now.Sub(k.last) > gap:
+			delete(t.keys, pa)
+			t.released = append(t.released, pa)
+		case k.repeats > 0:
+			frame.SetHeld(pa.action)
+		}
+	}
+	pending := t.released[:0]
+	for _, pa := range t.released {
+		if pa.player == p {
+			frame.SetReleased(pa.action)
+		} else {
+			pending = append(pending, pa)
+		}
+	}
+	t.released = pending
+}
+
+// reset forgets all keys, e.g. when the game loses focus.
+func (t *keyTracker) reset() {
+	clear(t.keys)
+	t.released = t.released[:0]
+}
+
+// keyInput tracks the keys of a game and, when the terminal supports it,
+// switches on the Kitty keyboard protocol for release events while the game
+// runs. The protocol is asked for with a query; terminals that don't know it
+// stay silent and holds are inferred from repeats.
+type keyInput struct {
+	*keyTracker
+	term    io.Writer // The player's terminal; nil never enables the protocol
+	active  bool      // A game is running
+	enabled bool      // Kitty flags are pushed
+
+	sent map[core.Action]bool // Actions reported held by heldChanges
+}
+
+// newKeyInput creates the key state of a game played on term.
+func newKeyInput(term io.Writer) *keyInput {
+	return &keyInput{keyTracker: newKeyTracker(), term: term, sent: make(map[core.Action]bool)}
+}
+
+// start asks the terminal whether it supports the Kitty keyboard protocol.
+func (k *keyInput) start() {
+	k.active = true
+	if k.term != nil && !k.enabled {
+		io.WriteString(k.term, kittyQuery) //nolint:errcheck // Without an answer, holds are inferred
+	}
+}
+
+// stop switches the protocol off again and forgets held keys.
+func (k *keyInput) stop() {
+	k.active = false
+	if k.enabled {
+		io.WriteString(k.term, kittyPop) //nolint:errcheck // Leaving the alternate screen resets it too
+		k.enabled = false
+	}
+	k.releases = false
+	k.reset()
+	clear(k.sent)
+}
+
+// heldChanges returns the actions that became held and those released since
+// the last call, for games that keep key state elsewhere, like online
+// matches. It returns false when nothing changed.
+func (k *keyInput) heldChanges(now time.Time) (core.InputFrame, bool) {
+	var frame core.InputFrame
+	k.apply(core.Player1, &frame, now)
+
+	changes := core.NewInputFrame()
+	changed := false
+	for a := range frame.HeldActions {
+		if !k.sent[a] {
+			k.sent[a] = true
+			changes.SetHeld(a)
+			changed = true
+		}
+	}
+	for a := range frame.ReleasedActions {
+		if k.sent[a] {
+			delete(k.sent, a)
+			changes.SetReleased(a)
+			changed = true
+		}
+	}
+	return changes, changed
+}
+
+// handle processes the terminal's answer to the protocol query and key
+// releases. It returns the key of a release, or false for other messages.
+func (k *keyInput) handle(msg tea.Msg) (tea.KeyMsg, bool) {
+	switch msg := msg.(type) {
+	case kittySupportedMsg:
+		if k.active && !k.enabled {
+			io.WriteString(k.term, kittyPush) //nolint:errcheck // Without it, holds are inferred
+			k.enabled = true
+			k.releases = true
+			k.reset()
+		}
+	case keyReleaseMsg:
+		return msg.key, true
+	}
+	return tea.KeyMsg{}, false
+}
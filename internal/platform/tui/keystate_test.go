@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// applyAt returns the input frame a tracker produces for player 1 at now.
+func applyAt(tr *keyTracker, now time.Time) core.InputFrame {
+	var frame core.InputFrame
+	tr.apply(core.Player1, &frame, now)
+	return frame
+}
+
+func TestKeyTrackerTap(t *testing.T) {
+	tr := newKeyTracker()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if !tr.press(core.Player1, core.ActionJump, t0) {
+		t.Fatal("first press should be a new press")
+	}
+	if f := applyAt(tr, t0.Add(10*time.Millisecond)); f.HeldActions[core.ActionJump] || f.Released(core.ActionJump) {
+		t.Errorf("a tap is neither held nor released yet: %+v", f)
+	}
+
+	// No repeats follow, so the key is let go once the repeat gap passes
+	if f := applyAt(tr, t0.Add(defaultRepeatGap+time.Millisecond)); !f.Released(core.ActionJump) {
+		t.Error("tap should be released after the repeat gap")
+	}
+	if f := applyAt(tr, t0.Add(time.Second)); f.Released(core.ActionJump) {
+		t.Error("release should be reported once")
+	}
+}
+
+func TestKeyTrackerHold(t *testing.T) {
+	tr := newKeyTracker()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const osDelay, repeat = 500 * time.Millisecond, 30 * time.Millisecond
+
+	tr.press(core.Player1, core.ActionUp, t0)
+	applyAt(tr, t0.Add(defaultRepeatGap+time.Millisecond)) // Looks like a tap while the OS waits
+
+	// The first repeat after the OS delay can't be told from a new press
+	now := t0.Add(osDelay)
+	if !tr.press(core.Player1, core.ActionUp, now) {
+		t.Error("first repeat after the OS delay should count as a press")
+	}
+	for range 10 {
+		now = now.Add(repeat)
+		if tr.press(core.Player1, core.ActionUp, now) {
+			t.Fatal("repeats should not count as presses")
+		}
+		if f := applyAt(tr, now); !f.HeldActions[core.ActionUp] {
+			t.Fatal("key should be held while it repeats")
+		}
+	}
+
+	// The gap follows the measured repeat interval
+	if gap := tr.repeatGap(); gap != repeat*5/2 {
+		t.Errorf("repeat gap %v for a %v repeat interval", gap, repeat)
+	}
+	if f := applyAt(tr, now.Add(tr.repeatGap()-time.Millisecond)); !f.HeldActions[core.ActionUp] {
+		t.Error("key should stay held within the repeat gap")
+	}
+	if f := applyAt(tr, now.Add(tr.repeatGap()+time.Millisecond)); !f.Released(core.ActionUp) || f.HeldActions[core.ActionUp] {
+		t.Errorf("key should be released once repeats stop: %+v", f)
+	}
+}
+
+func TestKeyTrackerPlayers(t *testing.T) {
+	tr := newKeyTracker()
+	t0 := time.Now()
+	tr.press(core.Player1, core.ActionUp, t0)
+	tr.press(core.Player2, core.ActionUp, t0)
+
+	// Player 2's release waits for player 2's frame
+	late := t0.Add(time.Second)
+	if f := applyAt(tr, late); !f.Released(core.ActionUp) {
+		t.Error("player 1's key should be released")
+	}
+	var p2 core.InputFrame
+	tr.apply(core.Player2, &p2, late)
+	if !p2.Released(core.ActionUp) {
+		t.Error("player 2's key should be released in their own frame")
+	}
+}
+
+func TestKeyTrackerReportedReleases(t *testing.T) {
+	tr := newKeyTracker()
+	tr.releases = true
+	t0 := time.Now()
+
+	tr.press(core.Player1, core.ActionLeft, t0)
+	if f := applyAt(tr, t0.Add(time.Minute)); !f.HeldActions[core.ActionLeft] {
+		t.Error("with reported releases a key stays held until released")
+	}
+	if tr.press(core.Player1, core.ActionLeft, t0.Add(2*time.Minute)) {
+		t.Error("a repeat of a held key is not a new press")
+	}
+
+	tr.release(core.Player1, core.ActionLeft)
+	f := applyAt(tr, t0.Add(2*time.Minute))
+	if !f.Released(core.ActionLeft) || f.HeldActions[core.ActionLeft] {
+		t.Errorf("released key: %+v", f)
+	}
+	tr.release(core.Player1, core.ActionLeft) // Not down any more
+	if f := applyAt(tr, t0.Add(2*time.Minute)); f.Released(core.ActionLeft) {
+		t.Error("a key that isn't down can't be released")
+	}
+}
+
+func TestKeyInputProtocol(t *testing.T) {
+	var term bytes.Buffer
+	k := newKeyInput(&term)
+
+	// Answers to the query only count while a game runs
+	k.handle(kittySupportedMsg{})
+	if k.enabled || term.Len() != 0 {
+		t.Fatal("protocol enabled outside a game")
+	}
+
+	k.start()
+	if term.String() != kittyQuery {
+		t.Fatalf("start wrote %q, want the query", term.String())
+	}
+	term.Reset()
+	k.handle(kittySupportedMsg{})
+	if !k.enabled || !k.releases || term.String() != kittyPush {
+		t.Fatalf("answer: enabled %v, releases %v, wrote %q", k.enabled, k.releases, term.String())
+	}
+
+	key, ok := k.handle(keyReleaseMsg{runes("a")})
+	if !ok || key.String() != "a" {
+		t.Errorf("release: got %v, %v", key, ok)
+	}
+
+	term.Reset()
+	k.stop()
+	if k.enabled || k.releases || term.String() != kittyPop {
+		t.Errorf("stop: enabled %v, releases %v, wrote %q", k.enabled, k.releases, term.String())
+	}
+}
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Kitty keyboard protocol escapes (https://sw.kovidgoyal.net/kitty/keyboard-protocol/).
+const (
+	kittyQuery = "\x1b[?u"   // Asks for the current flags; only supporting terminals answer
+	kittyPush  = "\x1b[>15u" // Disambiguate, event types, alternate keys, all keys as escapes
+	kittyPop   = "\x1b[<u"   // Restores the flags from before the push
+)
+
+// Kitty key event types.
+const (
+	kittyPress   = 1
+	kittyRepeat  = 2
+	kittyRelease = 3
+)
+
+// kittySupportedMsg reports that the terminal answered the protocol query.
+type kittySupportedMsg struct{}
+
+// keyReleaseMsg reports a key release, which only the Kitty protocol sends.
+type keyReleaseMsg struct {
+	key tea.KeyMsg
+}
+
+// Kitty modifier bits, after subtracting one from the reported value.
+const (
+	kittyShift = 1
+	kittyAlt   = 2
+	kittyCtrl  = 4
+)
+
+// kittyFunctionKeys maps the numbers of "CSI number ~" keys.
+var kittyFunctionKeys = map[int]tea.KeyType{
+	2: tea.KeyInsert, 3: tea.KeyDelete, 5: tea.KeyPgUp, 6: tea.KeyPgDown,
+	7: tea.KeyHome, 8: tea.KeyEnd,
+	11: tea.KeyF1, 12: tea.KeyF2, 13: tea.KeyF3, 14: tea.KeyF4, 15: tea.KeyF5,
+	17: tea.KeyF6, 18: tea.KeyF7, 19: tea.KeyF8, 20: tea.KeyF9, 21: tea.KeyF10,
+	23: tea.KeyF11, 24: tea.KeyF12,
+}
+
+// kittyLetterKeys maps the final byte of "CSI 1;modifiers X" keys.
+var kittyLetterKeys = map[byte]tea.KeyType{
+	'A': tea.KeyUp, 'B': tea.KeyDown, 'C': tea.KeyRight, 'D': tea.KeyLeft,
+	'H': tea.KeyHome, 'F': tea.KeyEnd,
+	'P': tea.KeyF1, 'Q': tea.KeyF2, 'R': tea.KeyF3, 'S': tea.KeyF4,
+}
+
+// kittyModifiedKeys maps keys to their shift, ctrl and ctrl+shift variants,
+// which Bubble Tea reports for the legacy sequences of the same keys.
+var kittyModifiedKeys = map[tea.KeyType][3]tea.KeyType{
+	tea.KeyUp:    {tea.KeyShiftUp, tea.KeyCtrlUp, tea.KeyCtrlShiftUp},
+	tea.KeyDown:  {tea.KeyShiftDown, tea.KeyCtrlDown, tea.KeyCtrlShiftDown},
+	tea.KeyRight: {tea.KeyShiftRight, tea.KeyCtrlRight, tea.KeyCtrlShiftRight},
+	tea.KeyLeft:  {tea.KeyShiftLeft, tea.KeyCtrlLeft, tea.KeyCtrlShiftLeft},
+	tea.KeyHome:  {tea.KeyShiftHome, tea.KeyCtrlHome, tea.KeyCtrlShiftHome},
+	tea.KeyEnd:   {tea.KeyShiftEnd, tea.KeyCtrlEnd, tea.KeyCtrlShiftEnd},
+}
+
+// translateKitty turns Kitty protocol input, which Bubble Tea reports as
+// unknown CSI sequences, into key messages: presses and repeats become
+// tea.KeyMsg, releases keyReleaseMsg, and the query answer kittySupportedMsg.
+// Other messages are returned unchanged.
+func translateKitty(msg tea.Msg) tea.Msg {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		return msg
+	}
+	s, ok := msg.(fmt.Stringer)
+	if !ok {
+		return msg
+	}
+	seq, ok := unknownCSI(s.String())
+	if !ok || seq == "" {
+		return msg
+	}
+
+	params, final := seq[:len(seq)-1], seq[len(seq)-1]
+	if final == 'u' && strings.HasPrefix(params, "?") {
+		return kittySupportedMsg{}
+	}
+	key, event, ok := parseKittyKey(params, final)
+	if !ok {
+		return msg
+	}
+	if event == kittyRelease {
+		return keyReleaseMsg{key: key}
+	}
+	return key
+}
+
+// unknownCSI recovers the bytes after "ESC [" from Bubble Tea's description
+// of an unknown CSI sequence, "?CSI[49 59 50 117]?".
+func unknownCSI(s string) (string, bool) {
+	inner, ok := strings.CutPrefix(s, "?CSI[")
+	if !ok {
+		return "", false
+	}
+	inner, ok = strings.CutSuffix(inner, "]?")
+	if !ok {
+		return "", false
+	}
+	var b strings.Builder
+	for _, field := range strings.Fields(inner) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 || n > 0x7f {
+			return "", false
+		}
+		b.WriteByte(byte(n))
+	}
+	return b.String(), true
+}
+
+// parseKittyKey parses "code[:shifted];modifiers[:event]" followed by the
+// final byte of a Kitty key sequence.
+func parseKittyKey(params string, final byte) (tea.KeyMsg, int, bool) {
+	fields := strings.Split(params, ";")
+	codes := strings.Split(fields[0], ":")
+	code := 1 // "CSI A" omits the number
+	if codes[0] != "" {
+		n, err := strconv.Atoi(codes[0])
+		if err != nil {
+			return tea.KeyMsg{}, 0, false
+		}
+		code = n
+	}
+
+	mods, event := 0, kittyPress
+	if len(fields) > 1 {
+		modEvent := strings.Split(fields[1], ":")
+		if n, err := strconv.Atoi(modEvent[0]); err == nil && n > 0 {
+			mods = n - 1
+		}
+		if len(modEvent) > 1 {
+			if n, err := strconv.Atoi(modEvent[1]); err == nil {
+				event = n
+			}
+		}
+	}
+	if event < kittyPress || event > kittyRelease {
+		return tea.KeyMsg{}, 0, false
+	}
+
+	var key tea.KeyMsg
+	switch final {
+	case 'u':
+		shifted := 0
+		if len(codes) > 1 && codes[1] != "" {
+			shifted, _ = strconv.Atoi(codes[1]) //nolint:errcheck // Zero falls back to the base key
+		}
+		var ok bool
+		if key, ok = kittyTextKey(code, shifted, mods); !ok {
+			return tea.KeyMsg{}, 0, false
+		}
+	case '~':
+		t, ok := kittyFunctionKeys[code]
+		if !ok {
+			return tea.KeyMsg{}, 0, false
+		}
+		key = tea.KeyMsg{Type: kittyModifiedKey(t, mods)}
+	default:
+		t, ok := kittyLetterKeys[final]
+		if !ok || code != 1 {
+			return tea.KeyMsg{}, 0, false
+		}
+		key = tea.KeyMsg{Type: kittyModifiedKey(t, mods)}
+	}
+	key.Alt = mods&kittyAlt != 0
+	return key, event, true
+}
+
+// kittyModifiedKey returns the variant of a key for the shift and ctrl
+// modifiers, if it has one.
+func kittyModifiedKey(t tea.KeyType, mods int) tea.KeyType {
+	variants, ok := kittyModifiedKeys[t]
+	if !ok {
+		return t
+	}
+	switch mods & (kittyShift | kittyCtrl) {
+	case kittyShift:
+		return variants[0]
+	case kittyCtrl:
+		return variants[1]
+	case kittyShift | kittyCtrl:
+		return variants[2]
+	}
+	return t
+}
+
+// kittyTextKey returns the key for a "CSI code u" sequence.
+func kittyTextKey(code, shifted, mods int) (tea.KeyMsg, bool) {
+	switch code {
+	case 9:
+		if mods&kittyShift != 0 {
+			return tea.KeyMsg{Type: tea.KeyShiftTab}, true
+		}
+		return tea.KeyMsg{Type: tea.KeyTab}, true
+	case 13:
+		return tea.KeyMsg{Type: tea.KeyEnter}, true
+	case 27:
+		return tea.KeyMsg{Type: tea.KeyEsc}, true
+	case 127:
+		return tea.KeyMsg{Type: tea.KeyBackspace}, true
+	case 32:
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}, true
+	}
+
+	// Modifier keys, keypad and media keys are in the private use area
+	if code < 32 || (code >= 0xe000 && code <= 0xf8ff) || code > unicode.MaxRune {
+		return tea.KeyMsg{}, false
+	}
+	r := rune(code)
+	if mods&kittyCtrl != 0 {
+		if r >= 'a' && r <= 'z' {
+			return tea.KeyMsg{Type: tea.KeyCtrlA + tea.KeyType(r-'a')}, true
+		}
+		return tea.KeyMsg{}, false
+	}
+	if mods&kittyShift != 0 {
+		if shifted > 0 {
+			r = rune(shifted)
+		} else {
+			r = unicode.ToUpper(r)
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, true
+}
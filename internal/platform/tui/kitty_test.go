@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// csiMsg stands in for Bubble Tea's message for an unknown CSI sequence,
+// holding the bytes after "ESC [".
+type csiMsg string
+
+func (m csiMsg) String() string {
+	return fmt.Sprintf("?CSI%+v?", []byte(m))
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestTranslateKitty(t *testing.T) {
+	tests := []struct {
+		seq  string
+		want tea.Msg // nil: passed through unchanged
+	}{
+		// Text keys
+		{"97u", runes("a")},
+		{"97;1:2u", runes("a")}, // Repeat
+		{"97;1:3u", keyReleaseMsg{runes("a")}},
+		{"97;2u", runes("A")},
+		{"49:33;2u", runes("!")}, // Shifted key reported
+		{"97;3u", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a"), Alt: true}},
+		{"99;5u", tea.KeyMsg{Type: tea.KeyCtrlC}},
+		{"99;5:3u", keyReleaseMsg{tea.KeyMsg{Type: tea.KeyCtrlC}}},
+		{"32u", tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}},
+		{"13u", tea.KeyMsg{Type: tea.KeyEnter}},
+		{"27u", tea.KeyMsg{Type: tea.KeyEsc}},
+		{"127u", tea.KeyMsg{Type: tea.KeyBackspace}},
+		{"9u", tea.KeyMsg{Type: tea.KeyTab}},
+		{"9;2u", tea.KeyMsg{Type: tea.KeyShiftTab}},
+
+		// Arrows and function keys
+		{"A", tea.KeyMsg{Type: tea.KeyUp}},
+		{"1;1:3A", keyReleaseMsg{tea.KeyMsg{Type: tea.KeyUp}}},
+		{"1;5A", tea.KeyMsg{Type: tea.KeyCtrlUp}},
+		{"1;2B", tea.KeyMsg{Type: tea.KeyShiftDown}},
+		{"1;6C", tea.KeyMsg{Type: tea.KeyCtrlShiftRight}},
+		{"1;3D", tea.KeyMsg{Type: tea.KeyLeft, Alt: true}},
+		{"1;5:3D", keyReleaseMsg{tea.KeyMsg{Type: tea.KeyCtrlLeft}}},
+		{"1;2H", tea.KeyMsg{Type: tea.KeyShiftHome}},
+		{"P", tea.KeyMsg{Type: tea.KeyF1}},
+		{"5~", tea.KeyMsg{Type: tea.KeyPgUp}},
+		{"3;1:3~", keyReleaseMsg{tea.KeyMsg{Type: tea.KeyDelete}}},
+		{"8;5~", tea.KeyMsg{Type: tea.KeyCtrlEnd}},
+
+		// Answer to the protocol query
+		{"?15u", kittySupportedMsg{}},
+		{"?0u", kittySupportedMsg{}},
+
+		// Passed through
+		{"57441u", nil},  // Left shift, in the private use area
+		{"49;5u", nil},   // Ctrl+1 has no key type
+		{"x;5u", nil},    // Not a number
+		{"97;1:9u", nil}, // Unknown event type
+		{"99~", nil},     // Unknown function key
+		{"2;5A", nil},    // Arrows are always key 1
+		{"1;5Z", nil},    // Unknown final byte
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.seq, func(t *testing.T) {
+			msg := csiMsg(tt.seq)
+			want := tt.want
+			if want == nil {
+				want = msg
+			}
+			if got := translateKitty(msg); !reflect.DeepEqual(got, want) {
+				t.Errorf("translateKitty(CSI %s) = %#v, want %#v", tt.seq, got, want)
+			}
+		})
+	}
+}
+
+func TestTranslateKittyOtherMessages(t *testing.T) {
+	for _, msg := range []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyUp},
+		tea.WindowSizeMsg{Width: 80, Height: 24},
+		stringerMsg("?CSI[49 300 117]?"), // Not bytes
+		stringerMsg("?CSI[49 59 50 117]"),
+		stringerMsg("hello"),
+	} {
+		if got := translateKitty(msg); !reflect.DeepEqual(got, msg) {
+			t.Errorf("translateKitty(%#v) = %#v, want it unchanged", msg, got)
+		}
+	}
+}
+
+// stringerMsg is a message that describes itself as the given text.
+type stringerMsg string
+
+func (m stringerMsg) String() string { return string(m) }
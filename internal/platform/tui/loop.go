@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// DefaultRenderFPS is the render rate used when none is configured.
+const DefaultRenderFPS = 60
+
+// Frame loop limits.
+const (
+	minRenderFPS = 10                     // Adaptive rendering never drops below this rate
+	maxCatchUp   = 250 * time.Millisecond // Longest stall the simulation makes up for
+	statsWindow  = time.Second            // Period the overlay's rates are measured over
+	noticeTime   = 3 * time.Second        // How long notices stay on screen
+)
+
+// statsKey toggles the FPS/tick-time overlay.
+const statsKey = "f3"
+
+// frameLoop drives a game: it runs the simulation in fixed steps at the tick
+// rate and draws frames at an independent, adaptive rate.
+//
+// Every TickMsg advances a core.Timestep, which runs as many steps as are due
+// and catches up after stalls. The next View draws a frame only if the
+// simulation advanced and the frame interval has passed. When frames take more
+// than half the interval to draw the interval grows (down to minRenderFPS), and
+// it shrinks back once drawing is cheap again. Frames whose cells didn't change
+// reuse the previous output.
+//
+// Models hold the loop by pointer, so View can update it.
+type frameLoop struct {
+	timestep *core.Timestep
+	tickRate int
+
+	minInterval time.Duration // Frame interval at the configured render rate
+	maxInterval time.Duration // Frame interval at minRenderFPS
+	interval    time.Duration // Current frame interval
+
+	now       time.Time // Time of the last tick
+	lastFrame time.Time
+	pending   bool // The simulation advanced since the last frame
+	forced    bool // The next View draws no matter what, e.g. after a resize
+
+	drawn  *core.Screen // Cells of the last frame
+	output string       // Rendered last frame
+
+	showStats bool
+	stats     loopStats
+
+	notice      string    // Message shown at the bottom, e.g. where a screenshot went
+	noticeUntil time.Time // When the notice disappears
+
+	recording *recording // Records drawn frames while set
+}
+
+// loopStats are the measurements shown by the overlay.
+type loopStats struct {
+	windowStart time.Time
+	ticks       int // Steps run in the current window
+	frames      int // Frames drawn in the current window
+	tps         int // Steps per second over the last window
+	fps         int // Frames per second over the last window
+	stepTime    time.Duration
+	drawTime    time.Duration
+}
+
+// newFrameLoop creates a loop for the tick and render rates in cfg.
+func newFrameLoop(cfg core.RuntimeConfig) *frameLoop {
+	renderFPS := cfg.RenderFPS
+	if renderFPS <= 0 {
+		renderFPS = DefaultRenderFPS
+	}
+	minInterval := time.Second / time.Duration(renderFPS)
+
+	return &frameLoop{
+		timestep:    core.NewTimestep(cfg.TickRate, maxCatchUp),
+		tickRate:    max(1, cfg.TickRate),
+		minInterval: minInterval,
+		maxInterval: max(minInterval, time.Second/minRenderFPS),
+		interval:    minInterval,
+	}
+}
+
+// start restarts the clock and returns the first tick.
+func (l *frameLoop) start() tea.Cmd {
+	now := time.Now()
+	l.timestep.Reset(now)
+	l.now = now
+	l.stats = loopStats{windowStart: now}
+	l.invalidate()
+	return l.next()
+}
+
+// next schedules the tick for when the next simulation step is due.
+func (l *frameLoop) next() tea.Cmd {
+	return tea.Tick(l.timestep.UntilNext(), func(t time.Time) tea.Msg {
+		return TickMsg(t)
+	})
+}
+
+// invalidate makes the next View draw a frame.
+func (l *frameLoop) invalidate() {
+	l.forced = true
+	l.pending = true
+}
+
+// renderFPS returns the configured render rate.
+func (l *frameLoop) renderFPS() int {
+	return int(time.Second / l.minInterval)
+}
+
+// setRenderFPS changes the render rate. Adaptive rendering starts over from
+// the new rate.
+func (l *frameLoop) setRenderFPS(fps int) {
+	l.minInterval = time.Second / time.Duration(max(fps, 1))
+	l.maxInterval = max(l.minInterval, time.Second/minRenderFPS)
+	l.interval = l.minInterval
+	l.invalidate()
+}
+
+// toggleStats shows or hides the overlay.
+func (l *frameLoop) toggleStats() {
+	l.showStats = !l.showStats
+	l.invalidate()
+}
+
+// showNotice shows a message at the bottom of the screen for a few seconds.
+func (l *frameLoop) showNotice(text string) {
+	l.notice = text
+	l.noticeUntil = l.now.Add(noticeTime)
+	l.invalidate()
+}
+
+// startRecording records every frame drawn from now on.
+func (l *frameLoop) startRecording(r *recording) {
+	l.recording = r
+	l.invalidate()
+}
+
+// toggleRecording stops and saves the current recording, or starts one with
+// start, and shows what happened.
+func (l *frameLoop) toggleRecording(start func() (*recording, error)) {
+	if l.recording != nil {
+		l.showNotice(l.stopRecording())
+		return
+	}
+	r, err := start()
+	if err != nil {
+		l.showNotice("Recording failed: " + err.Error())
+		return
+	}
+	l.startRecording(r)
+	l.showNotice("Recording - press Ctrl+R to stop")
+}
+
+// stopRecording saves the recording, if any, and returns a message saying
+// where it went.
+func (l *frameLoop) stopRecording() string {
+	if l.recording == nil {
+		return ""
+	}
+	msg := l.recording.finish()
+	l.recording = nil
+	return msg
+}
+
+// advance runs the simulation steps that are due at now.
+func (l *frameLoop) advance(now time.Time, step func()) {
+	l.now = now
+	if l.recording != nil && l.recording.full {
+		l.showNotice(l.stopRecording())
+	}
+	if l.notice != "" && !now.Before(l.noticeUntil) {
+		l.notice = ""
+		l.invalidate()
+	}
+	steps := l.timestep.Advance(now)
+	for range steps {
+		start := time.Now()
+		step()
+		l.stats.stepTime = smoothDuration(l.stats.stepTime, time.Since(start))
+	}
+	if steps > 0 {
+		l.pending = true
+		l.stats.ticks += steps
+	}
+
+	if window := now.Sub(l.stats.windowStart); window >= statsWindow {
+		l.stats.tps = perSecond(l.stats.ticks, window)
+		l.stats.fps = perSecond(l.stats.frames, window)
+		l.stats.ticks, l.stats.frames = 0, 0
+		l.stats.windowStart = now
+	}
+}
+
+// frameDue reports whether View should draw a new frame.
+func (l *frameLoop) frameDue() bool {
+	if l.forced {
+		return true
+	}
+	// Half a step of slack keeps frames from slipping to the following tick
+	// when the render rate matches the tick rate
+	return l.pending && l.now.Sub(l.lastFrame) >= l.interval-l.timestep.Step()/2
+}
+
+// view returns the output for the current frame. When a frame is due it is drawn
+// onto screen with render, and turned into output with output if its cells changed.
+func (l *frameLoop) view(screen *core.Screen, render func(*core.Screen), output func(*core.Screen) string) string {
+	if !l.frameDue() {
+		return l.output
+	}
+
+	start := time.Now()
+	if l.draw(screen, render) {
+		l.output = output(screen)
+		if l.recording != nil {
+			l.recording.frame(start, screen, l.output)
+		}
+	}
+	l.adapt(time.Since(start))
+	return l.output
+}
+
+// viewScreen draws a new frame onto screen when one is due and returns it,
+// for the diff renderer.
+func (l *frameLoop) viewScreen(screen *core.Screen, render func(*core.Screen)) *core.Screen {
+	if l.frameDue() {
+		start := time.Now()
+		if l.draw(screen, render) && l.recording != nil {
+			l.recording.frame(start, screen, "")
+		}
+		l.adapt(time.Since(start))
+	}
+	return screen
+}
+
+// draw draws a frame and reports whether its cells differ from the last one.
+func (l *frameLoop) draw(screen *core.Screen, render func(*core.Screen)) bool {
+	render(screen)
+	if l.showStats {
+		l.drawStats(screen)
+	}
+	if l.notice != "" {
+		l.drawNotice(screen)
+	}
+
+	l.pending, l.forced = false, false
+	l.lastFrame = l.now
+	l.stats.frames++
+
+	if l.drawn == nil {
+		l.drawn = core.NewScreen(screen.Width(), screen.Height())
+	} else if screen.Equal(l.drawn) {
+		return false
+	}
+	l.drawn.CopyFrom(screen)
+	return true
+}
+
+// adapt adjusts the frame interval to how long the last frame took to draw.
+func (l *frameLoop) adapt(cost time.Duration) {
+	l.stats.drawTime = smoothDuration(l.stats.drawTime, cost)
+	switch {
+	case l.stats.drawTime > l.interval/2:
+		l.interval = min(l.interval*5/4, l.maxInterval)
+	case l.stats.drawTime < l.interval/4:
+		l.interval = max(l.interval*9/10, l.minInterval)
+	}
+}
+
+// drawStats draws the overlay in the top right corner.
+func (l *frameLoop) drawStats(screen *core.Screen) {
+	renderCap := min(int(time.Second/l.interval), l.tickRate)
+	text := fmt.Sprintf(" %d/%d fps  %d/%d tps  step %s  draw %s ",
+		l.stats.fps, renderCap, l.stats.tps, l.tickRate,
+		formatMillis(l.stats.stepTime), formatMillis(l.stats.drawTime))
+	x := max(0, screen.Width()-len(text))
+	screen.DrawTextStyled(x, 0, text, core.RoleHUD, core.ColorDefault, core.AttrReverse)
+}
+
+// drawNotice draws the notice centered on the bottom row.
+func (l *frameLoop) drawNotice(screen *core.Screen) {
+	text := " " + l.notice + " "
+	x := max(0, (screen.Width()-core.StringWidth(text))/2)
+	screen.DrawTextStyled(x, screen.Height()-1, text, core.RoleHUD, core.ColorDefault, core.AttrReverse)
+}
+
+// smoothDuration folds a new sample into a moving average.
+func smoothDuration(avg, sample time.Duration) time.Duration {
+	if avg == 0 {
+		return sample
+	}
+	return avg + (sample-avg)/8
+}
+
+// perSecond converts a count over a window into a rounded rate.
+func perSecond(count int, window time.Duration) int {
+	return int(float64(count)/window.Seconds() + 0.5)
+}
+
+// formatMillis formats a duration as milliseconds with two decimals.
+func formatMillis(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
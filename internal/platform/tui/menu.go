@@ -19,6 +19,9 @@ type MenuItem struct {
 	Mode   multiplayer.MatchMode
 }
 
+// adminMenuID is the pseudo game ID of the Admin entry shown to server admins.
+const adminMenuID = "admin"
+
 // MenuModel is the Bubble Tea model for the game picker menu.
 type MenuModel struct {
 	items          []MenuItem
@@ -28,6 +31,9 @@ type MenuModel struct {
 	store          *storage.Store
 	config         core.RuntimeConfig
 	keyMapper      *KeyMapper
+	renderer       *ScreenRenderer // Renders the attract mode; nil renders for the local terminal
+	attract        *attractMode    // Demos shown when the menu is idle
+	nickname       string          // Shown above the games when set
 	quitting       bool
 	selected       *MenuItem // Set when user selects a game
 	openScoreboard bool      // True if user pressed Tab for scoreboard
@@ -55,6 +61,7 @@ func NewMenuModel(store *storage.Store, cfg core.RuntimeConfig) MenuModel {
 			Mode:   mode,
 		})
 	}
+	items = append(items, MenuItem{GameID: settingsMenuID, Title: "Settings"})
 
 	return MenuModel{
 		items:     items,
@@ -64,35 +71,89 @@ func NewMenuModel(store *storage.Store, cfg core.RuntimeConfig) MenuModel {
 		store:     store,
 		config:    cfg,
 		keyMapper: NewKeyMapper(),
+		attract:   newAttractMode(store, cfg, defaultAttractAfter),
 	}
 }
 
+// WithAdmin returns a copy of the menu with the Admin console entry appended.
+func (m MenuModel) WithAdmin() MenuModel {
+	items := make([]MenuItem, len(m.items), len(m.items)+1)
+	copy(items, m.items)
+	m.items = append(items, MenuItem{GameID: adminMenuID, Title: "Admin"})
+	return m
+}
+
+// WithNickname returns a copy of the menu that greets the player by name.
+func (m MenuModel) WithNickname(name string) MenuModel {
+	m.nickname = name
+	return m
+}
+
+// WithAttract returns a copy of the menu whose attract mode starts after the
+// given number of seconds idle: 0 is the default and a negative value turns
+// it off.
+func (m MenuModel) WithAttract(seconds int) MenuModel {
+	m.attract = newAttractMode(m.store, m.config, attractDelay(seconds))
+	return m
+}
+
+// WithRenderer returns a copy of the menu that draws its attract mode with r.
+func (m MenuModel) WithRenderer(r *ScreenRenderer) MenuModel {
+	m.renderer = r
+	return m
+}
+
 // Init initializes the menu model.
 func (m MenuModel) Init() tea.Cmd {
-	return nil
+	return m.attract.wait()
 }
 
 // Update handles messages for the menu.
 func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case attractIdleMsg:
+		if msg.attract == m.attract {
+			return m, m.attract.idle()
+		}
+		return m, nil
+
+	case attractTickMsg:
+		if msg.attract == m.attract {
+			return m, m.attract.advance(msg.at)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		if stopped, cmd := m.attract.touch(); stopped {
+			return m, cmd // The key only stops the demos
+		}
+		return m.handleAction(m.keyMapper.MapKeyToMenuAction(msg))
+
+	case tea.MouseMsg:
+		if m.attract.running && msg.Action == tea.MouseActionMotion {
+			return m, nil // Only clicks and the wheel stop the demos
+		}
+		if stopped, cmd := m.attract.touch(); stopped {
+			return m, cmd
+		}
+		var action MenuAction
+		m.cursor, action = menuMouse(msg, menuListRow, len(m.items), m.cursor)
+		return m.handleAction(action)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.config.ScreenW = msg.Width
 		m.config.ScreenH = msg.Height
+		m.attract.resize(msg.Width, msg.Height)
 		return m, nil
 	}
 
 	return m, nil
 }
 
-// handleKey processes keyboard input for menu navigation.
-func (m MenuModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	action := m.keyMapper.MapKeyToMenuAction(msg)
-
+// handleAction processes a menu action from the keyboard or mouse.
+func (m MenuModel) handleAction(action MenuAction) (tea.Model, tea.Cmd) {
 	switch action {
 	case MenuActionQuit:
 		m.quitting = true
@@ -128,6 +189,9 @@ func (m MenuModel) View() string {
 	if m.quitting {
 		return ""
 	}
+	if m.attract.running {
+		return m.attract.view(m.renderer.Render)
+	}
 
 	var b strings.Builder
 
@@ -140,6 +204,9 @@ func (m MenuModel) View() string {
 
 	// Subtitle
 	subtitle := "Select a game"
+	if m.nickname != "" {
+		subtitle = fmt.Sprintf("Playing as %s - select a game", m.nickname)
+	}
 	subtitleLine := centerText(subtitle, m.width)
 	b.WriteString(subtitleLine)
 	b.WriteString("\n\n")
@@ -192,10 +259,11 @@ func (m MenuModel) Config() core.RuntimeConfig {
 
 // centerText centers text within given width.
 func centerText(text string, width int) string {
-	if len(text) >= width {
+	textWidth := core.StringWidth(text)
+	if textWidth >= width {
 		return text
 	}
-	padding := (width - len(text)) / 2
+	padding := (width - textWidth) / 2
 	return strings.Repeat(" ", padding) + text
 }
 
@@ -205,16 +273,21 @@ type MenuResult struct {
 	Mode            multiplayer.MatchMode
 	Config          core.RuntimeConfig
 	WantsScoreboard bool
+	WantsSettings   bool
 	Quit            bool
 }
 
 // RunMenu runs the menu and returns the selection result.
 func RunMenu(store *storage.Store, cfg core.RuntimeConfig) (MenuResult, error) {
 	model := NewMenuModel(store, cfg)
+	if prefs, err := LoadSettings(store, LocalUsername(), false); err == nil {
+		model = model.WithNickname(prefs.Nickname).WithAttract(prefs.Attract)
+	}
 
 	p := tea.NewProgram(
 		model,
 		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
 	)
 
 	finalModel, err := p.Run()
@@ -241,6 +314,11 @@ func RunMenu(store *storage.Store, cfg core.RuntimeConfig) (MenuResult, error) {
 		return result, nil
 	}
 
+	if m.Selected() != nil && m.Selected().GameID == settingsMenuID {
+		result.WantsSettings = true
+		return result, nil
+	}
+
 	if m.Selected() != nil {
 		result.GameID = m.Selected().GameID
 		result.Mode = m.Selected().Mode
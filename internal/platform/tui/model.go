@@ -1,9 +1,7 @@
 package tui
 
 import (
-	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,8 +17,14 @@ type Model struct {
 	screen     *core.Screen
 	store      *storage.Store
 	config     core.RuntimeConfig
-	inputFrame core.InputFrame
+	inputFrame core.MultiInputFrame
 	gameState  core.GameState
+	keyMapper  *KeyMapper
+	keys       *keyInput
+	mouse      *mouseInput
+	loop       *frameLoop
+	pause      *pauseMenu   // Open pause menu, or nil while the game runs
+	help       *helpOverlay // Open help, or nil
 	quitting   bool
 	scoreSaved bool // Whether score has been saved for current game over
 }
@@ -37,7 +41,11 @@ func NewModel(game registry.Game, store *storage.Store, cfg core.RuntimeConfig)
 		screen:     core.NewScreen(cfg.ScreenW, cfg.ScreenH),
 		store:      store,
 		config:     cfg,
-		inputFrame: core.NewInputFrame(),
+		inputFrame: core.NewMultiInputFrame(),
+		keyMapper:  currentKeyBindings().gameMapper(game),
+		keys:       newKeyInput(os.Stdout),
+		mouse:      newMouseInput(),
+		loop:       newFrameLoop(cfg),
 	}
 }
 
@@ -47,21 +55,45 @@ func (m Model) Init() tea.Cmd {
 	m.game.Reset(m.config)
 	// Note: gameState will be set on first tick (value receiver limitation)
 
+	m.keys.start()
+
 	// Start the tick loop
-	return tickCmd(m.config.TickRate)
+	cmd := m.loop.start()
+	if recordPath != "" && m.loop.recording == nil {
+		m.loop.toggleRecording(m.recordingStarter(recordPath))
+	}
+	return cmd
 }
 
 // Update handles messages and updates the model state.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	msg = translateKitty(msg)
+	if key, ok := m.keys.handle(msg); ok {
+		if player, action, _ := m.keyMapper.MapPlayerKey(key); action != core.ActionNone {
+			m.keys.release(player, action)
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 
+	case tea.MouseMsg:
+		if m.pause != nil {
+			return m.handlePause(m.pause.handleMouse(msg, m.screen.Width(), m.screen.Height()))
+		}
+		if m.help != nil {
+			return m, nil
+		}
+		m.mouse.handle(msg)
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		return m.handleResize(msg)
 
 	case TickMsg:
-		return m.handleTick()
+		return m.handleTick(time.Time(msg))
 	}
 
 	return m, nil
@@ -69,33 +101,112 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKey processes keyboard input.
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Global quit keys
 	switch msg.String() {
-	case "ctrl+c", "q":
-		m.quitting = true
-		return m, tea.Quit
-	case "ctrl+s":
+	case screenshotKey:
 		m.saveScreenshot()
 		return m, nil
+	case recordKey:
+		m.loop.toggleRecording(m.recordingStarter(""))
+		return m, nil
+	case statsKey:
+		m.loop.toggleStats()
+		return m, nil
 	}
 
-	// Map key to action
-	switch msg.String() {
-	case " ", "up", "w":
-		m.inputFrame.Set(core.ActionJump)
-	case "down", "s":
-		m.inputFrame.Set(core.ActionDuck)
-	case "p", "esc":
-		m.inputFrame.Set(core.ActionPause)
-	case "r":
+	if m.pause != nil {
+		return m.handlePause(m.pause.handleKey(msg, m.keyMapper))
+	}
+	if m.help != nil {
+		// Any key closes the help
+		m.help = nil
+		m.loop.invalidate()
+		return m, nil
+	}
+	if msg.String() == helpKey {
+		m.openHelp()
+		return m, nil
+	}
+
+	player, action, isQuit := m.keyMapper.MapPlayerKey(msg)
+	switch {
+	case isQuit:
+		return m.quit()
+	case action == core.ActionNone || !m.keys.press(player, action, time.Now()):
+		// Repeats of a held key are picked up by step
+	case action == core.ActionPause || action == core.ActionBack:
+		if !m.gameState.GameOver {
+			m.openPause()
+		}
+	case action == core.ActionRestart:
 		if m.gameState.GameOver {
-			m.inputFrame.Set(core.ActionRestart)
+			m.press(player, core.ActionRestart)
 		}
+	default:
+		m.press(player, action)
 	}
 
 	return m, nil
 }
 
+// quit stops the game and ends the program.
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	m.quitting = true
+	m.loop.stopRecording()
+	m.keys.stop()
+	return m, tea.Quit
+}
+
+// openPause pauses the game and opens the pause menu.
+func (m *Model) openPause() {
+	settings := NewSettingsModel(m.store, LocalUsername(), nil, m.screen.Width(), m.screen.Height())
+	m.pause = newPauseMenu(m.game.ID(), "Quit game", m.loop, settings)
+	m.keys.reset()
+	m.inputFrame.Clear()
+	m.loop.invalidate()
+}
+
+// openHelp pauses the game and shows its help.
+func (m *Model) openHelp() {
+	m.help = newHelpOverlay(m.game, m.keyMapper)
+	m.keys.reset()
+	m.inputFrame.Clear()
+	m.loop.invalidate()
+}
+
+// handlePause acts on the result of input to the pause menu.
+func (m Model) handlePause(result pauseResult) (tea.Model, tea.Cmd) {
+	switch result {
+	case pauseStay:
+		// Keys may have been rebound on the controls screen
+		m.keyMapper = m.pause.keys().gameMapper(m.game)
+		return m, nil
+	case pauseRestarted:
+		m.restart()
+	case pauseLeave, pauseQuitAll:
+		// There is no menu to go back to, so leaving quits
+		return m.quit()
+	}
+	m.pause = nil
+	m.loop.invalidate()
+	return m, nil
+}
+
+// restart starts the game over with a new seed.
+func (m *Model) restart() {
+	m.config.Seed = time.Now().UnixNano()
+	m.game.Reset(m.config)
+	m.gameState = m.game.State()
+	m.scoreSaved = false
+	m.inputFrame.Clear()
+}
+
+// press marks an action pressed for a player in the next step.
+func (m *Model) press(player core.PlayerID, action core.Action) {
+	in := m.inputFrame.Player(player)
+	in.Set(action)
+	m.inputFrame.SetPlayer(player, in)
+}
+
 // handleResize processes window resize events.
 func (m Model) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	// Update screen size
@@ -108,29 +219,44 @@ func (m Model) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	if !m.gameState.GameOver {
 		m.game.Reset(m.config)
 	}
+	m.loop.invalidate()
 
 	return m, nil
 }
 
-// handleTick processes simulation ticks.
-func (m Model) handleTick() (tea.Model, tea.Cmd) {
+// handleTick runs the simulation steps that are due and schedules the next tick.
+func (m Model) handleTick(now time.Time) (tea.Model, tea.Cmd) {
+	m.loop.advance(now, m.step)
+	return m, m.loop.next()
+}
+
+// step runs one simulation step. Nothing moves while the game is paused
+// or its help is open.
+func (m *Model) step() {
+	if m.pause != nil || m.help != nil {
+		return
+	}
+
+	now := time.Now()
+	p1Input := m.inputFrame.Player1Frame()
+	m.keys.apply(core.Player1, &p1Input, now)
+	if m.keyMapper.Mouse() {
+		m.mouse.apply(&p1Input)
+	}
+
 	// Check for restart
-	if m.inputFrame.Has(core.ActionRestart) && m.gameState.GameOver {
-		// Reset seed for new game
-		m.config.Seed = time.Now().UnixNano()
-		m.game.Reset(m.config)
-		m.gameState = m.game.State()
-		m.scoreSaved = false
-		m.inputFrame.Clear()
-		return m, tickCmd(m.config.TickRate)
+	if p1Input.Has(core.ActionRestart) && m.gameState.GameOver {
+		m.restart()
+		return
 	}
 
 	// Run game simulation
-	result := m.game.Step(m.inputFrame)
+	result := stepPlayers(m.game, &m.inputFrame, p1Input, m.keys, now)
 	m.gameState = result.State
 
-	// Save score on game over (once)
-	if m.gameState.GameOver && !m.scoreSaved && m.gameState.Score > 0 {
+	// Save score on game over (once); two players have no high score
+	_, twoPlayer := localTwoPlayer(m.game)
+	if m.gameState.GameOver && !m.scoreSaved && m.gameState.Score > 0 && !twoPlayer {
 		if m.store != nil {
 			//nolint:errcheck // Best-effort save, game continues regardless
 			m.store.SaveScore(m.game.ID(), m.gameState.Score)
@@ -138,31 +264,37 @@ func (m Model) handleTick() (tea.Model, tea.Cmd) {
 		m.scoreSaved = true
 	}
 
-	// Clear input for next frame
+	// Clear input for next step
 	m.inputFrame.Clear()
+}
 
-	// Continue ticking
-	return m, tickCmd(m.config.TickRate)
+// stepPlayers steps game with Player1's input, or with the input of both
+// players when they share the keyboard, adding Player2's held keys.
+func stepPlayers(game registry.Game, frame *core.MultiInputFrame, p1Input core.InputFrame, keys *keyInput, now time.Time) core.StepResult {
+	tp, ok := localTwoPlayer(game)
+	if !ok {
+		return game.Step(p1Input)
+	}
+	p2Input := frame.Player2Frame()
+	keys.apply(core.Player2, &p2Input, now)
+	frame.SetPlayer(core.Player1, p1Input)
+	frame.SetPlayer(core.Player2, p2Input)
+	return tp.StepMulti(*frame)
 }
 
-// saveScreenshot saves the current screen to a file.
+// saveScreenshot saves the current screen in the configured format and
+// shows where it went.
 func (m *Model) saveScreenshot() {
-	// Render current state
 	m.game.Render(m.screen)
+	m.loop.showNotice(localCaptures(m.store).screenshotMessage(m.game.ID(), m.screen))
+}
 
-	// Create screenshots directory
-	dir := filepath.Join(os.Getenv("HOME"), ".arcade", "screenshots")
-	//nolint:errcheck // Best-effort directory creation
-	os.MkdirAll(dir, 0o755)
-
-	// Generate filename with timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("%s_%s.txt", m.game.ID(), timestamp)
-	path := filepath.Join(dir, filename)
-
-	// Save screenshot
-	//nolint:errcheck // Best-effort save, game continues regardless
-	os.WriteFile(path, []byte(m.screen.String()), 0o600)
+// recordingStarter returns a function that starts recording the game to path,
+// or to ~/.arcade/recordings if path is empty.
+func (m Model) recordingStarter(path string) func() (*recording, error) {
+	return func() (*recording, error) {
+		return localCaptures(m.store).startRecording(m.game.ID(), path, m.screen.Width(), m.screen.Height(), RenderScreen)
+	}
 }
 
 // View renders the current state to a string for display.
@@ -171,21 +303,48 @@ func (m Model) View() string {
 		return ""
 	}
 
-	// Render game to screen buffer
-	m.game.Render(m.screen)
+	if m.pause != nil && m.pause.controlsOpen() {
+		return m.pause.view(m.screen.Width())
+	}
 
-	// Convert screen to string
-	return RenderScreen(m.screen)
+	// Render game to screen buffer when a frame is due
+	return m.loop.view(m.screen, m.render, RenderScreen)
+}
+
+// ViewScreen renders the game for the diff renderer.
+func (m Model) ViewScreen() *core.Screen {
+	if m.quitting || (m.pause != nil && m.pause.controlsOpen()) {
+		return nil
+	}
+	return m.loop.viewScreen(m.screen, m.render)
+}
+
+// render draws the game, with the pause menu or the help over it while
+// they are open.
+func (m Model) render(dst *core.Screen) {
+	m.game.Render(dst)
+	if m.pause != nil {
+		m.pause.render(dst)
+	}
+	if m.help != nil {
+		m.help.render(dst)
+	}
 }
 
 // Run starts the Bubble Tea program with the given model.
 func Run(game registry.Game, store *storage.Store, cfg core.RuntimeConfig) error {
 	model := NewModel(game, store, cfg)
 
+	if diffRendering {
+		_, err := runDiff(model)
+		return err
+	}
+
 	p := tea.NewProgram(
 		model,
-		tea.WithAltScreen(),       // Use alternate screen buffer
-		tea.WithMouseCellMotion(), // Enable mouse (for future use)
+		tea.WithAltScreen(),        // Use alternate screen buffer
+		tea.WithMouseAllMotion(),   // Pointer moves without a button held steer paddles
+		tea.WithFPS(cfg.RenderFPS), // Match the renderer to the frame loop
 	)
 
 	_, err := p.Run()
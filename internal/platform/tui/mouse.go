@@ -0,0 +1,85 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// Rows of the first entry in menu lists. Menu views start with a blank line
+// and the title, followed by a blank line, and by a subtitle and another
+// blank line when they have one.
+const (
+	menuListRow      = 5 // Below a title and subtitle
+	menuShortListRow = 3 // Below a title only
+)
+
+// Mouse tracking escapes for the diff renderer, which draws without Bubble
+// Tea's renderer: report all motion, in SGR format for wide terminals.
+const (
+	escMouseOn  = "\x1b[?1003h\x1b[?1006h"
+	escMouseOff = "\x1b[?1003l\x1b[?1006l"
+)
+
+// mouseInput keeps the mouse state of a game between frames.
+type mouseInput struct {
+	state core.MouseState
+}
+
+// newMouseInput creates the mouse state of a game, with the pointer unknown.
+func newMouseInput() *mouseInput {
+	return &mouseInput{}
+}
+
+// handle records a mouse event.
+func (m *mouseInput) handle(msg tea.MouseMsg) {
+	s := &m.state
+	if !s.Active || msg.X != s.X || msg.Y != s.Y {
+		s.Moved = true
+	}
+	s.X, s.Y, s.Active = msg.X, msg.Y, true
+
+	switch {
+	case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		s.Down, s.Pressed = true, true
+		s.DragX, s.DragY = msg.X, msg.Y
+	case msg.Action == tea.MouseActionRelease && s.Down:
+		// Some terminals don't say which button went up
+		s.Down, s.Released = false, true
+	}
+}
+
+// apply puts the mouse state on frame and starts a new frame.
+func (m *mouseInput) apply(frame *core.InputFrame) {
+	frame.Mouse = m.state
+	m.state.ClearEdges()
+}
+
+// menuMouse translates a mouse event over a menu list of count entries, the
+// first on row first. Pointing at an entry moves the cursor there, clicking
+// it selects it, the wheel moves the cursor and a right click goes back.
+// It returns the new cursor and the menu action to handle.
+func menuMouse(msg tea.MouseMsg, first, count, cursor int) (int, MenuAction) {
+	if msg.Action != tea.MouseActionPress && msg.Action != tea.MouseActionMotion {
+		return cursor, MenuActionNone
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return cursor, MenuActionUp
+	case tea.MouseButtonWheelDown:
+		return cursor, MenuActionDown
+	case tea.MouseButtonRight:
+		if msg.Action == tea.MouseActionPress {
+			return cursor, MenuActionBack
+		}
+	}
+
+	i := msg.Y - first
+	if i < 0 || i >= count {
+		return cursor, MenuActionNone
+	}
+	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+		return i, MenuActionSelect
+	}
+	return i, MenuActionNone
+}
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/games/pong"
 	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
 )
 
@@ -49,17 +50,14 @@ type OnlineLobbyModel struct {
 	backToMenu bool
 	cancelled  bool
 	quitting   bool
-
-	// For receiving events from coordinator
-	eventChan <-chan multiplayer.SessionEvent
 }
 
 // NewOnlineLobbyModel creates a new online lobby model.
+// Coordinator events are read by the owning SessionModel and forwarded to Update.
 func NewOnlineLobbyModel(
 	gameID string,
 	sessionID multiplayer.SessionID,
 	coordinator *multiplayer.Coordinator,
-	eventChan <-chan multiplayer.SessionEvent,
 	width, height int,
 ) OnlineLobbyModel {
 	return OnlineLobbyModel{
@@ -70,27 +68,12 @@ func NewOnlineLobbyModel(
 		gameID:      gameID,
 		sessionID:   sessionID,
 		coordinator: coordinator,
-		eventChan:   eventChan,
 	}
 }
 
 // Init initializes the lobby model.
 func (m OnlineLobbyModel) Init() tea.Cmd {
-	return m.waitForEvent()
-}
-
-// waitForEvent returns a command that waits for coordinator events.
-func (m OnlineLobbyModel) waitForEvent() tea.Cmd {
-	return func() tea.Msg {
-		if m.eventChan == nil {
-			return nil
-		}
-		evt, ok := <-m.eventChan
-		if !ok {
-			return nil
-		}
-		return evt
-	}
+	return nil
 }
 
 // Update handles messages.
@@ -105,20 +88,20 @@ func (m OnlineLobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case multiplayer.LobbyCreatedEvent:
 		m.lobbyCode = msg.Code
 		m.state = OnlineStateHostWaiting
-		return m, m.waitForEvent()
+		return m, nil
 	case multiplayer.LobbyJoinedEvent:
 		m.side = msg.Side
 		m.opponentID = msg.OpponentID
-		return m, m.waitForEvent()
+		return m, nil
 	case multiplayer.LobbyErrorEvent:
 		m.joinError = msg.Message
 		if m.state == OnlineStateJoinWaiting {
 			m.state = OnlineStateJoinEnterCode
 		}
-		return m, m.waitForEvent()
+		return m, nil
 	case multiplayer.LobbyPlayerLeftEvent:
 		// If in host waiting state and joiner left, stay waiting
-		return m, m.waitForEvent()
+		return m, nil
 	case multiplayer.MatchStartedEvent:
 		m.matchID = msg.MatchID
 		m.side = msg.Side
@@ -164,7 +147,7 @@ func (m OnlineLobbyModel) handleChooseModeKey(msg tea.KeyMsg) (tea.Model, tea.Cm
 			SessionID: m.sessionID,
 			GameID:    m.gameID,
 		})
-		return m, m.waitForEvent()
+		return m, nil
 	case "j", "J", "2":
 		// Join
 		m.state = OnlineStateJoinEnterCode
@@ -224,7 +207,7 @@ func (m OnlineLobbyModel) handleJoinCodeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 				SessionID: m.sessionID,
 				Code:      m.joinCodeInput,
 			})
-			return m, m.waitForEvent()
+			return m, nil
 		}
 	case "backspace":
 		if m.joinCodeInput != "" {
@@ -407,29 +390,54 @@ func (m OnlineLobbyModel) LobbyCode() string {
 	return m.lobbyCode
 }
 
-// PongModeModel lets users choose between Vs CPU and Online PvP for Pong.
+// PongModeModel lets users choose between Vs CPU and Online PvP for Pong,
+// then pick a CPU personality for Vs CPU games.
 type PongModeModel struct {
-	cursor    int
-	width     int
-	height    int
-	keyMapper *KeyMapper
-	selected  multiplayer.MatchMode
-	choosing  bool
-	quitting  bool
-	back      bool
+	cursor              int
+	personalityCursor   int
+	inPersonalitySelect bool
+	width               int
+	height              int
+	keyMapper           *KeyMapper
+	modes               []multiplayer.MatchMode
+	selected            multiplayer.MatchMode
+	personality         string
+	choosing            bool
+	quitting            bool
+	back                bool
 }
 
 // NewPongModeModel creates a new pong mode selection model.
 func NewPongModeModel(width, height int) PongModeModel {
 	return PongModeModel{
-		cursor:    0,
-		width:     width,
-		height:    height,
-		keyMapper: NewKeyMapper(),
-		choosing:  true,
+		cursor:            0,
+		personalityCursor: defaultPersonalityIndex(),
+		width:             width,
+		height:            height,
+		keyMapper:         NewKeyMapper(),
+		modes:             []multiplayer.MatchMode{multiplayer.MatchModeVsCPU, multiplayer.MatchModeLocal2P, multiplayer.MatchModeOnlinePvP},
+		choosing:          true,
 	}
 }
 
+// NewLocalPongModeModel creates a pong mode selection model for local play.
+// Online PvP is only available over SSH.
+func NewLocalPongModeModel(width, height int) PongModeModel {
+	m := NewPongModeModel(width, height)
+	m.modes = []multiplayer.MatchMode{multiplayer.MatchModeVsCPU, multiplayer.MatchModeLocal2P}
+	return m
+}
+
+// defaultPersonalityIndex returns the menu index of the Pro personality.
+func defaultPersonalityIndex() int {
+	for i, p := range pong.Personalities() {
+		if p.ID == pong.PersonalityPro.ID {
+			return i
+		}
+	}
+	return 0
+}
+
 // Init initializes the model.
 func (m PongModeModel) Init() tea.Cmd {
 	return nil
@@ -440,6 +448,8 @@ func (m PongModeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -451,6 +461,23 @@ func (m PongModeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m PongModeModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	action := m.keyMapper.MapKeyToMenuAction(msg)
 
+	if m.inPersonalitySelect {
+		return m.handlePersonalitySelectKey(action)
+	}
+	return m.handleModeSelectKey(action)
+}
+
+func (m PongModeModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	var action MenuAction
+	if m.inPersonalitySelect {
+		m.personalityCursor, action = menuMouse(msg, menuShortListRow, len(pong.Personalities()), m.personalityCursor)
+		return m.handlePersonalitySelectKey(action)
+	}
+	m.cursor, action = menuMouse(msg, menuListRow, len(m.modes), m.cursor)
+	return m.handleModeSelectKey(action)
+}
+
+func (m PongModeModel) handleModeSelectKey(action MenuAction) (tea.Model, tea.Cmd) {
 	switch action {
 	case MenuActionQuit:
 		m.quitting = true
@@ -460,20 +487,52 @@ func (m PongModeModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.cursor--
 		}
 	case MenuActionDown:
-		if m.cursor < 1 { // Only 2 options
+		if m.cursor < len(m.modes)-1 {
 			m.cursor++
 		}
 	case MenuActionSelect:
-		m.choosing = false
-		if m.cursor == 0 {
-			m.selected = multiplayer.MatchModeVsCPU
-		} else {
-			m.selected = multiplayer.MatchModeOnlinePvP
+		if m.modes[m.cursor] == multiplayer.MatchModeVsCPU {
+			m.inPersonalitySelect = true
+			return m, nil
 		}
-		return m, nil
+		m.choosing = false
+		m.selected = m.modes[m.cursor]
+		return m, tea.Quit
 	case MenuActionBack:
 		m.back = true
-		return m, nil
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m PongModeModel) handlePersonalitySelectKey(action MenuAction) (tea.Model, tea.Cmd) {
+	personalities := pong.Personalities()
+
+	switch action {
+	case MenuActionQuit:
+		m.quitting = true
+		return m, tea.Quit
+	case MenuActionUp:
+		if m.personalityCursor > 0 {
+			m.personalityCursor--
+		}
+	case MenuActionDown:
+		if m.personalityCursor < len(personalities)-1 {
+			m.personalityCursor++
+		}
+	case MenuActionSelect:
+		m.choosing = false
+		m.selected = multiplayer.MatchModeVsCPU
+		m.personality = personalities[m.personalityCursor].ID
+		return m, tea.Quit
+	case MenuActionBack:
+		// With a single mode there is no mode list to return to
+		if len(m.modes) == 1 {
+			m.back = true
+			return m, tea.Quit
+		}
+		m.inPersonalitySelect = false
 	}
 
 	return m, nil
@@ -485,6 +544,13 @@ func (m PongModeModel) View() string {
 		return ""
 	}
 
+	if m.inPersonalitySelect {
+		return m.viewPersonalitySelect()
+	}
+	return m.viewModeSelect()
+}
+
+func (m PongModeModel) viewModeSelect() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
@@ -493,13 +559,35 @@ func (m PongModeModel) View() string {
 	b.WriteString(centerText("Select game mode:", m.width))
 	b.WriteString("\n\n")
 
-	modes := []string{"Vs CPU", "Online PvP"}
-	for i, mode := range modes {
+	for i, mode := range m.modes {
 		cursor := "  "
 		if i == m.cursor {
 			cursor = "> "
 		}
-		b.WriteString(centerText(fmt.Sprintf("%s%s", cursor, mode), m.width))
+		b.WriteString(centerText(fmt.Sprintf("%s%s", cursor, pongModeLabel(mode)), m.width))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(centerText("Enter: Select  |  Esc: Back  |  Q: Quit", m.width))
+
+	return b.String()
+}
+
+func (m PongModeModel) viewPersonalitySelect() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(centerText("PONG - CHOOSE OPPONENT", m.width))
+	b.WriteString("\n\n")
+
+	for i, p := range pong.Personalities() {
+		cursor := "  "
+		if i == m.personalityCursor {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%-7s %s", cursor, p.Name, personalityBlurb(p))
+		b.WriteString(centerText(line, m.width))
 		b.WriteString("\n")
 	}
 
@@ -509,6 +597,34 @@ func (m PongModeModel) View() string {
 	return b.String()
 }
 
+// pongModeLabel returns the menu label for a Pong match mode.
+func pongModeLabel(mode multiplayer.MatchMode) string {
+	switch mode {
+	case multiplayer.MatchModeVsCPU:
+		return "Vs CPU"
+	case multiplayer.MatchModeLocal2P:
+		return "Local 2P (shared keyboard)"
+	case multiplayer.MatchModeOnlinePvP:
+		return "Online PvP"
+	default:
+		return mode.String()
+	}
+}
+
+// personalityBlurb returns a short description of a CPU personality.
+func personalityBlurb(p pong.Personality) string {
+	switch p.ID {
+	case pong.PersonalityRookie.ID:
+		return "- chases the ball, slow to react"
+	case pong.PersonalityPro.ID:
+		return "- reads bounces, angles returns"
+	case pong.PersonalityWall.ID:
+		return "- almost never misses"
+	default:
+		return ""
+	}
+}
+
 // Selected returns the selected mode, or -1 if still choosing.
 func (m PongModeModel) Selected() multiplayer.MatchMode {
 	if m.choosing {
@@ -517,6 +633,11 @@ func (m PongModeModel) Selected() multiplayer.MatchMode {
 	return m.selected
 }
 
+// Personality returns the selected CPU personality ID (empty unless Vs CPU was chosen).
+func (m PongModeModel) Personality() string {
+	return m.personality
+}
+
 // IsChoosing returns true if still in selection mode.
 func (m PongModeModel) IsChoosing() bool {
 	return m.choosing
@@ -531,3 +652,36 @@ func (m PongModeModel) IsQuitting() bool {
 func (m PongModeModel) WantsBack() bool {
 	return m.back
 }
+
+// PongSelection holds the user's selection from the local Pong menu.
+type PongSelection struct {
+	Mode        multiplayer.MatchMode
+	Personality string // CPU personality ID
+}
+
+// RunPongModeSelector runs the local Pong mode/opponent selection and returns the selection.
+func RunPongModeSelector(cfg core.RuntimeConfig) (*PongSelection, core.RuntimeConfig, error) {
+	model := NewLocalPongModeModel(cfg.ScreenW, cfg.ScreenH)
+
+	p := tea.NewProgram(
+		model,
+		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
+	)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, cfg, err
+	}
+
+	m, ok := finalModel.(PongModeModel)
+	if !ok {
+		return nil, cfg, nil
+	}
+
+	if m.IsQuitting() || m.WantsBack() || m.IsChoosing() {
+		return nil, cfg, nil
+	}
+
+	return &PongSelection{Mode: m.Selected(), Personality: m.Personality()}, cfg, nil
+}
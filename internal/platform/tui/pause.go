@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// Entries of the pause menu.
+const (
+	pauseResume = iota
+	pauseRestart
+	pauseControls
+	pauseSettings
+	pauseQuit
+)
+
+// Rows of the pause menu's settings page.
+const (
+	pauseSettingTheme = iota
+	pauseSettingFPS
+	pauseSettingBack
+)
+
+// renderFPSChoices are the render rates the pause menu cycles through.
+var renderFPSChoices = []int{15, 30, 60, 120}
+
+// pauseResult tells a game model what to do after the pause menu handled
+// input.
+type pauseResult int
+
+const (
+	pauseStay      pauseResult = iota // Keep the menu open
+	pauseResumed                      // Close the menu and carry on
+	pauseRestarted                    // Close the menu and start over
+	pauseLeave                        // Back to the menu, or out of a single game
+	pauseQuitAll                      // The quit key was pressed
+)
+
+// pauseMenu is the menu shown over a paused game, the same in every game.
+// The game does not step while it is open. Besides resuming it offers a
+// restart, the controls screen on the game's page, a few settings and
+// leaving the game. Theme and key binding changes are saved like in the
+// Settings screen; the frame rate lasts for the session.
+type pauseMenu struct {
+	gameID    string
+	quitLabel string
+	loop      *frameLoop
+	settings  SettingsModel // Applies and saves themes and key bindings
+
+	cursor      int
+	settingsRow int
+	inSettings  bool // The settings page is open
+}
+
+// newPauseMenu creates the pause menu of a game drawn by loop. settings
+// holds the player's key bindings and saves their changes.
+func newPauseMenu(gameID, quitLabel string, loop *frameLoop, settings SettingsModel) *pauseMenu {
+	return &pauseMenu{
+		gameID:    gameID,
+		quitLabel: quitLabel,
+		loop:      loop,
+		settings:  settings,
+	}
+}
+
+// keys returns the key bindings, with any changes made on the controls
+// screen.
+func (p *pauseMenu) keys() *KeyBindings {
+	return p.settings.keys
+}
+
+// controlsOpen reports whether the controls screen is shown instead of the
+// game.
+func (p *pauseMenu) controlsOpen() bool {
+	return p.settings.controls != nil
+}
+
+// rows returns the labels of the current page.
+func (p *pauseMenu) rows() []string {
+	if p.inSettings {
+		return []string{
+			fmt.Sprintf("Theme       < %s >", p.settings.renderer.Theme().Name),
+			fmt.Sprintf("Frame rate  < %d fps >", p.loop.renderFPS()),
+			"Back",
+		}
+	}
+	return []string{"Resume", "Restart", "Controls", "Settings", p.quitLabel}
+}
+
+// handleKey processes a key. mapper maps the game's keys, so the pause key
+// resumes.
+func (p *pauseMenu) handleKey(msg tea.KeyMsg, mapper *KeyMapper) pauseResult {
+	if p.controlsOpen() {
+		model, _ := p.settings.handleControlsKey(msg)
+		p.settings = model.(SettingsModel)
+		if p.settings.quitting {
+			return pauseQuitAll
+		}
+		if !p.controlsOpen() {
+			p.loop.invalidate()
+		}
+		return pauseStay
+	}
+
+	_, action, isQuit := mapper.MapPlayerKey(msg)
+	switch {
+	case isQuit:
+		return pauseQuitAll
+	case action == core.ActionPause:
+		return pauseResumed
+	case p.inSettings && (action == core.ActionLeft || action == core.ActionRight):
+		step := 1
+		if action == core.ActionLeft {
+			step = -1
+		}
+		p.changeSetting(step)
+		return pauseStay
+	}
+	return p.handleAction(mapper.MapKeyToMenuAction(msg))
+}
+
+// handleMouse processes a mouse event over the menu on a w x h screen.
+func (p *pauseMenu) handleMouse(msg tea.MouseMsg, w, h int) pauseResult {
+	if p.controlsOpen() {
+		return pauseStay
+	}
+	first := pauseBox(p.rows(), p.footer(), w, h).Y + 3
+	var action MenuAction
+	if p.inSettings {
+		p.settingsRow, action = menuMouse(msg, first, len(p.rows()), p.settingsRow)
+	} else {
+		p.cursor, action = menuMouse(msg, first, len(p.rows()), p.cursor)
+	}
+	return p.handleAction(action)
+}
+
+// handleAction applies a menu action to the current page.
+func (p *pauseMenu) handleAction(action MenuAction) pauseResult {
+	cursor := &p.cursor
+	if p.inSettings {
+		cursor = &p.settingsRow
+	}
+
+	switch action {
+	case MenuActionQuit:
+		return pauseQuitAll
+	case MenuActionUp:
+		*cursor = max(*cursor-1, 0)
+	case MenuActionDown:
+		*cursor = min(*cursor+1, len(p.rows())-1)
+	case MenuActionBack:
+		if !p.inSettings {
+			return pauseResumed
+		}
+		p.inSettings = false
+	case MenuActionSelect:
+		if p.inSettings {
+			if p.settingsRow == pauseSettingBack {
+				p.inSettings = false
+			} else {
+				p.changeSetting(1)
+			}
+			break
+		}
+		switch p.cursor {
+		case pauseResume:
+			return pauseResumed
+		case pauseRestart:
+			return pauseRestarted
+		case pauseControls:
+			p.settings.openControls(p.gameID)
+		case pauseSettings:
+			p.inSettings = true
+			p.settingsRow = 0
+			p.settings.message = ""
+		case pauseQuit:
+			return pauseLeave
+		}
+	}
+	p.loop.invalidate()
+	return pauseStay
+}
+
+// changeSetting moves the setting under the cursor step choices along.
+func (p *pauseMenu) changeSetting(step int) {
+	switch p.settingsRow {
+	case pauseSettingTheme:
+		themes := p.settings.themes
+		current := 0
+		for i, theme := range themes {
+			if theme.Name == p.settings.renderer.Theme().Name {
+				current = i
+			}
+		}
+		p.settings.selectTheme(themes[(current+step+len(themes))%len(themes)])
+	case pauseSettingFPS:
+		fps := nextChoice(renderFPSChoices, p.loop.renderFPS(), step)
+		p.loop.setRenderFPS(fps)
+		p.settings.message = fmt.Sprintf("Frame rate set to %d fps", fps)
+	}
+	p.loop.invalidate()
+}
+
+// footer returns the line under the current page: a hint, or the result of
+// the last settings change.
+func (p *pauseMenu) footer() string {
+	switch {
+	case !p.inSettings:
+		return "P: Resume"
+	case p.settings.message != "":
+		return p.settings.message
+	}
+	return "Left/Right: Change"
+}
+
+// render draws the menu over the paused game.
+func (p *pauseMenu) render(dst *core.Screen) {
+	title, cursor := "PAUSED", p.cursor
+	if p.inSettings {
+		title, cursor = "SETTINGS", p.settingsRow
+	}
+	rows, footer := p.rows(), p.footer()
+
+	box := pauseBox(rows, footer, dst.Width(), dst.Height())
+	dst.DrawRect(box, ' ')
+	dst.DrawBox(box)
+	dst.DrawTextCenteredWithColor(box.Y+1, title, core.RoleTitle)
+	for i, row := range rows {
+		x, y := box.X+3, box.Y+3+i
+		if i == cursor {
+			dst.DrawTextStyled(x-2, y, "> "+row, core.RoleHUD, core.ColorDefault, core.AttrBold)
+			continue
+		}
+		dst.DrawText(x, y, row)
+	}
+	dst.DrawTextCenteredWithColor(box.Bottom()-2, footer, core.RoleMuted)
+}
+
+// view returns the controls screen while it is open.
+func (p *pauseMenu) view(width int) string {
+	return p.settings.controls.View(width)
+}
+
+// pauseBox returns where the menu with rows sits on a w x h screen: centered,
+// with the title, a blank line, the rows, a blank line and the footer inside
+// a border.
+func pauseBox(rows []string, footer string, w, h int) core.Rect {
+	width := core.StringWidth(footer)
+	for _, row := range rows {
+		width = max(width, core.StringWidth(row))
+	}
+	box := core.NewRect(0, 0, width+6, len(rows)+6)
+	box.X = (w - box.W) / 2
+	box.Y = (h - box.H) / 2
+	return box
+}
@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+)
+
+// raceGames are the games two players can race in split screen.
+var raceGames = []string{"flappy", "dino"}
+
+// raceCountdown is how long the countdown before a race lasts, in seconds.
+const raceCountdown = 3
+
+// IsRaceGame reports whether a game can be played as a split-screen race.
+func IsRaceGame(gameID string) bool {
+	return slices.Contains(raceGames, gameID)
+}
+
+// NewLocalGame creates a game for a local match at the given difficulty
+// preset, empty for the game's config. Local 2P makes a split-screen race
+// of the runner games and lets two players share the keyboard in games that
+// support it; other modes create the game as is.
+func NewLocalGame(gameID string, mode multiplayer.MatchMode, difficulty string) (registry.Game, error) {
+	twoPlayer := mode == multiplayer.MatchModeLocal2P
+	if twoPlayer && IsRaceGame(gameID) {
+		return newRaceGame(gameID, difficulty)
+	}
+	game, err := createGame(gameID, difficulty)
+	if err != nil {
+		return nil, err
+	}
+	if tp, ok := game.(registry.TwoPlayerGame); ok {
+		tp.SetTwoPlayer(twoPlayer)
+	}
+	return game, nil
+}
+
+// createGame creates a registered game at the given difficulty preset.
+func createGame(gameID, difficulty string) (registry.Game, error) {
+	game, err := registry.Create(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := game.(registry.DifficultyGame); ok {
+		d.SetDifficulty(difficulty)
+	}
+	return game, nil
+}
+
+// raceGame runs two copies of a game with the same seed, one per player,
+// each drawn into its own half of the screen. The race starts after a
+// countdown and ends when a player crashes: whoever survives longer wins.
+type raceGame struct {
+	games    [2]registry.Game
+	cfg      core.RuntimeConfig
+	tick     int // Ticks since the race was reset
+	start    int // Tick the countdown ends on
+	finished bool
+	winner   core.PlayerID // 0 for a draw
+}
+
+// newRaceGame creates a race of two copies of a registered game.
+func newRaceGame(gameID, difficulty string) (*raceGame, error) {
+	r := &raceGame{}
+	for i := range r.games {
+		game, err := createGame(gameID, difficulty)
+		if err != nil {
+			return nil, err
+		}
+		r.games[i] = game
+	}
+	return r, nil
+}
+
+// ID returns the raced game's ID, so its key bindings and captures apply.
+func (r *raceGame) ID() string {
+	return r.games[0].ID()
+}
+
+// Title returns the display name of the race.
+func (r *raceGame) Title() string {
+	return r.games[0].Title() + " Race"
+}
+
+// SetTwoPlayer does nothing: a race always has two players.
+func (r *raceGame) SetTwoPlayer(bool) {}
+
+// TwoPlayer reports that two players share the keyboard.
+func (r *raceGame) TwoPlayer() bool {
+	return true
+}
+
+// Reset restarts both games with the same seed, sized to their halves.
+func (r *raceGame) Reset(cfg core.RuntimeConfig) {
+	r.cfg = cfg
+	r.tick = 0
+	r.start = raceCountdown * max(cfg.TickRate, 1)
+	r.finished = false
+	r.winner = 0
+
+	halves, _ := raceLayout(cfg.ScreenW, cfg.ScreenH)
+	for i, game := range r.games {
+		half := cfg
+		half.ScreenW, half.ScreenH = halves[i].W, halves[i].H
+		game.Reset(half)
+	}
+}
+
+// Step advances the race with input for Player1 only.
+func (r *raceGame) Step(in core.InputFrame) core.StepResult {
+	multi := core.NewMultiInputFrame()
+	multi.SetPlayer(core.Player1, in)
+	return r.StepMulti(multi)
+}
+
+// StepMulti advances both games by one tick, each with its player's input.
+// When the countdown ends both players get a Jump, so runners that wait for
+// one set off together.
+func (r *raceGame) StepMulti(in core.MultiInputFrame) core.StepResult {
+	if r.finished {
+		return core.StepResult{State: r.State()}
+	}
+
+	frames := [2]core.InputFrame{in.Player1Frame(), in.Player2Frame()}
+	r.tick++
+	if r.tick < r.start {
+		return core.StepResult{State: r.State()}
+	}
+
+	var crashed [2]bool
+	for i, game := range r.games {
+		if r.tick == r.start {
+			frames[i] = frames[i].Clone()
+			frames[i].Set(core.ActionJump)
+		}
+		crashed[i] = game.Step(frames[i]).State.GameOver
+	}
+	r.finish(crashed)
+
+	return core.StepResult{State: r.State()}
+}
+
+// finish ends the race once a player has crashed. If both crash on the
+// same tick, the higher score wins.
+func (r *raceGame) finish(crashed [2]bool) {
+	switch {
+	case crashed[0] && crashed[1]:
+		score1, score2 := r.games[0].State().Score, r.games[1].State().Score
+		switch {
+		case score1 > score2:
+			r.winner = core.Player1
+		case score2 > score1:
+			r.winner = core.Player2
+		}
+	case crashed[0]:
+		r.winner = core.Player2
+	case crashed[1]:
+		r.winner = core.Player1
+	default:
+		return
+	}
+	r.finished = true
+}
+
+// Render draws each game into its half, with the countdown or the result
+// on top.
+func (r *raceGame) Render(dst *core.Screen) {
+	halves, stacked := raceLayout(dst.Width(), dst.Height())
+	for i, game := range r.games {
+		game.Render(dst.Region(halves[i]))
+	}
+
+	if stacked {
+		dst.DrawHLine(0, halves[0].Bottom(), dst.Width(), '─')
+	} else {
+		dst.DrawVLine(halves[0].Right(), 0, dst.Height(), '│')
+	}
+	for i, role := range []core.Color{core.RolePlayer, core.RoleOpponent} {
+		label := fmt.Sprintf("P%d", i+1)
+		dst.DrawTextWithColor(halves[i].Right()-len(label)-1, halves[i].Y, label, role)
+	}
+
+	switch {
+	case r.finished:
+		msg := "DRAW!"
+		if r.winner != 0 {
+			msg = fmt.Sprintf("PLAYER %d WINS!", r.winner)
+		}
+		survived := float64(r.tick-r.start) / float64(max(r.cfg.TickRate, 1))
+		dst.DrawMessageBox(msg, fmt.Sprintf("Survived %.1fs  |  Press R to restart", survived))
+	case r.tick < r.start:
+		left := (r.start - r.tick + max(r.cfg.TickRate, 1) - 1) / max(r.cfg.TickRate, 1)
+		dst.DrawMessageBox(fmt.Sprintf("RACE STARTS IN %d", left), "Survive longer than the other player")
+	}
+}
+
+// State returns the state of the race. The score is Player1's.
+func (r *raceGame) State() core.GameState {
+	return core.GameState{
+		Score:    r.games[0].State().Score,
+		GameOver: r.finished,
+	}
+}
+
+// raceLayout splits a w x h screen into the halves of the two players with
+// a one-cell divider between them. The halves sit side by side, or one
+// above the other on terminals at least four times wider than tall, which
+// keeps both playfields wide. It reports whether the halves are stacked.
+func raceLayout(w, h int) ([2]core.Rect, bool) {
+	if w >= 4*h {
+		top := (h - 1) / 2
+		return [2]core.Rect{
+			core.NewRect(0, 0, w, top),
+			core.NewRect(0, top+1, w, h-top-1),
+		}, true
+	}
+	left := (w - 1) / 2
+	return [2]core.Rect{
+		core.NewRect(0, 0, left, h),
+		core.NewRect(left+1, 0, w-left-1, h),
+	}, false
+}
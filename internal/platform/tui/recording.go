@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vovakirdan/tui-arcade/internal/asciicast"
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
+)
+
+// recordKey starts and stops recording the current game.
+const recordKey = "ctrl+r"
+
+// Recording limits for recordings saved to the database, which protect the
+// server. A recording that reaches one is stopped and saved; local files
+// have no limit.
+const (
+	maxRecordingTime = 10 * time.Minute
+	maxRecordingSize = 16 << 20 // Bytes buffered for a recording saved to the database
+)
+
+// DefaultMaxRecordings is how many recordings the SSH server keeps per player.
+const DefaultMaxRecordings = 5
+
+// recordPath is the file the next local game is recorded to, set with --record.
+var recordPath string
+
+// SetRecordPath makes Run record the game to an asciicast file from the
+// start. Empty disables it; Ctrl+R still records on demand.
+func SetRecordPath(path string) {
+	recordPath = path
+}
+
+// recording writes the frames of a game as an asciicast. Frames come from the
+// same renderer as the player's terminal, so the recording shows exactly what
+// was displayed, overlays included.
+type recording struct {
+	target captureTarget
+	name   string
+	render func(*core.Screen) string // Renders frames the caller didn't render itself
+
+	cast   *asciicast.Writer
+	path   string        // Local file; empty when saved to the database
+	file   *os.File      // Local file being written
+	out    *bufio.Writer // Buffers writes to file
+	buf    bytes.Buffer  // Recording saved to the database when finished
+	width  int           // Screen size of the last frame
+	height int
+	last   string // Last recorded frame, to skip unchanged ones
+	full   bool   // A limit was reached or writing failed; no more frames are recorded
+	err    error  // Why writing failed
+}
+
+// startRecording starts recording a game of the given screen size. A local
+// recording goes to path, or to ~/.arcade/recordings if path is empty.
+func (t captureTarget) startRecording(name, path string, width, height int, render func(*core.Screen) string) (*recording, error) {
+	r := &recording{target: t, name: name, render: render, width: width, height: height}
+
+	var w io.Writer
+	env := map[string]string{}
+	if t.remote {
+		if t.profile == "" {
+			return nil, errors.New("recordings need an SSH key")
+		}
+		if t.store == nil {
+			return nil, errors.New("recordings need the scores database")
+		}
+		if t.recordings <= 0 {
+			return nil, errors.New("recording is disabled on this server")
+		}
+		w = &r.buf
+	} else {
+		if path == "" {
+			timestamp := time.Now().Format("20060102_150405")
+			path = filepath.Join(os.Getenv("HOME"), ".arcade", "recordings", fmt.Sprintf("%s_%s.cast", name, timestamp))
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		r.path, r.file, r.out = path, file, bufio.NewWriter(file)
+		w = r.out
+		if term := os.Getenv("TERM"); term != "" {
+			env["TERM"] = term
+		}
+	}
+
+	cast, err := asciicast.NewWriter(w, asciicast.Header{
+		Width:     width,
+		Height:    height,
+		Timestamp: time.Now(),
+		Title:     fmt.Sprintf("TUI Arcade - %s", name),
+		Env:       env,
+	})
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	r.cast = cast
+	return r, nil
+}
+
+// frame records a frame drawn on s at now. output is the frame as sent to the
+// terminal; empty renders it. Returns false once a limit has been reached.
+func (r *recording) frame(now time.Time, s *core.Screen, output string) bool {
+	if r.full {
+		return false
+	}
+
+	if s.Width() != r.width || s.Height() != r.height {
+		r.width, r.height = s.Width(), s.Height()
+		r.cast.Resize(now, r.width, r.height) //nolint:errcheck // Write errors are reported by the next Frame
+		r.last = ""
+	}
+
+	if output == "" {
+		output = r.render(s)
+	}
+	if output != r.last {
+		if err := r.cast.Frame(now, output); err != nil {
+			r.full, r.err = true, err
+			return false
+		}
+		r.last = output
+	}
+
+	if r.target.remote && (r.cast.Elapsed() >= maxRecordingTime || r.buf.Len() >= maxRecordingSize) {
+		r.full = true
+	}
+	return !r.full
+}
+
+// finish saves the recording and returns a message saying where it went.
+func (r *recording) finish() string {
+	duration := r.cast.Elapsed().Round(time.Second)
+	limit := ""
+	if r.full {
+		limit = ", limit reached"
+	}
+
+	if !r.target.remote {
+		err := r.out.Flush()
+		if closeErr := r.file.Close(); err == nil {
+			err = closeErr
+		}
+		if r.err != nil {
+			err = r.err
+		}
+		if err != nil {
+			return "Recording failed: " + err.Error()
+		}
+		return fmt.Sprintf("Recording saved (%s%s): %s", duration, limit, r.path)
+	}
+
+	if r.err != nil {
+		return "Recording failed: " + r.err.Error()
+	}
+	id, err := r.target.store.SaveRecording(storage.Recording{
+		Username: r.target.profile,
+		GameID:   r.name,
+		Duration: r.cast.Elapsed(),
+		Content:  r.buf.Bytes(),
+	}, r.target.recordings)
+	if err != nil {
+		return "Recording failed: " + err.Error()
+	}
+	return fmt.Sprintf("Recording %d saved (%s%s): ssh <server> recording %d > %s.cast", id, duration, limit, id, r.name)
+}
+
+// close releases a recording that could not be started.
+func (r *recording) close() {
+	if r.file != nil {
+		r.file.Close() //nolint:errcheck // The recording is abandoned anyway
+	}
+}
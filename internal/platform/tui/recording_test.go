@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+func TestRecordingTimeLimit(t *testing.T) {
+	screen := core.NewScreen(10, 3)
+	frames := 0
+	render := func(*core.Screen) string {
+		frames++
+		return strings.Repeat("x", frames)
+	}
+
+	// Long local recordings go on: they only fill the player's disk
+	local := captureTarget{profile: "player"}
+	r, err := local.startRecording("snake", filepath.Join(t.TempDir(), "snake.cast"), 10, 3, render)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	r.frame(start, screen, "")
+	if !r.frame(start.Add(maxRecordingTime+time.Minute), screen, "") {
+		t.Error("local recording stopped at the time limit")
+	}
+	if msg := r.finish(); strings.Contains(msg, "limit") {
+		t.Errorf("local recording: %s", msg)
+	}
+
+	// Recordings kept on the server stop
+	remote := captureTarget{store: testStore(t), profile: "SHA256:alice", remote: true, recordings: 1}
+	r, err = remote.startRecording("snake", "", 10, 3, render)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.frame(start, screen, "")
+	if r.frame(start.Add(maxRecordingTime+time.Minute), screen, "") {
+		t.Error("server recording went past the time limit")
+	}
+	if msg := r.finish(); !strings.Contains(msg, "limit reached") {
+		t.Errorf("server recording: %s", msg)
+	}
+}
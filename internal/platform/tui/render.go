@@ -1,69 +1,224 @@
 package tui
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
 	"github.com/vovakirdan/tui-arcade/internal/core"
 )
 
-// colorStyles maps core.Color to lipgloss styles.
-var colorStyles = map[core.Color]lipgloss.Style{
-	core.ColorDefault:       lipgloss.NewStyle(),
-	core.ColorRed:           lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
-	core.ColorGreen:         lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
-	core.ColorYellow:        lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
-	core.ColorBlue:          lipgloss.NewStyle().Foreground(lipgloss.Color("4")),
-	core.ColorMagenta:       lipgloss.NewStyle().Foreground(lipgloss.Color("5")),
-	core.ColorCyan:          lipgloss.NewStyle().Foreground(lipgloss.Color("6")),
-	core.ColorWhite:         lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
-	core.ColorBrightRed:     lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
-	core.ColorBrightGreen:   lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
-	core.ColorBrightYellow:  lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
-	core.ColorBrightBlue:    lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
-	core.ColorBrightMagenta: lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
-	core.ColorBrightCyan:    lipgloss.NewStyle().Foreground(lipgloss.Color("14")),
-	core.ColorBrightWhite:   lipgloss.NewStyle().Foreground(lipgloss.Color("15")),
-	core.ColorOrange:        lipgloss.NewStyle().Foreground(lipgloss.Color("208")),
-	core.ColorGray:          lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
-}
-
-// RenderScreen converts a Screen buffer to a styled string for display.
-// Groups adjacent cells with the same color to minimize ANSI escape sequences.
-func RenderScreen(s *core.Screen) string {
+// monoFillGlyph replaces blank cells whose only content is a background fill
+// when colors are unavailable, so filled shapes stay visible.
+const monoFillGlyph = '░'
+
+// cellStyle is the part of a cell that determines how it is styled,
+// after its colors have been resolved and down-sampled.
+type cellStyle struct {
+	fg    core.Color
+	bg    core.Color
+	attrs core.Attr
+}
+
+// ScreenRenderer renders screens for one terminal.
+// Color roles are resolved with the renderer's theme, then colors are
+// down-sampled to the terminal's color profile; with no color support
+// (including NO_COLOR and monochrome themes) they are dropped and background
+// fills become glyphs.
+type ScreenRenderer struct {
+	renderer *lipgloss.Renderer
+	profile  core.ColorProfile
+	theme    atomic.Pointer[core.Theme]
+
+	// Built lipgloss styles by cellStyle.
+	// SSH sessions render concurrently, so it must be safe for concurrent use.
+	styles sync.Map
+}
+
+// NewScreenRenderer creates a screen renderer for the lipgloss renderer's output.
+func NewScreenRenderer(r *lipgloss.Renderer) *ScreenRenderer {
+	return &ScreenRenderer{
+		renderer: r,
+		profile:  colorProfile(r.ColorProfile()),
+	}
+}
+
+// defaultScreenRenderer renders to the local terminal.
+var defaultScreenRenderer = sync.OnceValue(func() *ScreenRenderer {
+	return NewScreenRenderer(lipgloss.DefaultRenderer())
+})
+
+// colorProfile converts a detected termenv profile to a core color profile.
+func colorProfile(p termenv.Profile) core.ColorProfile {
+	switch p {
+	case termenv.TrueColor:
+		return core.ProfileTrueColor
+	case termenv.ANSI256:
+		return core.ProfileANSI256
+	case termenv.ANSI:
+		return core.ProfileANSI
+	default:
+		return core.ProfileMono
+	}
+}
+
+// Profile returns the color profile of the terminal.
+func (r *ScreenRenderer) Profile() core.ColorProfile {
+	return r.profile
+}
+
+// Theme returns the theme color roles are resolved with.
+func (r *ScreenRenderer) Theme() *core.Theme {
+	if theme := r.theme.Load(); theme != nil {
+		return theme
+	}
+	return core.DefaultTheme()
+}
+
+// SetTheme changes the theme color roles are resolved with.
+// A nil theme selects the default theme.
+func (r *ScreenRenderer) SetTheme(theme *core.Theme) {
+	r.theme.Store(theme)
+}
+
+// WithTheme returns a renderer for the same terminal that uses another theme,
+// e.g. to preview a theme before selecting it.
+func (r *ScreenRenderer) WithTheme(theme *core.Theme) *ScreenRenderer {
+	preview := NewScreenRenderer(r.renderer)
+	preview.SetTheme(theme)
+	return preview
+}
+
+// activeProfile returns the profile to render with: the terminal's profile,
+// or monochrome if the theme asks for it.
+func (r *ScreenRenderer) activeProfile(theme *core.Theme) core.ColorProfile {
+	if theme.Monochrome {
+		return core.ProfileMono
+	}
+	return r.profile
+}
+
+// lipglossColor converts a down-sampled color to a lipgloss color.
+func lipglossColor(c core.Color) lipgloss.Color {
+	if index, ok := c.Index(); ok {
+		return lipgloss.Color(strconv.Itoa(int(index)))
+	}
+	red, green, blue := c.RGBValues()
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", red, green, blue))
+}
+
+// styleKey returns a cell's style with its colors resolved and down-sampled.
+func styleKey(cell core.Cell, theme *core.Theme, profile core.ColorProfile) cellStyle {
+	return cellStyle{
+		fg:    theme.Resolve(cell.Color).Downsample(profile),
+		bg:    theme.Resolve(cell.Background).Downsample(profile),
+		attrs: cell.Attrs,
+	}
+}
+
+// style returns the lipgloss style for a cell's colors and attributes.
+func (r *ScreenRenderer) style(cell core.Cell, theme *core.Theme, profile core.ColorProfile) lipgloss.Style {
+	key := styleKey(cell, theme, profile)
+	if cached, ok := r.styles.Load(key); ok {
+		if style, isStyle := cached.(lipgloss.Style); isStyle {
+			return style
+		}
+	}
+
+	style := r.renderer.NewStyle()
+	if !key.fg.IsDefault() {
+		style = style.Foreground(lipglossColor(key.fg))
+	}
+	if !key.bg.IsDefault() {
+		style = style.Background(lipglossColor(key.bg))
+	}
+	if cell.Attrs.Has(core.AttrBold) {
+		style = style.Bold(true)
+	}
+	if cell.Attrs.Has(core.AttrDim) {
+		style = style.Faint(true)
+	}
+	if cell.Attrs.Has(core.AttrReverse) {
+		style = style.Reverse(true)
+	}
+	if cell.Attrs.Has(core.AttrUnderline) {
+		style = style.Underline(true)
+	}
+
+	r.styles.Store(key, style)
+	return style
+}
+
+// glyph returns the rune to draw for a cell, followed by its Combining runes.
+func glyph(cell core.Cell, profile core.ColorProfile) rune {
+	if cell.IsTransparent() {
+		return ' ' // A layer drawn without compositing
+	}
+	if profile == core.ProfileMono && cell.Rune == ' ' && !cell.Background.IsDefault() {
+		return monoFillGlyph
+	}
+	return cell.Rune
+}
+
+// Render converts a Screen buffer to a styled string for display.
+// Groups adjacent cells with the same colors and attributes to minimize ANSI escape sequences.
+// A nil renderer renders for the local terminal.
+func (r *ScreenRenderer) Render(s *core.Screen) string {
+	if r == nil {
+		r = defaultScreenRenderer()
+	}
+	theme := r.Theme()
+	profile := r.activeProfile(theme)
+
 	var sb strings.Builder
 	// Pre-allocate with extra space for ANSI codes
 	sb.Grow(s.Width()*s.Height()*2 + s.Height())
 
+	var run strings.Builder
 	for y := range s.Height() {
 		if y > 0 {
 			sb.WriteRune('\n')
 		}
 
-		// Group consecutive cells with the same color for efficiency
+		// Group consecutive cells with the same style for efficiency
 		x := 0
 		for x < s.Width() {
-			cell := s.GetCell(x, y)
-			startColor := cell.Color
+			start := s.GetCell(x, y)
 
-			// Collect consecutive cells with same color
-			var run strings.Builder
+			// Collect consecutive cells with same style
+			run.Reset()
 			for x < s.Width() {
-				cell = s.GetCell(x, y)
-				if cell.Color != startColor {
+				cell := s.GetCell(x, y)
+				if cell.IsContinuation() {
+					x++ // Drawn by the wide character to its left
+					continue
+				}
+				if !cell.SameStyle(start) {
 					break
 				}
-				run.WriteRune(cell.Rune)
+				run.WriteRune(glyph(cell, profile))
+				run.WriteString(cell.Combining)
 				x++
 			}
 
 			// Apply style to the run
-			style, ok := colorStyles[startColor]
-			if !ok {
-				style = colorStyles[core.ColorDefault]
-			}
-			sb.WriteString(style.Render(run.String()))
+			sb.WriteString(r.style(start, theme, profile).Render(run.String()))
 		}
 	}
 	return sb.String()
 }
+
+// RenderScreen converts a Screen buffer to a styled string for the local terminal.
+func RenderScreen(s *core.Screen) string {
+	return defaultScreenRenderer().Render(s)
+}
+
+// SetTheme sets the theme used to render to the local terminal.
+func SetTheme(theme *core.Theme) {
+	defaultScreenRenderer().SetTheme(theme)
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// Benchmarks compare the full-frame renderer (RenderScreen) with the diff renderer.
+// Besides time and allocations they report the bytes sent to the terminal per frame:
+//
+//	go test ./internal/platform/tui -run '^$' -bench . -benchmem
+
+const benchW, benchH = 80, 24
+
+// benchScreenRenderer renders 256 colors regardless of where the benchmark runs.
+func benchScreenRenderer() *ScreenRenderer {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+	return NewScreenRenderer(r)
+}
+
+// drawStaticFrame draws a mostly static board like Snake's: walls, a HUD and
+// a short snake that moves one cell per frame.
+func drawStaticFrame(s *core.Screen, frame int) {
+	s.Clear()
+	s.DrawTextWithColor(0, 0, "Score: 120  Level: 3", core.RoleHUD)
+	for x := range benchW {
+		s.SetWithColor(x, 1, '#', core.RoleWall)
+		s.SetWithColor(x, benchH-1, '#', core.RoleWall)
+	}
+	for y := 1; y < benchH; y++ {
+		s.SetWithColor(0, y, '#', core.RoleWall)
+		s.SetWithColor(benchW-1, y, '#', core.RoleWall)
+	}
+	s.SetWithColor(40, 12, '*', core.RolePickup)
+
+	head := 1 + frame%(benchW-6)
+	for i := range 4 {
+		s.SetWithColor(head+i, 10, 'o', core.RolePlayer)
+	}
+	s.SetWithColor(head+4, 10, 'O', core.RolePlayer)
+}
+
+// drawBusyFrame draws a frame where every cell changes color each frame.
+func drawBusyFrame(s *core.Screen, frame int) {
+	for y := range benchH {
+		for x := range benchW {
+			c := core.Color256(uint8((x + y + frame) % 256)) //nolint:gosec // Kept below 256
+			s.SetCell(x, y, core.Cell{Rune: '█', Color: c, Background: core.ColorBlack})
+		}
+	}
+}
+
+func benchmarkRenderScreen(b *testing.B, draw func(*core.Screen, int)) {
+	r := benchScreenRenderer()
+	s := core.NewScreen(benchW, benchH)
+	total := 0
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		draw(s, i)
+		total += len(r.Render(s))
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "bytes/frame")
+}
+
+func benchmarkDiffRenderer(b *testing.B, draw func(*core.Screen, int)) {
+	d := NewDiffRenderer(benchScreenRenderer())
+	s := core.NewScreen(benchW, benchH)
+	draw(s, 0)
+	d.Frame(s) // The first frame is always a full redraw
+	total := 0
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		draw(s, i+1)
+		total += len(d.Frame(s))
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "bytes/frame")
+}
+
+func BenchmarkRenderScreenStatic(b *testing.B) {
+	benchmarkRenderScreen(b, drawStaticFrame)
+}
+
+func BenchmarkDiffRendererStatic(b *testing.B) {
+	benchmarkDiffRenderer(b, drawStaticFrame)
+}
+
+func BenchmarkRenderScreenBusy(b *testing.B) {
+	benchmarkRenderScreen(b, drawBusyFrame)
+}
+
+func BenchmarkDiffRendererBusy(b *testing.B) {
+	benchmarkDiffRenderer(b, drawBusyFrame)
+}
+
+func BenchmarkDiffRendererFullRedraw(b *testing.B) {
+	d := NewDiffRenderer(benchScreenRenderer())
+	s := core.NewScreen(benchW, benchH)
+	drawStaticFrame(s, 0)
+	total := 0
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		d.Invalidate()
+		total += len(d.Frame(s))
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "bytes/frame")
+}
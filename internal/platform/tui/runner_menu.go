@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+)
+
+// runnerModes are the modes of the runner games, in menu order.
+var runnerModes = []multiplayer.MatchMode{multiplayer.MatchModeSolo, multiplayer.MatchModeLocal2P}
+
+// RunnerSelection holds the user's selection from a runner game's menu.
+type RunnerSelection struct {
+	Mode multiplayer.MatchMode
+}
+
+// RunnerModeModel lets users choose between playing a runner game alone and
+// racing a second player in split screen.
+type RunnerModeModel struct {
+	gameID    string
+	cursor    int
+	width     int
+	height    int
+	keyMapper *KeyMapper
+	selection RunnerSelection
+	choosing  bool
+	quitting  bool
+	back      bool
+}
+
+// NewRunnerModeModel creates a mode selection model for a runner game.
+func NewRunnerModeModel(gameID string, width, height int) RunnerModeModel {
+	return RunnerModeModel{
+		gameID:    gameID,
+		width:     width,
+		height:    height,
+		keyMapper: NewKeyMapper(),
+		choosing:  true,
+	}
+}
+
+// Init initializes the model.
+func (m RunnerModeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages.
+func (m RunnerModeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleAction(m.keyMapper.MapKeyToMenuAction(msg))
+	case tea.MouseMsg:
+		var action MenuAction
+		m.cursor, action = menuMouse(msg, menuListRow, len(runnerModes), m.cursor)
+		return m.handleAction(action)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m RunnerModeModel) handleAction(action MenuAction) (tea.Model, tea.Cmd) {
+	switch action {
+	case MenuActionQuit:
+		m.quitting = true
+		return m, tea.Quit
+	case MenuActionUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case MenuActionDown:
+		if m.cursor < len(runnerModes)-1 {
+			m.cursor++
+		}
+	case MenuActionSelect:
+		m.choosing = false
+		m.selection = RunnerSelection{Mode: runnerModes[m.cursor]}
+		return m, tea.Quit
+	case MenuActionBack:
+		m.back = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// View renders the mode selection.
+func (m RunnerModeModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(centerText(strings.ToUpper(registry.Title(m.gameID)), m.width))
+	b.WriteString("\n\n")
+	b.WriteString(centerText("Select game mode:", m.width))
+	b.WriteString("\n\n")
+
+	for i, mode := range runnerModes {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		b.WriteString(centerText(fmt.Sprintf("%s%s", cursor, runnerModeLabel(mode)), m.width))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(centerText("Enter: Select  |  Esc: Back  |  Q: Quit", m.width))
+
+	return b.String()
+}
+
+// runnerModeLabel returns the menu label for a runner game mode.
+func runnerModeLabel(mode multiplayer.MatchMode) string {
+	if mode == multiplayer.MatchModeLocal2P {
+		return "Split-screen race (2P)"
+	}
+	return mode.String()
+}
+
+// Selected returns the selection, or nil if still choosing.
+func (m RunnerModeModel) Selected() *RunnerSelection {
+	if m.choosing {
+		return nil
+	}
+	return &m.selection
+}
+
+// IsQuitting returns true if user wants to quit.
+func (m RunnerModeModel) IsQuitting() bool {
+	return m.quitting
+}
+
+// WantsBack returns true if user pressed back.
+func (m RunnerModeModel) WantsBack() bool {
+	return m.back
+}
+
+// RunRunnerModeSelector runs the mode selection of a runner game and returns the selection.
+func RunRunnerModeSelector(gameID string, cfg core.RuntimeConfig) (*RunnerSelection, core.RuntimeConfig, error) {
+	model := NewRunnerModeModel(gameID, cfg.ScreenW, cfg.ScreenH)
+
+	p := tea.NewProgram(
+		model,
+		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
+	)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, cfg, err
+	}
+
+	m, ok := finalModel.(RunnerModeModel)
+	if !ok {
+		return nil, cfg, nil
+	}
+
+	if m.IsQuitting() || m.WantsBack() {
+		return nil, cfg, nil
+	}
+
+	return m.Selected(), cfg, nil
+}
@@ -10,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/vovakirdan/tui-arcade/internal/core"
 	"github.com/vovakirdan/tui-arcade/internal/registry"
 	"github.com/vovakirdan/tui-arcade/internal/storage"
 )
@@ -241,20 +242,11 @@ func (m ScoreboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.NextGame), key.Matches(msg, m.keys.Right):
-			if len(m.games) > 0 {
-				m.gameCursor = (m.gameCursor + 1) % len(m.games)
-				m.loadScores(m.games[m.gameCursor].ID)
-			}
+			m.switchGame(1)
 			return m, nil
 
 		case key.Matches(msg, m.keys.PrevGame), key.Matches(msg, m.keys.Left):
-			if len(m.games) > 0 {
-				m.gameCursor--
-				if m.gameCursor < 0 {
-					m.gameCursor = len(m.games) - 1
-				}
-				m.loadScores(m.games[m.gameCursor].ID)
-			}
+			m.switchGame(-1)
 			return m, nil
 
 		case key.Matches(msg, m.keys.Up), key.Matches(msg, m.keys.Down):
@@ -263,6 +255,9 @@ func (m ScoreboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -278,15 +273,78 @@ func (m ScoreboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// View renders the scoreboard.
-func (m ScoreboardModel) View() string {
-	if m.quitting || m.goingBack {
-		return ""
+// switchGame shows the scores of the game delta places away in the list.
+func (m *ScoreboardModel) switchGame(delta int) {
+	if len(m.games) == 0 {
+		return
 	}
+	m.gameCursor = (m.gameCursor + delta + len(m.games)) % len(m.games)
+	m.loadScores(m.games[m.gameCursor].ID)
+}
 
-	var b strings.Builder
+// handleMouse picks a game with a click on its name or tab, scrolls the
+// scores with the wheel and goes back with a right click.
+func (m ScoreboardModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.table.MoveUp(1)
+	case tea.MouseButtonWheelDown:
+		m.table.MoveDown(1)
+	case tea.MouseButtonRight:
+		m.goingBack = true
+		return m, tea.Quit
+	case tea.MouseButtonLeft:
+		if i, ok := m.gameAt(msg.X, msg.Y); ok && i != m.gameCursor {
+			m.gameCursor = i
+			m.loadScores(m.games[i].ID)
+		}
+	}
+	return m, nil
+}
 
-	// Title
+// gameAt returns the game whose name or tab is drawn at a cell. On narrow
+// screens that only show the current game between arrows, the arrows pick
+// the previous and next game.
+func (m ScoreboardModel) gameAt(x, y int) (int, bool) {
+	row := strings.Count(m.header(), "\n")
+	if m.showSidebar {
+		// Below the sidebar's top border, "Games" and the divider
+		i := y - row - 3
+		if x >= sidebarWidth+2 || i < 0 || i >= len(m.games) {
+			return 0, false
+		}
+		return i, true
+	}
+
+	if y != row || len(m.games) == 0 {
+		return 0, false
+	}
+	line, tabs := m.tabLine()
+	start := 0
+	if w := core.StringWidth(line); w < m.width {
+		start = (m.width - w) / 2 // As centerText pads it
+	}
+	if tabs == nil {
+		if x < m.width/2 {
+			return (m.gameCursor + len(m.games) - 1) % len(m.games), true
+		}
+		return (m.gameCursor + 1) % len(m.games), true
+	}
+	for i, tab := range tabs {
+		end := start + lipgloss.Width(tab)
+		if x >= start && x < end {
+			return i, true
+		}
+		start = end + 1
+	}
+	return 0, false
+}
+
+// header renders the title above the game list and scores.
+func (m ScoreboardModel) header() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("229")).
@@ -297,8 +355,19 @@ func (m ScoreboardModel) View() string {
 		title = fmt.Sprintf("HIGH SCORES - %s", m.games[m.gameCursor].Title)
 	}
 
-	b.WriteString(titleStyle.Render(centerText(title, m.width)))
-	b.WriteString("\n\n")
+	return titleStyle.Render(centerText(title, m.width)) + "\n\n"
+}
+
+// View renders the scoreboard.
+func (m ScoreboardModel) View() string {
+	if m.quitting || m.goingBack {
+		return ""
+	}
+
+	var b strings.Builder
+
+	// Title
+	b.WriteString(m.header())
 
 	if m.showSidebar {
 		// Wide layout: sidebar + table
@@ -363,11 +432,9 @@ func (m ScoreboardModel) renderWideLayout() string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, sidebarRendered, "  ", tableRendered)
 }
 
-// renderNarrowLayout renders the scoreboard with game tabs above the table.
-func (m ScoreboardModel) renderNarrowLayout() string {
-	var b strings.Builder
-
-	// Game tabs (horizontal)
+// tabLine renders the game tabs of the narrow layout, and the tabs on it.
+// When they don't fit, the line only shows the current game and no tabs.
+func (m ScoreboardModel) tabLine() (string, []string) {
 	tabStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241"))
 	activeTabStyle := lipgloss.NewStyle().
@@ -394,8 +461,17 @@ func (m ScoreboardModel) renderNarrowLayout() string {
 	if len(tabLine) > m.width-4 {
 		// Just show current game with arrows
 		current := m.games[m.gameCursor].Title
-		tabLine = fmt.Sprintf("< %s >", current)
+		return fmt.Sprintf("< %s >", current), nil
 	}
+	return tabLine, tabs
+}
+
+// renderNarrowLayout renders the scoreboard with game tabs above the table.
+func (m ScoreboardModel) renderNarrowLayout() string {
+	var b strings.Builder
+
+	// Game tabs (horizontal)
+	tabLine, _ := m.tabLine()
 	b.WriteString(centerText(tabLine, m.width))
 	b.WriteString("\n\n")
 
@@ -441,6 +517,7 @@ func RunScoreboard(store *storage.Store, width, height int) (goBack bool, err er
 	p := tea.NewProgram(
 		model,
 		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
 	)
 
 	finalModel, err := p.Run()
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/screenshot"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
+)
+
+// screenshotKey saves a screenshot of the current game.
+const screenshotKey = "ctrl+s"
+
+// maxStoredScreenshots is how many screenshots the server keeps per player.
+const maxStoredScreenshots = 20
+
+// screenshotFormatOverride is the format forced with --screenshot-format, if any.
+var screenshotFormatOverride screenshot.Format
+
+// SetScreenshotFormat forces a screenshot format for everyone, overriding
+// the format users picked in Settings. Empty restores their choice.
+func SetScreenshotFormat(f screenshot.Format) {
+	screenshotFormatOverride = f
+}
+
+// LoadScreenshotFormat returns the format a user's screenshots are saved in:
+// the forced format, else the one they picked in Settings, else the default.
+// The arguments are as for LoadSettings.
+func LoadScreenshotFormat(store *storage.Store, profile string, remote bool) screenshot.Format {
+	if screenshotFormatOverride != "" {
+		return screenshotFormatOverride
+	}
+	s, err := LoadSettings(store, profile, remote)
+	if err != nil {
+		return screenshot.DefaultFormat
+	}
+	f, _ := screenshot.ParseFormat(s.Screenshot) // Validated when loaded
+	return f
+}
+
+// captureTarget saves screenshots and recordings for a player: to files in
+// ~/.arcade locally, or to the database over SSH, where the server's home
+// directory is out of the player's reach.
+type captureTarget struct {
+	store      *storage.Store
+	profile    string          // Where the player's settings, screenshots and recordings are saved; see LoadSettings
+	renderer   *ScreenRenderer // Theme and color profile; nil for the local terminal
+	remote     bool            // Save to the database for "ssh <host> screenshot"
+	recordings int             // Recordings kept per player in the database; 0 disables remote recording
+}
+
+// localCaptures returns the target for the local terminal.
+func localCaptures(store *storage.Store) captureTarget {
+	return captureTarget{store: store, profile: LocalUsername()}
+}
+
+// saveScreenshot exports a screen in the player's format and returns a
+// message saying where it went.
+func (t captureTarget) saveScreenshot(name string, s *core.Screen) (string, error) {
+	renderer := t.renderer
+	if renderer == nil {
+		renderer = defaultScreenRenderer()
+	}
+	theme := renderer.Theme()
+	format := LoadScreenshotFormat(t.store, t.profile, t.remote)
+
+	content, err := screenshot.Bytes(s, format, screenshot.Options{
+		Theme:   theme,
+		Profile: renderer.activeProfile(theme),
+		Title:   fmt.Sprintf("TUI Arcade - %s", name),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if t.remote {
+		if t.profile == "" {
+			return "", errors.New("screenshots need an SSH key")
+		}
+		if t.store == nil {
+			return "", errors.New("screenshots need the scores database")
+		}
+		id, err := t.store.SaveScreenshot(storage.Screenshot{
+			Username: t.profile,
+			GameID:   name,
+			Format:   string(format),
+			Content:  content,
+		}, maxStoredScreenshots)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Screenshot %d saved: ssh <server> screenshot %d > %s%s", id, id, name, format.Extension()), nil
+	}
+
+	dir := filepath.Join(os.Getenv("HOME"), ".arcade", "screenshots")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	timestamp := time.Now().Format("20060102_150405")
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s%s", name, timestamp, format.Extension()))
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return "", err
+	}
+	return "Screenshot saved: " + path, nil
+}
+
+// screenshotMessage saves a screenshot and returns the message to show either way.
+func (t captureTarget) screenshotMessage(name string, s *core.Screen) string {
+	msg, err := t.saveScreenshot(name, s)
+	if err != nil {
+		return "Screenshot failed: " + err.Error()
+	}
+	return msg
+}
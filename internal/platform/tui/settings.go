@@ -0,0 +1,654 @@
+package tui
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os/user"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/config"
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/screenshot"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
+)
+
+// settingsMenuID is the pseudo game ID of the Settings entry in the menu.
+const settingsMenuID = "settings"
+
+// settingsSettingKey is the user setting holding an SSH player's settings
+// as YAML.
+const settingsSettingKey = "settings"
+
+// defaultTickRate is the simulation tick rate used when none is configured.
+const defaultTickRate = 60
+
+// tickRateChoices are the tick rates the settings screen cycles through.
+var tickRateChoices = []int{30, 60, 120}
+
+// attractChoices are the idle times, in seconds, before the menu's demos
+// start that the settings screen cycles through; -1 turns them off.
+var attractChoices = []int{-1, 30, 60, 120, 300}
+
+// errNoProfile is returned when a keyless SSH player's settings are saved.
+var errNoProfile = errors.New("connect with an SSH key to save settings")
+
+// LocalUsername returns the name local settings are saved under.
+func LocalUsername() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "local"
+}
+
+// LoadSettings returns a user's saved settings: an SSH player's profile when
+// remote, else ~/.arcade/settings.yaml. profile is the SSH player's key
+// fingerprint, as the username is whatever the client sent, and empty for
+// keyless players, who have no saved settings; locally it is LocalUsername.
+// Settings that don't load are returned empty with the error.
+func LoadSettings(store *storage.Store, profile string, remote bool) (config.Settings, error) {
+	var s config.Settings
+	var err error
+	switch {
+	case !remote:
+		s, err = config.LoadUserSettings()
+	case store != nil && profile != "":
+		var data string
+		var ok bool
+		if data, ok, err = store.GetUserSetting(profile, settingsSettingKey); err == nil && ok {
+			s, err = config.ParseSettings([]byte(data))
+		}
+	}
+	if err != nil {
+		return config.Settings{}, err
+	}
+	return s, nil
+}
+
+// saveSettings saves a user's settings where LoadSettings finds them.
+func saveSettings(store *storage.Store, profile string, remote bool, s config.Settings) error {
+	if !remote {
+		return config.SaveUserSettings(s)
+	}
+	if profile == "" {
+		return errNoProfile
+	}
+	if store == nil {
+		return errors.New("no database")
+	}
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+	return store.SetUserSetting(profile, settingsSettingKey, string(data))
+}
+
+// LoadUserTheme returns the theme a user selected, or the default theme.
+func LoadUserTheme(store *storage.Store, profile string, remote bool) *core.Theme {
+	s, err := LoadSettings(store, profile, remote)
+	if err != nil || s.Theme == "" {
+		return core.DefaultTheme()
+	}
+	themes, _ := config.LoadThemes() // Broken user theme files are reported in the settings screen
+	if theme := config.FindTheme(themes, s.Theme); theme != nil {
+		return theme
+	}
+	return core.DefaultTheme()
+}
+
+// themeSample is one labelled color in the theme preview.
+type themeSample struct {
+	label string
+	color core.Color
+}
+
+// themePreviewRows lay out the theme preview, one screen row each.
+var themePreviewRows = [][]themeSample{
+	{
+		{"@ player", core.RolePlayer},
+		{"@ opponent", core.RoleOpponent},
+		{"o ball", core.RoleBall},
+		{"# hazard", core.RoleHazard},
+		{"* pickup", core.RolePickup},
+		{"= wall", core.RoleWall},
+		{"_ ground", core.RoleGround},
+	},
+	{
+		{"HUD", core.RoleHUD},
+		{"Alert", core.RoleAlert},
+		{"Title", core.RoleTitle},
+		{"Muted", core.RoleMuted},
+	},
+}
+
+// themePreviewTiers are the ranked colors shown as filled swatches.
+var themePreviewTiers = []core.Color{
+	core.RoleTier1, core.RoleTier2, core.RoleTier3, core.RoleTier4,
+	core.RoleTier5, core.RoleTier6, core.RoleTier7, core.RoleTier8,
+}
+
+// settingsRow is an entry of the settings screen below the theme list.
+type settingsRow int
+
+const (
+	settingsRowNickname settingsRow = iota
+	settingsRowDifficulty
+	settingsRowTickRate
+	settingsRowRenderRate
+	settingsRowScreenshots
+	settingsRowAttract
+	settingsRowControls
+)
+
+// SettingsModel lets the user pick a color theme, a nickname, the default
+// difficulty, the tick and frame rates, the screenshot format and when the
+// menu's demos start, and opens the controls screen. A theme is applied to
+// the renderer right away, the rest from the next game; everything is saved
+// per user. Keyless SSH players' settings last the session.
+type SettingsModel struct {
+	store     *storage.Store
+	profile   string // Where settings are saved; see LoadSettings
+	renderer  *ScreenRenderer
+	remote    bool         // An SSH player; settings are saved to their profile
+	keys      *KeyBindings // Bindings edited on the controls screen
+	keyMapper *KeyMapper
+	width     int
+	height    int
+
+	prefs    config.Settings
+	themes   []*core.Theme
+	cursor   int            // Index into themes, then into rows
+	editing  bool           // The nickname is being typed
+	input    string         // Nickname typed so far
+	controls *ControlsModel // Open controls screen, or nil
+	message  string         // Result of the last save, or loading problems
+	back     bool
+	quitting bool
+}
+
+// NewSettingsModel creates the settings screen.
+// A nil renderer applies the theme to the local terminal and saves key
+// bindings to ~/.arcade/keys.yaml; otherwise they go to the user's profile.
+func NewSettingsModel(store *storage.Store, profile string, renderer *ScreenRenderer, width, height int) SettingsModel {
+	remote := renderer != nil
+	if renderer == nil {
+		renderer = defaultScreenRenderer()
+	}
+
+	m := SettingsModel{
+		store:     store,
+		profile:   profile,
+		renderer:  renderer,
+		remote:    remote,
+		keys:      currentKeyBindings(),
+		keyMapper: NewKeyMapper(),
+		width:     width,
+		height:    height,
+	}
+
+	prefs, err := LoadSettings(store, profile, remote)
+	if err != nil {
+		m.message = fmt.Sprintf("Saved settings could not be loaded: %v", err)
+	}
+	m.prefs = prefs
+
+	themes, err := config.LoadThemes()
+	if err != nil {
+		m.message = fmt.Sprintf("Some themes could not be loaded: %v", err)
+	}
+	if len(themes) == 0 {
+		themes = []*core.Theme{core.DefaultTheme()}
+	}
+	m.themes = themes
+
+	current := renderer.Theme().Name
+	for i, theme := range themes {
+		if theme.Name == current {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+// Init initializes the settings screen.
+func (m SettingsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages.
+func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+	return m, nil
+}
+
+// rows returns the entries below the theme list. The tick rate is shared by
+// everyone on a server, so SSH players don't get it.
+func (m SettingsModel) rows() []settingsRow {
+	if m.remote {
+		return []settingsRow{
+			settingsRowNickname, settingsRowDifficulty,
+			settingsRowRenderRate, settingsRowScreenshots, settingsRowAttract, settingsRowControls,
+		}
+	}
+	return []settingsRow{
+		settingsRowNickname, settingsRowDifficulty, settingsRowTickRate,
+		settingsRowRenderRate, settingsRowScreenshots, settingsRowAttract, settingsRowControls,
+	}
+}
+
+func (m SettingsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.controls != nil {
+		return m.handleControlsKey(msg)
+	}
+	if m.editing {
+		return m.handleNicknameKey(msg), nil
+	}
+
+	switch m.keyMapper.MapKeyToMenuAction(msg) {
+	case MenuActionQuit:
+		m.quitting = true
+		return m, tea.Quit
+	case MenuActionBack:
+		m.back = true
+		return m, tea.Quit
+	case MenuActionUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case MenuActionDown:
+		if m.cursor < len(m.themes)+len(m.rows())-1 {
+			m.cursor++
+		}
+	case MenuActionSelect:
+		if m.cursor < len(m.themes) {
+			m.selectTheme(m.themes[m.cursor])
+			break
+		}
+		switch m.rows()[m.cursor-len(m.themes)] {
+		case settingsRowNickname:
+			m.editing = true
+			m.input = m.prefs.Nickname
+			m.message = ""
+		case settingsRowDifficulty:
+			m.nextDifficulty()
+		case settingsRowTickRate:
+			m.prefs.FPS = nextChoice(tickRateChoices, cmp.Or(m.prefs.FPS, defaultTickRate), 1)
+			m.savePrefs(fmt.Sprintf("Tick rate set to %d per second", m.prefs.FPS))
+		case settingsRowRenderRate:
+			m.prefs.RenderFPS = nextChoice(renderFPSChoices, cmp.Or(m.prefs.RenderFPS, DefaultRenderFPS), 1)
+			m.savePrefs(fmt.Sprintf("Frame rate set to %d fps", m.prefs.RenderFPS))
+		case settingsRowScreenshots:
+			m.nextScreenshotFormat()
+		case settingsRowAttract:
+			m.prefs.Attract = nextChoice(attractChoices, cmp.Or(m.prefs.Attract, int(defaultAttractAfter.Seconds())), 1)
+			m.savePrefs("Attract mode " + attractLabel(m.prefs.Attract))
+		case settingsRowControls:
+			m.openControls("")
+		}
+	}
+	return m, nil
+}
+
+// handleNicknameKey edits the nickname: Enter saves it, Esc keeps the old one.
+func (m SettingsModel) handleNicknameKey(msg tea.KeyMsg) SettingsModel {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editing = false
+		m.prefs.Nickname = strings.TrimSpace(m.input)
+		if m.prefs.Nickname == "" {
+			m.savePrefs("Nickname cleared")
+		} else {
+			m.savePrefs(fmt.Sprintf("Nickname set to %s", m.prefs.Nickname))
+		}
+	case tea.KeyEsc:
+		m.editing = false
+	case tea.KeyBackspace:
+		if _, size := utf8.DecodeLastRuneInString(m.input); size > 0 {
+			m.input = m.input[:len(m.input)-size]
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		for _, r := range msg.Runes {
+			if unicode.IsPrint(r) && utf8.RuneCountInString(m.input) < config.MaxNicknameLen {
+				m.input += string(r)
+			}
+		}
+	}
+	return m
+}
+
+// nextDifficulty switches the default difficulty to the next preset, after
+// the last one back to each game's own config.
+func (m *SettingsModel) nextDifficulty() {
+	next := config.DifficultyPresets[0]
+	for i, d := range config.DifficultyPresets {
+		if string(d) == m.prefs.Difficulty {
+			next = ""
+			if i+1 < len(config.DifficultyPresets) {
+				next = config.DifficultyPresets[i+1]
+			}
+		}
+	}
+	m.prefs.Difficulty = string(next)
+	m.savePrefs(fmt.Sprintf("Difficulty set to %s", difficultyLabel(m.prefs.Difficulty)))
+}
+
+// difficultyLabel describes a default difficulty.
+func difficultyLabel(d string) string {
+	if d == "" {
+		return "game default"
+	}
+	return d
+}
+
+// nextChoice returns the choice step places after current, wrapping around.
+// A current value between choices counts as the choice below it.
+func nextChoice(choices []int, current, step int) int {
+	i := 0
+	for j, c := range choices {
+		if c <= current {
+			i = j
+		}
+	}
+	return choices[(i+step%len(choices)+len(choices))%len(choices)]
+}
+
+// savePrefs saves the settings and shows done, or why saving failed.
+func (m *SettingsModel) savePrefs(done string) {
+	if m.remote && m.profile == "" {
+		m.message = done + " for this session (connect with an SSH key to save it)"
+		return
+	}
+	if m.remote && m.store == nil {
+		m.message = done + " (not saved: no database)"
+		return
+	}
+	if err := saveSettings(m.store, m.profile, m.remote, m.prefs); err != nil {
+		m.message = fmt.Sprintf("%s, but saving failed: %v", done, err)
+		return
+	}
+	m.message = done
+}
+
+// openControls opens the controls screen, on a game's page if gameID is set.
+func (m *SettingsModel) openControls(gameID string) {
+	controls := newControlsModel(m.keys, m.saveKeys, m.keysLocation())
+	controls.showGame(gameID)
+	m.controls = &controls
+}
+
+// handleControlsKey passes a key to the open controls screen.
+func (m SettingsModel) handleControlsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	controls := m.controls.handleKey(msg)
+	m.keys = controls.bindings
+	m.keyMapper = m.keys.Mapper("")
+	switch {
+	case controls.quitting:
+		m.quitting = true
+		return m, tea.Quit
+	case controls.done:
+		m.controls = nil
+		m.message = ""
+	default:
+		m.controls = &controls
+	}
+	return m, nil
+}
+
+// saveKeys saves the user's key bindings: locally to ~/.arcade/keys.yaml,
+// on the SSH server to the player's profile, while keyless players' last the
+// session. Only bindings that differ from
+// the defaults are saved, so later changes to the defaults still apply.
+func (m SettingsModel) saveKeys(b *KeyBindings) error {
+	if !m.remote {
+		SetKeyBindings(b)
+		return config.SaveUserKeys(b.diff(DefaultKeyBindings()))
+	}
+
+	if m.profile == "" {
+		return nil // Keyless players keep their bindings for the session
+	}
+	if m.store == nil {
+		return errors.New("no database")
+	}
+	data, err := b.diff(currentKeyBindings()).Marshal()
+	if err != nil {
+		return err
+	}
+	return m.store.SetUserSetting(m.profile, keysSettingKey, string(data))
+}
+
+// keysLocation describes where saveKeys saves key bindings.
+func (m SettingsModel) keysLocation() string {
+	switch {
+	case m.remote && m.profile == "":
+		return "this session only (connect with an SSH key to keep them)"
+	case m.remote:
+		return "your profile on this server"
+	}
+	return config.UserKeysPath()
+}
+
+// selectTheme applies a theme and saves it for the user.
+func (m *SettingsModel) selectTheme(theme *core.Theme) {
+	m.renderer.SetTheme(theme)
+	m.prefs.Theme = theme.Name
+	m.savePrefs(fmt.Sprintf("Theme set to %s", theme.Name))
+}
+
+// nextScreenshotFormat switches to the next screenshot format and saves it
+// for the user.
+func (m *SettingsModel) nextScreenshotFormat() {
+	if screenshotFormatOverride != "" {
+		m.message = fmt.Sprintf("Screenshot format is set to %s by --screenshot-format", screenshotFormatOverride)
+		return
+	}
+	if m.remote && m.profile == "" {
+		m.message = "Screenshots need an SSH key"
+		return
+	}
+
+	formats := screenshot.Formats()
+	next := formats[0]
+	for i, f := range formats {
+		if f == m.screenshotFormat() {
+			next = formats[(i+1)%len(formats)]
+		}
+	}
+	m.prefs.Screenshot = string(next)
+	m.savePrefs(fmt.Sprintf("Screenshot format set to %s", next))
+}
+
+// screenshotFormat returns the screenshot format picked in the settings.
+func (m SettingsModel) screenshotFormat() screenshot.Format {
+	f, _ := screenshot.ParseFormat(m.prefs.Screenshot) // Validated when loaded
+	return f
+}
+
+// previewTheme returns the theme under the cursor, or the current theme when
+// the cursor is on another row.
+func (m SettingsModel) previewTheme() *core.Theme {
+	if m.cursor < len(m.themes) {
+		return m.themes[m.cursor]
+	}
+	return m.renderer.Theme()
+}
+
+// View renders the settings screen.
+func (m SettingsModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	if m.controls != nil {
+		return m.controls.View(m.width)
+	}
+
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(centerText("SETTINGS", m.width))
+	b.WriteString("\n\n")
+	b.WriteString(centerText("Color theme", m.width))
+	b.WriteString("\n\n")
+
+	current := m.renderer.Theme().Name
+	for i, theme := range m.themes {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		mark := " "
+		if theme.Name == current {
+			mark = "*"
+		}
+		line := fmt.Sprintf("%s%s %-16s %-44s", cursor, mark, theme.Name, theme.Description)
+		b.WriteString(centerText(line, m.width))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderer.WithTheme(m.previewTheme()).Render(m.preview()))
+	b.WriteString("\n\n")
+
+	for i, row := range m.rows() {
+		cursor := "  "
+		if m.cursor == len(m.themes)+i {
+			cursor = "> "
+		}
+		name, value := m.rowText(row)
+		line := fmt.Sprintf("%s  %-16s %-44s", cursor, name, value)
+		b.WriteString(centerText(line, m.width))
+		b.WriteString("\n")
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		b.WriteString(centerText(m.message, m.width))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(centerText(fmt.Sprintf("Themes folder: %s", config.UserThemesDir()), m.width))
+	b.WriteString("\n")
+	if !m.remote {
+		b.WriteString(centerText(fmt.Sprintf("Settings file: %s (command-line flags override it)", config.UserSettingsPath()), m.width))
+		b.WriteString("\n")
+	}
+	help := "Up/Down: Navigate  |  Enter: Select  |  Esc: Back  |  Q: Quit"
+	if m.editing {
+		help = "Type a nickname  |  Enter: Save  |  Esc: Cancel"
+	}
+	b.WriteString(centerText(help, m.width))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// rowText returns the name and current value of a row.
+func (m SettingsModel) rowText(row settingsRow) (name, value string) {
+	switch row {
+	case settingsRowNickname:
+		if m.editing {
+			return "Nickname", m.input + "_"
+		}
+		if m.prefs.Nickname == "" {
+			return "Nickname", "(none) - shown to other players"
+		}
+		return "Nickname", m.prefs.Nickname
+	case settingsRowDifficulty:
+		return "Difficulty", difficultyLabel(m.prefs.Difficulty) + ", for new games"
+	case settingsRowTickRate:
+		return "Tick rate", fmt.Sprintf("%d per second, for new games", cmp.Or(m.prefs.FPS, defaultTickRate))
+	case settingsRowRenderRate:
+		return "Frame rate", fmt.Sprintf("%d fps at most, for new games", cmp.Or(m.prefs.RenderFPS, DefaultRenderFPS))
+	case settingsRowScreenshots:
+		f := m.screenshotFormat()
+		if screenshotFormatOverride != "" {
+			f = screenshotFormatOverride
+		}
+		return "Screenshots", fmt.Sprintf("%s (%s), Ctrl+S in games", f, f.Extension())
+	case settingsRowAttract:
+		return "Attract mode", attractLabel(m.prefs.Attract)
+	case settingsRowControls:
+		return "Controls", "Rebind keys for menus and games"
+	}
+	return "", ""
+}
+
+// attractLabel describes when the menu's demos start.
+func attractLabel(seconds int) string {
+	if d := attractDelay(seconds); d > 0 {
+		return fmt.Sprintf("demos after %d seconds idle in the menu", int(d.Seconds()))
+	}
+	return "off"
+}
+
+// preview draws samples of every color role, centered on a screen as wide as the terminal.
+func (m SettingsModel) preview() *core.Screen {
+	width := max(m.width, 1)
+	screen := core.NewScreen(width, len(themePreviewRows)+1)
+
+	for y, row := range themePreviewRows {
+		labels := make([]string, len(row))
+		for i, sample := range row {
+			labels[i] = sample.label
+		}
+		x := (width - core.StringWidth(strings.Join(labels, "   "))) / 2
+		for _, sample := range row {
+			screen.DrawTextWithColor(x, y, sample.label, sample.color)
+			x += core.StringWidth(sample.label) + 3
+		}
+	}
+
+	// Tier swatches, labelled with their rank like 2048 tiles
+	const swatchW = 4
+	y := len(themePreviewRows)
+	x := (width - swatchW*len(themePreviewTiers)) / 2
+	for i, tier := range themePreviewTiers {
+		screen.FillRect(core.Rect{X: x, Y: y, W: swatchW, H: 1}, ' ', core.ColorDefault, tier)
+		screen.DrawTextStyled(x+1, y, fmt.Sprint(i+1), core.RoleTierText, tier, core.AttrBold)
+		x += swatchW
+	}
+	return screen
+}
+
+// WantsBack returns true if the user wants to go back to the menu.
+func (m SettingsModel) WantsBack() bool {
+	return m.back
+}
+
+// IsQuitting returns true if the user wants to quit entirely.
+func (m SettingsModel) IsQuitting() bool {
+	return m.quitting
+}
+
+// RunSettings runs the settings screen for the local terminal.
+func RunSettings(store *storage.Store, width, height int) (goBack bool, err error) {
+	model := NewSettingsModel(store, LocalUsername(), nil, width, height)
+
+	p := tea.NewProgram(
+		model,
+		tea.WithAltScreen(),
+	)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return false, err
+	}
+
+	m, ok := finalModel.(SettingsModel)
+	if !ok {
+		return false, nil
+	}
+
+	return m.WantsBack(), nil
+}
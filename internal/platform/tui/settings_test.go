@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/config"
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/screenshot"
+)
+
+// openSettings opens the settings screen of an SSH session, as the menu does.
+func openSettings(m SessionModel) SessionModel {
+	m.state = SessionStateSettings
+	m.settings = m.newSettings()
+	return m
+}
+
+// selectRow moves the settings cursor to row and presses Enter.
+func selectRow(t *testing.T, m SessionModel, row settingsRow) SessionModel {
+	t.Helper()
+	i := slices.Index(m.settings.rows(), row)
+	if i < 0 {
+		t.Fatalf("settings row %d is not shown", row)
+	}
+	m.settings.cursor = len(m.settings.themes) + i
+	return pressKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+// pressKeys sends keys to the session's settings screen.
+func pressKeys(m SessionModel, keys ...tea.KeyMsg) SessionModel {
+	for _, key := range keys {
+		model, _ := m.updateSettings(key)
+		m = model.(SessionModel)
+	}
+	return m
+}
+
+func TestKeylessSettingsLastTheSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewSessionModel(nil, core.RuntimeConfig{ScreenW: 80, ScreenH: 24}, "guest", "keyless", nil, nil)
+	m.renderer = benchScreenRenderer()
+	m.maxFPS = DefaultRenderFPS
+	m.menu = m.newMenu()
+
+	m = openSettings(m)
+	m = selectRow(t, m, settingsRowNickname)
+	m = pressKeys(m, runes("neo"), tea.KeyMsg{Type: tea.KeyEnter})
+	m = selectRow(t, m, settingsRowAttract)
+	attract := m.settings.prefs.Attract
+	m = selectRow(t, m, settingsRowRenderRate)
+	fps := m.settings.prefs.RenderFPS
+	m.settings.selectTheme(m.settings.themes[len(m.settings.themes)-1])
+	theme := m.renderer.Theme().Name
+	m = pressKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.state != SessionStateMenu {
+		t.Fatalf("state %v after Esc, want the menu", m.state)
+	}
+	if m.prefs.Nickname != "neo" || m.prefs.Attract != attract || m.prefs.RenderFPS != fps {
+		t.Fatalf("session settings after the first visit: %+v", m.prefs)
+	}
+
+	// Opening Settings again starts from the session's settings, and leaving
+	// keeps them
+	m = openSettings(m)
+	if got := m.settings.prefs; got.Nickname != "neo" || got.Attract != attract || got.RenderFPS != fps || got.Theme != theme {
+		t.Errorf("settings screen reopened with %+v", got)
+	}
+	m = pressKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.prefs.Nickname != "neo" || m.prefs.Attract != attract || m.prefs.RenderFPS != fps {
+		t.Errorf("session settings after the second visit: %+v", m.prefs)
+	}
+	if m.menu.nickname != "neo" || m.renderer.Theme().Name != theme {
+		t.Errorf("menu nickname %q, theme %q", m.menu.nickname, m.renderer.Theme().Name)
+	}
+}
+
+func TestScreenshotFormatSavedWithSettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// No database: the format still goes to settings.yaml
+	m := NewSettingsModel(nil, LocalUsername(), nil, 80, 24)
+	m.cursor = len(m.themes) + slices.Index(m.rows(), settingsRowScreenshots)
+	model, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(SettingsModel)
+
+	want := m.screenshotFormat()
+	if want == screenshot.DefaultFormat {
+		t.Fatalf("format still %s after switching", want)
+	}
+	saved, err := config.LoadUserSettings()
+	if err != nil || saved.Screenshot != string(want) {
+		t.Errorf("settings.yaml screenshot = %q, %v; want %q", saved.Screenshot, err, want)
+	}
+	if got := LoadScreenshotFormat(nil, "", false); got != want {
+		t.Errorf("LoadScreenshotFormat = %s, want %s", got, want)
+	}
+
+	// SSH players keep theirs in their profile
+	store := testStore(t)
+	if err := saveSettings(store, "SHA256:alice", true, config.Settings{Screenshot: "svg"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadScreenshotFormat(store, "SHA256:alice", true); got != screenshot.FormatSVG {
+		t.Errorf("profile format = %s, want svg", got)
+	}
+	if got := LoadScreenshotFormat(store, "SHA256:bob", true); got != screenshot.DefaultFormat {
+		t.Errorf("another profile's format = %s, want the default", got)
+	}
+}
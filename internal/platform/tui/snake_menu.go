@@ -59,6 +59,8 @@ func (m SnakeModeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -76,6 +78,16 @@ func (m SnakeModeModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m.handleModeSelectKey(action)
 }
 
+func (m SnakeModeModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	var action MenuAction
+	if m.inLevelSelect {
+		m.levelCursor, action = menuMouse(msg, menuShortListRow, len(snake.LevelNames()), m.levelCursor)
+		return m.handleLevelSelectKey(action)
+	}
+	m.cursor, action = menuMouse(msg, menuListRow, 3, m.cursor) // Campaign, Endless, Select Level
+	return m.handleModeSelectKey(action)
+}
+
 func (m SnakeModeModel) handleModeSelectKey(action MenuAction) (tea.Model, tea.Cmd) {
 	switch action {
 	case MenuActionQuit:
@@ -237,6 +249,7 @@ func RunSnakeModeSelector(cfg core.RuntimeConfig) (*SnakeSelection, core.Runtime
 	p := tea.NewProgram(
 		model,
 		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
 	)
 
 	finalModel, err := p.Run()
@@ -0,0 +1,346 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// rateWindow is the window over which new connections per IP are counted.
+const rateWindow = time.Minute
+
+// Errors returned when a connection is refused.
+var (
+	errServerFull    = errors.New("server is full, try again later")
+	errTooManyFromIP = errors.New("too many sessions from your address")
+	errRateLimited   = errors.New("too many connection attempts, slow down")
+)
+
+// bannedKeyOffered is the context key marking a connection that offered a
+// banned key, which may not fall back to keyboard-interactive auth.
+type bannedKeyOffered struct{}
+
+// banList is a persistent list of banned key fingerprints and IP addresses.
+// Each line of the file holds one entry: a SHA256 key fingerprint, an IP address
+// or a CIDR range, optionally followed by a "# comment".
+type banList struct {
+	path string
+
+	mu   sync.RWMutex
+	keys map[string]bool // SHA256 fingerprints
+	ips  map[string]bool
+	nets []*net.IPNet
+}
+
+// loadBanList reads the ban list at path. A missing file is an empty list.
+func loadBanList(path string) (*banList, error) {
+	b := &banList{
+		path: path,
+		keys: make(map[string]bool),
+		ips:  make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from server configuration
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ban list: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		entry, _, _ := strings.Cut(scanner.Text(), "#")
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if err := b.add(entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read ban list: %w", err)
+	}
+
+	return b, nil
+}
+
+// add adds an entry to the in-memory list. Must be called with the lock held or before the list is shared.
+func (b *banList) add(entry string) error {
+	switch {
+	case strings.HasPrefix(entry, "SHA256:"):
+		b.keys[entry] = true
+	case strings.Contains(entry, "/"):
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return fmt.Errorf("invalid CIDR %q", entry)
+		}
+		b.nets = append(b.nets, ipNet)
+	default:
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return fmt.Errorf("invalid ban entry %q", entry)
+		}
+		b.ips[ip.String()] = true
+	}
+	return nil
+}
+
+// Ban adds entries to the list and appends them to the ban file.
+// comment is written next to each entry to record who was banned and why.
+func (b *banList) Ban(comment string, entries ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines strings.Builder
+	for _, entry := range entries {
+		if err := b.add(entry); err != nil {
+			return err
+		}
+		lines.WriteString(entry)
+		if comment != "" {
+			lines.WriteString(" # ")
+			lines.WriteString(strings.ReplaceAll(comment, "\n", " "))
+		}
+		lines.WriteString("\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("cannot create ban list directory: %w", err)
+	}
+	f, err := os.OpenFile(b.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot open ban list: %w", err)
+	}
+	if _, err := f.WriteString(lines.String()); err != nil {
+		f.Close()
+		return fmt.Errorf("cannot write ban list: %w", err)
+	}
+	return f.Close()
+}
+
+// IsKeyBanned reports whether the key's fingerprint is banned.
+func (b *banList) IsKeyBanned(key ssh.PublicKey) bool {
+	if key == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.keys[gossh.FingerprintSHA256(key)]
+}
+
+// IsIPBanned reports whether the IP is banned directly or through a CIDR range.
+func (b *banList) IsIPBanned(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.ips[ip.String()] {
+		return true
+	}
+	for _, n := range b.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// accessControl enforces concurrent session caps and per-IP connection rates.
+// A limit of zero means unlimited.
+type accessControl struct {
+	maxSessions      int
+	maxSessionsPerIP int
+	ratePerMinute    int
+
+	mu       sync.Mutex
+	active   int
+	activeIP map[string]int
+	attempts map[string][]time.Time // Recent connection attempts per IP
+	pruned   time.Time              // When idle IPs were last forgotten
+}
+
+// newAccessControl creates the limiter from the server config.
+func newAccessControl(cfg SSHServerConfig) *accessControl {
+	return &accessControl{
+		maxSessions:      cfg.MaxSessions,
+		maxSessionsPerIP: cfg.MaxSessionsPerIP,
+		ratePerMinute:    cfg.ConnectionsPerMinute,
+		activeIP:         make(map[string]int),
+		attempts:         make(map[string][]time.Time),
+	}
+}
+
+// allowConnection records a connection attempt and reports whether the IP is within its rate.
+func (a *accessControl) allowConnection(ip string, now time.Time) error {
+	if a.ratePerMinute <= 0 {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// A scan of the whole map once per window keeps accepts cheap however
+	// many addresses connect
+	if now.Sub(a.pruned) >= rateWindow {
+		a.pruneLocked(now)
+		a.pruned = now
+	}
+
+	// Drop attempts that have left the window
+	recent := a.attempts[ip]
+	cutoff := now.Add(-rateWindow)
+	keep := 0
+	for _, t := range recent {
+		if t.After(cutoff) {
+			recent[keep] = t
+			keep++
+		}
+	}
+	recent = recent[:keep]
+
+	if len(recent) >= a.ratePerMinute {
+		a.attempts[ip] = recent
+		return errRateLimited
+	}
+	a.attempts[ip] = append(recent, now)
+	return nil
+}
+
+// acquire reserves a session slot for the IP. Call release when the session ends.
+func (a *accessControl) acquire(ip string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.maxSessions > 0 && a.active >= a.maxSessions {
+		return errServerFull
+	}
+	if a.maxSessionsPerIP > 0 && a.activeIP[ip] >= a.maxSessionsPerIP {
+		return errTooManyFromIP
+	}
+	a.active++
+	a.activeIP[ip]++
+	return nil
+}
+
+// release frees a session slot reserved by acquire.
+func (a *accessControl) release(ip string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.active--
+	if a.activeIP[ip]--; a.activeIP[ip] <= 0 {
+		delete(a.activeIP, ip)
+	}
+}
+
+// pruneLocked forgets IPs with no recent attempts so the map doesn't grow forever.
+// Must be called with the lock held.
+func (a *accessControl) pruneLocked(now time.Time) {
+	cutoff := now.Add(-rateWindow)
+	for ip, recent := range a.attempts {
+		if len(recent) == 0 || !recent[len(recent)-1].After(cutoff) {
+			delete(a.attempts, ip)
+		}
+	}
+}
+
+// remoteIP extracts the IP address from a network address.
+func remoteIP(addr net.Addr) net.IP {
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
+
+// keyFingerprint returns the SHA256 fingerprint of a public key, or "" for
+// keyless clients. Unlike the SSH username, which clients pick freely, it
+// tells players apart.
+func keyFingerprint(key ssh.PublicKey) string {
+	if key == nil {
+		return ""
+	}
+	return gossh.FingerprintSHA256(key)
+}
+
+// handleConn screens new TCP connections for IP bans and connection rate.
+// Returning nil closes the connection before the SSH handshake.
+func (s *SSHServer) handleConn(_ ssh.Context, conn net.Conn) net.Conn {
+	ip := remoteIP(conn.RemoteAddr())
+
+	if s.bans.IsIPBanned(ip) {
+		s.logger.Warn("rejected banned address", "remote", conn.RemoteAddr().String())
+		return nil
+	}
+
+	if err := s.access.allowConnection(ip.String(), time.Now()); err != nil {
+		s.logger.Warn("rejected connection", "remote", conn.RemoteAddr().String(), "reason", err)
+		return nil
+	}
+
+	return conn
+}
+
+// authPublicKey accepts keys from the allow-list (if configured) or any key otherwise.
+// Admin keys are always accepted; banned keys never are.
+func (s *SSHServer) authPublicKey(ctx ssh.Context, key ssh.PublicKey) bool {
+	if s.bans.IsKeyBanned(key) {
+		ctx.SetValue(bannedKeyOffered{}, true)
+		return false
+	}
+	if s.config.AuthorizedKeysPath == "" {
+		return true
+	}
+	return containsKey(s.allowedKeys, key) || containsKey(s.adminKeys, key)
+}
+
+// authKeyboardInteractive lets clients without keys in unless an allow-list is configured.
+// Clients that offered a banned key are refused, or they could drop the key and get in.
+func (s *SSHServer) authKeyboardInteractive(ctx ssh.Context, _ gossh.KeyboardInteractiveChallenge) bool {
+	if banned, _ := ctx.Value(bannedKeyOffered{}).(bool); banned {
+		return false
+	}
+	return s.config.AuthorizedKeysPath == ""
+}
+
+// accessMiddleware enforces session caps and key bans for authenticated sessions.
+func (s *SSHServer) accessMiddleware(next ssh.Handler) ssh.Handler {
+	return func(sshSession ssh.Session) {
+		if s.bans.IsKeyBanned(sshSession.PublicKey()) {
+			s.logger.Warn("rejected banned key", "user", sshSession.User())
+			wish.Fatalln(sshSession, "You are banned from this server.")
+			return
+		}
+
+		ip := remoteIP(sshSession.RemoteAddr()).String()
+		if err := s.access.acquire(ip); err != nil {
+			s.logger.Warn("rejected session",
+				"user", sshSession.User(),
+				"remote", sshSession.RemoteAddr().String(),
+				"reason", err,
+			)
+			wish.Fatalln(sshSession, "Sorry, "+err.Error()+".")
+			return
+		}
+		defer s.access.release(ip)
+
+		next(sshSession)
+	}
+}
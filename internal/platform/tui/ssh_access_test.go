@@ -0,0 +1,296 @@
+package tui
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// authContext is the part of a connection's context the auth handlers use.
+type authContext struct {
+	ssh.Context
+	values map[any]any
+}
+
+func newAuthContext() *authContext {
+	return &authContext{values: make(map[any]any)}
+}
+
+func (c *authContext) SetValue(key, value any) { c.values[key] = value }
+func (c *authContext) Value(key any) any       { return c.values[key] }
+
+// testKey returns a fresh ed25519 public key.
+func testKey(t *testing.T) gossh.PublicKey {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := gossh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestBanListMatching(t *testing.T) {
+	banned, other := testKey(t), testKey(t)
+	path := filepath.Join(t.TempDir(), "bans.txt")
+	data := "# Banned players\n" +
+		gossh.FingerprintSHA256(banned) + " # spammer\n" +
+		"\n" +
+		"192.0.2.7\n" +
+		"198.51.100.0/24 # whole range\n" +
+		"2001:db8::/32\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	bans, err := loadBanList(path)
+	if err != nil {
+		t.Fatalf("loadBanList: %v", err)
+	}
+
+	if !bans.IsKeyBanned(banned) {
+		t.Error("banned key is not banned")
+	}
+	if bans.IsKeyBanned(other) {
+		t.Error("other key is banned")
+	}
+	if bans.IsKeyBanned(nil) {
+		t.Error("keyless client is banned by key")
+	}
+
+	ips := []struct {
+		ip   string
+		want bool
+	}{
+		{"192.0.2.7", true},
+		{"192.0.2.8", false},
+		{"198.51.100.1", true},
+		{"198.51.100.255", true},
+		{"198.51.101.1", false},
+		{"2001:db8::1", true},
+		{"2001:db9::1", false},
+	}
+	for _, tt := range ips {
+		if got := bans.IsIPBanned(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("IsIPBanned(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+	if bans.IsIPBanned(nil) {
+		t.Error("nil IP is banned")
+	}
+}
+
+func TestBanListMissingFile(t *testing.T) {
+	bans, err := loadBanList(filepath.Join(t.TempDir(), "missing.txt"))
+	if err != nil {
+		t.Fatalf("missing file should be an empty list: %v", err)
+	}
+	if bans.IsIPBanned(net.ParseIP("192.0.2.7")) {
+		t.Error("empty list bans an IP")
+	}
+}
+
+func TestBanListInvalidEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.txt")
+	if err := os.WriteFile(path, []byte("192.0.2.7\nnot-an-ip\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadBanList(path)
+	if err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("expected an error on line 2, got %v", err)
+	}
+}
+
+func TestBanAppendsToFile(t *testing.T) {
+	key := testKey(t)
+	path := filepath.Join(t.TempDir(), "nested", "bans.txt")
+	bans, err := loadBanList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bans.Ban("first\nban", gossh.FingerprintSHA256(key), "192.0.2.7"); err != nil {
+		t.Fatalf("Ban: %v", err)
+	}
+	if err := bans.Ban("", "198.51.100.0/24"); err != nil {
+		t.Fatalf("Ban: %v", err)
+	}
+	if err := bans.Ban("bad", "not-an-ip"); err == nil {
+		t.Error("Ban accepted an invalid entry")
+	}
+
+	// The bans apply at once
+	if !bans.IsKeyBanned(key) || !bans.IsIPBanned(net.ParseIP("192.0.2.7")) || !bans.IsIPBanned(net.ParseIP("198.51.100.9")) {
+		t.Error("new bans are not applied")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := gossh.FingerprintSHA256(key) + " # first ban\n" +
+		"192.0.2.7 # first ban\n" +
+		"198.51.100.0/24\n"
+	if string(data) != want {
+		t.Errorf("ban file:\n%s\nwant:\n%s", data, want)
+	}
+
+	// And survive a restart
+	reloaded, err := loadBanList(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !reloaded.IsKeyBanned(key) || !reloaded.IsIPBanned(net.ParseIP("198.51.100.9")) {
+		t.Error("bans lost on reload")
+	}
+}
+
+func TestBannedKeyCannotFallBackToKeyboardInteractive(t *testing.T) {
+	banned, other := testKey(t), testKey(t)
+	bans, err := loadBanList(filepath.Join(t.TempDir(), "bans.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bans.Ban("", gossh.FingerprintSHA256(banned)); err != nil {
+		t.Fatal(err)
+	}
+	s := &SSHServer{bans: bans}
+
+	ctx := newAuthContext()
+	if s.authPublicKey(ctx, banned) {
+		t.Fatal("banned key accepted")
+	}
+	if s.authKeyboardInteractive(ctx, nil) {
+		t.Error("keyless login allowed after offering a banned key")
+	}
+
+	ctx = newAuthContext()
+	if !s.authPublicKey(ctx, other) {
+		t.Error("other key refused")
+	}
+	if !s.authKeyboardInteractive(newAuthContext(), nil) {
+		t.Error("keyless login refused without an allow-list")
+	}
+}
+
+func TestAccessControlMaxSessions(t *testing.T) {
+	a := newAccessControl(SSHServerConfig{MaxSessions: 2})
+
+	if err := a.acquire("192.0.2.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.acquire("192.0.2.2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.acquire("192.0.2.3"); !errors.Is(err, errServerFull) {
+		t.Errorf("third session: got %v, want %v", err, errServerFull)
+	}
+
+	a.release("192.0.2.1")
+	if err := a.acquire("192.0.2.3"); err != nil {
+		t.Errorf("session after release: %v", err)
+	}
+}
+
+func TestAccessControlPerIP(t *testing.T) {
+	a := newAccessControl(SSHServerConfig{MaxSessionsPerIP: 2})
+
+	for range 2 {
+		if err := a.acquire("192.0.2.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.acquire("192.0.2.1"); !errors.Is(err, errTooManyFromIP) {
+		t.Errorf("third session from one IP: got %v, want %v", err, errTooManyFromIP)
+	}
+	if err := a.acquire("192.0.2.2"); err != nil {
+		t.Errorf("session from another IP: %v", err)
+	}
+
+	a.release("192.0.2.1")
+	if err := a.acquire("192.0.2.1"); err != nil {
+		t.Errorf("session after release: %v", err)
+	}
+
+	a.release("192.0.2.2")
+	if _, ok := a.activeIP["192.0.2.2"]; ok {
+		t.Error("released IP is still tracked")
+	}
+}
+
+func TestAccessControlRate(t *testing.T) {
+	a := newAccessControl(SSHServerConfig{ConnectionsPerMinute: 3})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		if err := a.allowConnection("192.0.2.1", start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+	}
+	if err := a.allowConnection("192.0.2.1", start.Add(10*time.Second)); !errors.Is(err, errRateLimited) {
+		t.Errorf("fourth attempt: got %v, want %v", err, errRateLimited)
+	}
+	if err := a.allowConnection("192.0.2.2", start.Add(10*time.Second)); err != nil {
+		t.Errorf("another IP: %v", err)
+	}
+
+	// The first attempt leaves the window after a minute
+	if err := a.allowConnection("192.0.2.1", start.Add(time.Minute+time.Second/2)); err != nil {
+		t.Errorf("attempt after the window: %v", err)
+	}
+	if err := a.allowConnection("192.0.2.1", start.Add(time.Minute+time.Second/2)); !errors.Is(err, errRateLimited) {
+		t.Errorf("second attempt after the window: got %v, want %v", err, errRateLimited)
+	}
+}
+
+func TestAccessControlUnlimited(t *testing.T) {
+	a := newAccessControl(SSHServerConfig{})
+	now := time.Now()
+	for range 100 {
+		if err := a.allowConnection("192.0.2.1", now); err != nil {
+			t.Fatal(err)
+		}
+		if err := a.acquire("192.0.2.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAccessControlForgetsIdleIPs(t *testing.T) {
+	a := newAccessControl(SSHServerConfig{ConnectionsPerMinute: 10})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 100 {
+		if err := a.allowConnection(fmt.Sprintf("192.0.2.%d", i), start.Add(time.Duration(i)*time.Millisecond)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(a.attempts) != 100 {
+		t.Fatalf("tracking %d IPs, want 100", len(a.attempts))
+	}
+
+	// Within the window nothing is scanned or forgotten
+	a.allowConnection("198.51.100.1", start.Add(rateWindow/2)) //nolint:errcheck
+	if len(a.attempts) != 101 {
+		t.Errorf("tracking %d IPs mid-window, want 101", len(a.attempts))
+	}
+
+	// After it the idle IPs go
+	a.allowConnection("198.51.100.2", start.Add(rateWindow+time.Second)) //nolint:errcheck
+	if len(a.attempts) != 2 {
+		t.Errorf("tracking %d IPs after the window, want 2", len(a.attempts))
+	}
+}
@@ -0,0 +1,274 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
+)
+
+// kickGracePeriod is how long a kicked session gets to show the notice before its connection is closed.
+const kickGracePeriod = 2 * time.Second
+
+// connection describes a live SSH connection for the admin console.
+type connection struct {
+	user        string
+	remote      string
+	connectedAt time.Time
+	key         ssh.PublicKey // Nil for keyless clients
+	session     ssh.Session
+}
+
+// AdminSession is a connected session as listed in the admin console.
+type AdminSession struct {
+	ID          multiplayer.SessionID
+	User        string
+	Remote      string
+	ConnectedAt time.Time
+	MatchID     multiplayer.MatchID // Empty if not in an online match
+}
+
+// adminBackend is the set of server operations available to the admin console.
+type adminBackend interface {
+	Sessions() []AdminSession
+	Matches() []multiplayer.MatchInfo
+	KickSession(id multiplayer.SessionID) error
+	BanSession(id multiplayer.SessionID) error
+	EndMatch(id multiplayer.MatchID) error
+	Broadcast(message string) error
+	ClearScores(gameID string) error
+	RecentActions(limit int) ([]storage.AdminAction, error)
+}
+
+// loadAuthorizedKeys reads public keys from a file in OpenSSH authorized_keys format.
+// Blank lines and comments are skipped.
+func loadAuthorizedKeys(path string) ([]ssh.PublicKey, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from server configuration
+	if err != nil {
+		return nil, fmt.Errorf("cannot read keys file: %w", err)
+	}
+
+	var keys []ssh.PublicKey
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		key, _, _, _, parseErr := ssh.ParseAuthorizedKey(line)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, parseErr)
+		}
+		keys = append(keys, key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read keys file: %w", err)
+	}
+
+	return keys, nil
+}
+
+// containsKey reports whether key matches any of the given keys.
+func containsKey(keys []ssh.PublicKey, key ssh.PublicKey) bool {
+	if key == nil {
+		return false
+	}
+	for _, k := range keys {
+		if ssh.KeysEqual(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdmin reports whether the session authenticated with one of the admin keys.
+func (s *SSHServer) isAdmin(sshSession ssh.Session) bool {
+	return containsKey(s.adminKeys, sshSession.PublicKey())
+}
+
+// trackConnection records a live connection for the admin console.
+func (s *SSHServer) trackConnection(id multiplayer.SessionID, sshSession ssh.Session) {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	s.connections[id] = &connection{
+		user:        sshSession.User(),
+		remote:      sshSession.RemoteAddr().String(),
+		connectedAt: time.Now(),
+		key:         sshSession.PublicKey(),
+		session:     sshSession,
+	}
+}
+
+// untrackConnection forgets a connection once it has closed.
+func (s *SSHServer) untrackConnection(id multiplayer.SessionID) {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	delete(s.connections, id)
+}
+
+// serverAdmin implements adminBackend for one admin's session.
+// Every action is written to the audit log under the admin's key fingerprint,
+// since the username is whatever the client sent.
+type serverAdmin struct {
+	server    *SSHServer
+	admin     string
+	key       string                // SHA256 fingerprint of the admin's key
+	sessionID multiplayer.SessionID // The admin's own session, which can't be kicked
+}
+
+// Sessions lists all connected sessions, oldest first.
+func (a *serverAdmin) Sessions() []AdminSession {
+	inMatch := make(map[multiplayer.SessionID]multiplayer.MatchID)
+	for _, m := range a.server.coordinator.Matches() {
+		inMatch[m.Player1] = m.ID
+		inMatch[m.Player2] = m.ID
+	}
+
+	a.server.connMu.Lock()
+	sessions := make([]AdminSession, 0, len(a.server.connections))
+	for id, c := range a.server.connections {
+		sessions = append(sessions, AdminSession{
+			ID:          id,
+			User:        c.user,
+			Remote:      c.remote,
+			ConnectedAt: c.connectedAt,
+			MatchID:     inMatch[id],
+		})
+	}
+	a.server.connMu.Unlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ConnectedAt.Before(sessions[j].ConnectedAt)
+	})
+	return sessions
+}
+
+// Matches lists all active online matches.
+func (a *serverAdmin) Matches() []multiplayer.MatchInfo {
+	return a.server.coordinator.Matches()
+}
+
+// KickSession notifies a session that it was kicked and closes its connection shortly after.
+func (a *serverAdmin) KickSession(id multiplayer.SessionID) error {
+	conn, err := a.connection(id)
+	if err != nil {
+		return err
+	}
+
+	a.disconnect(id, conn, "Kicked by an administrator")
+	return a.audit("kick", string(id), conn.user+"@"+conn.remote)
+}
+
+// BanSession bans a session's key and IP and disconnects it. The IP is banned
+// too because, without an allow-list, a client can drop its key and connect
+// keyless.
+func (a *serverAdmin) BanSession(id multiplayer.SessionID) error {
+	conn, err := a.connection(id)
+	if err != nil {
+		return err
+	}
+	if containsKey(a.server.adminKeys, conn.key) {
+		return errors.New("cannot ban an admin")
+	}
+
+	entries := []string{remoteIP(conn.session.RemoteAddr()).String()}
+	if conn.key != nil {
+		entries = append([]string{gossh.FingerprintSHA256(conn.key)}, entries...)
+	}
+	comment := fmt.Sprintf("%s@%s banned by %s on %s",
+		conn.user, conn.remote, a.admin, time.Now().Format(time.DateOnly))
+	if err := a.server.bans.Ban(comment, entries...); err != nil {
+		return err
+	}
+
+	a.disconnect(id, conn, "Banned by an administrator")
+	return a.audit("ban", strings.Join(entries, " "), conn.user+"@"+conn.remote)
+}
+
+// connection looks up a live connection other than the admin's own.
+func (a *serverAdmin) connection(id multiplayer.SessionID) (*connection, error) {
+	if id == a.sessionID {
+		return nil, errors.New("cannot act on your own session")
+	}
+
+	a.server.connMu.Lock()
+	defer a.server.connMu.Unlock()
+	conn, ok := a.server.connections[id]
+	if !ok {
+		return nil, fmt.Errorf("session %s is not connected", id)
+	}
+	return conn, nil
+}
+
+// disconnect tells a session why it is being removed and closes its connection shortly after.
+func (a *serverAdmin) disconnect(id multiplayer.SessionID, conn *connection, reason string) {
+	if handle, found := a.server.sessions.Get(id); found {
+		handle.Send(multiplayer.KickedEvent{Reason: reason})
+	}
+	time.AfterFunc(kickGracePeriod, func() {
+		//nolint:errcheck // Connection may already be gone
+		conn.session.Close()
+	})
+}
+
+// EndMatch cancels an active online match. Both players are returned to the menu.
+func (a *serverAdmin) EndMatch(id multiplayer.MatchID) error {
+	if _, ok := a.server.coordinator.GetMatch(id); !ok {
+		return fmt.Errorf("match %s is not running", id)
+	}
+	a.server.coordinator.Send(multiplayer.EndMatchMsg{MatchID: id})
+	return a.audit("end_match", string(id), "")
+}
+
+// Broadcast shows a banner message on every connected session.
+func (a *serverAdmin) Broadcast(message string) error {
+	if message == "" {
+		return errors.New("message is empty")
+	}
+	a.server.sessions.Broadcast(multiplayer.BannerEvent{Message: message})
+	return a.audit("broadcast", "", message)
+}
+
+// ClearScores deletes a game's leaderboard.
+func (a *serverAdmin) ClearScores(gameID string) error {
+	if a.server.store == nil {
+		return errors.New("scores database is not available")
+	}
+	if err := a.server.store.ClearScores(gameID); err != nil {
+		return err
+	}
+	return a.audit("clear_scores", gameID, "")
+}
+
+// RecentActions returns the latest audit log entries.
+func (a *serverAdmin) RecentActions(limit int) ([]storage.AdminAction, error) {
+	if a.server.store == nil {
+		return nil, nil
+	}
+	return a.server.store.RecentAdminActions(limit)
+}
+
+// audit logs an admin action to the server log and the audit table.
+func (a *serverAdmin) audit(action, target, details string) error {
+	a.server.logger.Info("admin action",
+		"admin", a.admin,
+		"key", a.key,
+		"action", action,
+		"target", target,
+		"details", details,
+	)
+	if a.server.store == nil {
+		return nil
+	}
+	return a.server.store.LogAdminAction(fmt.Sprintf("%s (%s)", a.admin, a.key), action, target, details)
+}
@@ -0,0 +1,514 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+
+	"github.com/vovakirdan/tui-arcade/internal/registry"
+)
+
+// sshCommandUsage is printed for `ssh host help` and unknown commands.
+const sshCommandUsage = `Usage: ssh <host> [command]
+
+Commands:
+  (none)                  Open the arcade menu (needs a terminal)
+  play <game> [--record]  Start a game straight away (needs a terminal)
+  watch [--record]        Spectate live online matches (needs a terminal)
+  scores <game> [--json]  Print the top 10 scores for a game
+  stats [--json]          Print per-game and server statistics
+  screenshots [--json]    List your screenshots (Ctrl+S in games)
+  screenshot <id|latest>  Print a screenshot, e.g. "ssh <host> screenshot latest > shot.svg"
+  recordings [--json]     List your recordings (Ctrl+R in games, or --record)
+  recording <id|latest>   Print a recording, e.g. "ssh <host> recording latest > game.cast"
+  help                    Show this help
+
+Use "ssh -t <host> play <game>" if your client doesn't allocate a terminal for commands.
+`
+
+// maxCommandScores is how many scores the scores command prints.
+const maxCommandScores = 10
+
+// commandMiddleware handles SSH exec commands.
+// Plain-output commands are answered here without a PTY; play and watch are passed on to the TUI.
+func (s *SSHServer) commandMiddleware(next ssh.Handler) ssh.Handler {
+	return func(sshSession ssh.Session) {
+		args := sshSession.Command()
+		_, _, hasPty := sshSession.Pty()
+
+		if len(args) == 0 {
+			if !hasPty {
+				wish.Fatal(sshSession, "No terminal requested.\n\n"+sshCommandUsage)
+				return
+			}
+			next(sshSession)
+			return
+		}
+
+		params, jsonOutput, _ := splitCommandArgs(args)
+
+		switch args[0] {
+		case "help", "--help", "-h":
+			wish.Print(sshSession, sshCommandUsage)
+
+		case "scores":
+			if len(params) != 1 {
+				wish.Fatal(sshSession, "Usage: scores <game> [--json]\n")
+				return
+			}
+			if err := s.writeScores(sshSession, params[0], jsonOutput); err != nil {
+				wish.Fatalln(sshSession, "Error:", err)
+			}
+
+		case "stats":
+			if err := s.writeStats(sshSession, jsonOutput); err != nil {
+				wish.Fatalln(sshSession, "Error:", err)
+			}
+
+		case "screenshots":
+			if err := s.writeScreenshots(sshSession, keyFingerprint(sshSession.PublicKey()), jsonOutput); err != nil {
+				wish.Fatalln(sshSession, "Error:", err)
+			}
+
+		case "screenshot":
+			if len(params) != 1 {
+				wish.Fatal(sshSession, "Usage: screenshot <id|latest>\n")
+				return
+			}
+			if err := s.writeScreenshot(sshSession, keyFingerprint(sshSession.PublicKey()), params[0]); err != nil {
+				wish.Fatalln(sshSession, "Error:", err)
+			}
+
+		case "recordings":
+			if err := s.writeRecordings(sshSession, keyFingerprint(sshSession.PublicKey()), jsonOutput); err != nil {
+				wish.Fatalln(sshSession, "Error:", err)
+			}
+
+		case "recording":
+			if len(params) != 1 {
+				wish.Fatal(sshSession, "Usage: recording <id|latest>\n")
+				return
+			}
+			if err := s.writeRecording(sshSession, keyFingerprint(sshSession.PublicKey()), params[0]); err != nil {
+				wish.Fatalln(sshSession, "Error:", err)
+			}
+
+		case "play":
+			if len(params) != 1 {
+				wish.Fatal(sshSession, "Usage: play <game> [--record]\n")
+				return
+			}
+			if !registry.Exists(params[0]) {
+				wish.Fatalf(sshSession, "Error: unknown game %q\n", params[0])
+				return
+			}
+			if !hasPty {
+				wish.Fatalln(sshSession, "play needs a terminal: use ssh -t")
+				return
+			}
+			next(sshSession)
+
+		case "watch":
+			if !hasPty {
+				wish.Fatalln(sshSession, "watch needs a terminal: use ssh -t")
+				return
+			}
+			next(sshSession)
+
+		default:
+			wish.Fatalf(sshSession, "Unknown command %q.\n\n%s", args[0], sshCommandUsage)
+		}
+	}
+}
+
+// splitCommandArgs separates the parameters of an exec command from its
+// --json and --record flags.
+func splitCommandArgs(args []string) (params []string, jsonOutput, record bool) {
+	for _, a := range args[1:] {
+		switch a {
+		case "--json", "-j":
+			jsonOutput = true
+		case "--record":
+			record = true
+		default:
+			params = append(params, a)
+		}
+	}
+	return params, jsonOutput, record
+}
+
+// scoreJSON is one leaderboard row in JSON output.
+type scoreJSON struct {
+	Rank  int       `json:"rank"`
+	Score int       `json:"score"`
+	Date  time.Time `json:"date"`
+}
+
+// writeScores prints the top scores for a game.
+func (s *SSHServer) writeScores(w io.Writer, gameID string, asJSON bool) error {
+	game, err := registry.Create(gameID)
+	if err != nil {
+		return fmt.Errorf("unknown game %q", gameID)
+	}
+	if s.store == nil {
+		return errors.New("scores database is not available")
+	}
+
+	scores, err := s.store.TopScores(gameID, maxCommandScores)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		rows := make([]scoreJSON, 0, len(scores))
+		for i, entry := range scores {
+			rows = append(rows, scoreJSON{Rank: i + 1, Score: entry.Score, Date: entry.CreatedAt})
+		}
+		return writeJSON(w, map[string]any{
+			"game":   gameID,
+			"title":  game.Title(),
+			"scores": rows,
+		})
+	}
+
+	fmt.Fprintf(w, "High Scores - %s\n\n", game.Title())
+	if len(scores) == 0 {
+		fmt.Fprintln(w, "No scores recorded yet.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "  %-4s  %-10s  %s\n", "Rank", "Score", "Date")
+	fmt.Fprintf(w, "  %-4s  %-10s  %s\n", "----", "-----", "----")
+	for i, entry := range scores {
+		fmt.Fprintf(w, "  %-4d  %-10d  %s\n", i+1, entry.Score, entry.CreatedAt.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+// gameStatsJSON is one game's statistics in JSON output.
+type gameStatsJSON struct {
+	Game       string     `json:"game"`
+	Title      string     `json:"title"`
+	Games      int        `json:"games"`
+	HighScore  int        `json:"high_score"`
+	AvgScore   float64    `json:"avg_score"`
+	LastPlayed *time.Time `json:"last_played,omitempty"`
+}
+
+// serverStatsJSON is the live server state in JSON output.
+type serverStatsJSON struct {
+	Sessions int `json:"sessions"`
+	Lobbies  int `json:"lobbies"`
+	Matches  int `json:"matches"`
+}
+
+// writeStats prints per-game statistics and live server counts.
+func (s *SSHServer) writeStats(w io.Writer, asJSON bool) error {
+	games := make([]gameStatsJSON, 0)
+	if s.store != nil {
+		all, err := s.store.GetAllGamesStats()
+		if err != nil {
+			return err
+		}
+		for _, g := range registry.List() {
+			st, ok := all[g.ID]
+			if !ok {
+				continue
+			}
+			row := gameStatsJSON{
+				Game:      g.ID,
+				Title:     g.Title,
+				Games:     st.GamesCount,
+				HighScore: st.HighScore,
+				AvgScore:  st.AvgScore,
+			}
+			if !st.LastPlayed.IsZero() {
+				lastPlayed := st.LastPlayed
+				row.LastPlayed = &lastPlayed
+			}
+			games = append(games, row)
+		}
+	}
+
+	server := serverStatsJSON{
+		Sessions: s.sessions.Count(),
+		Lobbies:  s.coordinator.LobbyCount(),
+		Matches:  s.coordinator.MatchCount(),
+	}
+
+	if asJSON {
+		return writeJSON(w, map[string]any{
+			"server": server,
+			"games":  games,
+		})
+	}
+
+	fmt.Fprintf(w, "Server: %d sessions, %d lobbies, %d matches\n\n", server.Sessions, server.Lobbies, server.Matches)
+	if len(games) == 0 {
+		fmt.Fprintln(w, "No games played yet.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "  %-18s  %-6s  %-8s  %-8s  %s\n", "Game", "Games", "Best", "Average", "Last played")
+	fmt.Fprintf(w, "  %-18s  %-6s  %-8s  %-8s  %s\n", "----", "-----", "----", "-------", "-----------")
+	for _, g := range games {
+		lastPlayed := "-"
+		if g.LastPlayed != nil {
+			lastPlayed = g.LastPlayed.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "  %-18s  %-6d  %-8d  %-8.1f  %s\n", g.Title, g.Games, g.HighScore, g.AvgScore, lastPlayed)
+	}
+	return nil
+}
+
+// captureKind describes the captures a player keeps on the server,
+// screenshots or recordings, for the commands that list and download them.
+type captureKind struct {
+	name     string // Singular, for messages
+	noKey    error  // Returned for keyless sessions
+	empty    string // Listing without any
+	download string // How to download one, under the listing
+}
+
+// check returns why owner's captures can't be listed or downloaded, if they
+// can't. owner is the player's key fingerprint, as the username is whatever
+// the client sent.
+func (k captureKind) check(s *SSHServer, owner string) error {
+	if owner == "" {
+		return k.noKey
+	}
+	if s.store == nil {
+		return errors.New("scores database is not available")
+	}
+	return nil
+}
+
+// writeTable prints a listing of captures, one row per capture under header.
+// Every column but the last is padded to its width.
+func (k captureKind) writeTable(w io.Writer, header []string, widths []int, rows [][]string) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, k.empty)
+		return
+	}
+
+	line := func(cells []string) {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			if i < len(widths) {
+				cell = fmt.Sprintf("%-*s", widths[i], cell)
+			}
+			padded[i] = cell
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(padded, "  "))
+	}
+	dashes := make([]string, len(header))
+	for i, h := range header {
+		dashes[i] = strings.Repeat("-", len(h))
+	}
+	line(header)
+	line(dashes)
+	for _, row := range rows {
+		line(row)
+	}
+	fmt.Fprintf(w, "\n%s\n", k.download)
+}
+
+// writeContent writes the content of the capture selected by which, an ID or
+// "latest". ids lists the owner's captures newest first; content returns
+// false for an ID the owner doesn't have.
+func (k captureKind) writeContent(w io.Writer, which string, ids func() ([]int64, error), content func(id int64) ([]byte, bool, error)) error {
+	var id int64
+	if which == "latest" {
+		all, err := ids()
+		if err != nil {
+			return err
+		}
+		if len(all) == 0 {
+			return fmt.Errorf("no %ss yet", k.name)
+		}
+		id = all[0]
+	} else {
+		parsed, err := strconv.ParseInt(which, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s ID %q", k.name, which)
+		}
+		id = parsed
+	}
+
+	data, found, err := content(id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no %s with ID %d", k.name, id)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// screenshotJSON is one saved screenshot in JSON output.
+type screenshotJSON struct {
+	ID     int64     `json:"id"`
+	Game   string    `json:"game"`
+	Format string    `json:"format"`
+	Size   int       `json:"size"`
+	Date   time.Time `json:"date"`
+}
+
+// errNoScreenshotKey is returned when a keyless player asks for screenshots.
+var errNoScreenshotKey = errors.New("screenshots are kept per SSH key: connect with a key to take and download them")
+
+var screenshotCaptures = captureKind{
+	name:     "screenshot",
+	noKey:    errNoScreenshotKey,
+	empty:    "No screenshots yet. Press Ctrl+S in a game to take one.",
+	download: "Download one with: ssh <host> screenshot <id> > file",
+}
+
+// writeScreenshots lists a player's saved screenshots, newest first.
+func (s *SSHServer) writeScreenshots(w io.Writer, owner string, asJSON bool) error {
+	if err := screenshotCaptures.check(s, owner); err != nil {
+		return err
+	}
+
+	shots, err := s.store.Screenshots(owner)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		rows := make([]screenshotJSON, 0, len(shots))
+		for _, shot := range shots {
+			rows = append(rows, screenshotJSON{
+				ID:     shot.ID,
+				Game:   shot.GameID,
+				Format: shot.Format,
+				Size:   shot.Size,
+				Date:   shot.CreatedAt,
+			})
+		}
+		return writeJSON(w, map[string]any{"screenshots": rows})
+	}
+
+	rows := make([][]string, 0, len(shots))
+	for _, shot := range shots {
+		rows = append(rows, []string{strconv.FormatInt(shot.ID, 10), shot.GameID, shot.Format, strconv.Itoa(shot.Size), shot.CreatedAt.Format("2006-01-02 15:04")})
+	}
+	screenshotCaptures.writeTable(w, []string{"ID", "Game", "Format", "Size", "Date"}, []int{6, 12, 6, 8}, rows)
+	return nil
+}
+
+// writeScreenshot writes the content of one of a player's screenshots,
+// selected by ID or "latest".
+func (s *SSHServer) writeScreenshot(w io.Writer, owner, which string) error {
+	if err := screenshotCaptures.check(s, owner); err != nil {
+		return err
+	}
+
+	ids := func() ([]int64, error) {
+		shots, err := s.store.Screenshots(owner)
+		all := make([]int64, len(shots))
+		for i, shot := range shots {
+			all[i] = shot.ID
+		}
+		return all, err
+	}
+	content := func(id int64) ([]byte, bool, error) {
+		shot, err := s.store.ScreenshotByID(owner, id)
+		if shot == nil || err != nil {
+			return nil, false, err
+		}
+		return shot.Content, true, nil
+	}
+	return screenshotCaptures.writeContent(w, which, ids, content)
+}
+
+// recordingJSON is one saved recording in JSON output.
+type recordingJSON struct {
+	ID       int64     `json:"id"`
+	Game     string    `json:"game"`
+	Duration float64   `json:"duration"` // Seconds
+	Size     int       `json:"size"`
+	Date     time.Time `json:"date"`
+}
+
+// errNoRecordingKey is returned when a keyless player asks for recordings.
+var errNoRecordingKey = errors.New("recordings are kept per SSH key: connect with a key to make and download them")
+
+var recordingCaptures = captureKind{
+	name:     "recording",
+	noKey:    errNoRecordingKey,
+	empty:    "No recordings yet. Press Ctrl+R in a game to start one.",
+	download: "Download one with: ssh <host> recording <id> > file.cast, play it with: asciinema play file.cast",
+}
+
+// writeRecordings lists a player's saved recordings, newest first.
+func (s *SSHServer) writeRecordings(w io.Writer, owner string, asJSON bool) error {
+	if err := recordingCaptures.check(s, owner); err != nil {
+		return err
+	}
+
+	recs, err := s.store.Recordings(owner)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		rows := make([]recordingJSON, 0, len(recs))
+		for _, rec := range recs {
+			rows = append(rows, recordingJSON{
+				ID:       rec.ID,
+				Game:     rec.GameID,
+				Duration: rec.Duration.Seconds(),
+				Size:     rec.Size,
+				Date:     rec.CreatedAt,
+			})
+		}
+		return writeJSON(w, map[string]any{"recordings": rows})
+	}
+
+	rows := make([][]string, 0, len(recs))
+	for _, rec := range recs {
+		rows = append(rows, []string{strconv.FormatInt(rec.ID, 10), rec.GameID, rec.Duration.Round(time.Second).String(), strconv.Itoa(rec.Size), rec.CreatedAt.Format("2006-01-02 15:04")})
+	}
+	recordingCaptures.writeTable(w, []string{"ID", "Game", "Length", "Size", "Date"}, []int{6, 12, 8, 8}, rows)
+	return nil
+}
+
+// writeRecording writes one of a player's recordings, selected by ID or "latest".
+func (s *SSHServer) writeRecording(w io.Writer, owner, which string) error {
+	if err := recordingCaptures.check(s, owner); err != nil {
+		return err
+	}
+
+	ids := func() ([]int64, error) {
+		recs, err := s.store.Recordings(owner)
+		all := make([]int64, len(recs))
+		for i, rec := range recs {
+			all[i] = rec.ID
+		}
+		return all, err
+	}
+	content := func(id int64) ([]byte, bool, error) {
+		rec, err := s.store.RecordingByID(owner, id)
+		if rec == nil || err != nil {
+			return nil, false, err
+		}
+		return rec.Content, true, nil
+	}
+	return recordingCaptures.writeContent(w, which, ids, content)
+}
+
+// writeJSON writes v as indented JSON followed by a newline.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
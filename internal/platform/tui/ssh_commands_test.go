@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+	"github.com/vovakirdan/tui-arcade/internal/storage"
+)
+
+// testStore opens a scores database in a temporary directory.
+func testStore(t *testing.T) *storage.Store {
+	t.Helper()
+	store, err := storage.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestScreenshotsPerKey(t *testing.T) {
+	s := &SSHServer{store: testStore(t)}
+	const alice, bob = "SHA256:alice", "SHA256:bob"
+	id, err := s.store.SaveScreenshot(storage.Screenshot{Username: alice, GameID: "snake", Format: "text", Content: []byte("shot")}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := s.writeScreenshot(&out, alice, "latest"); err != nil || out.String() != "shot" {
+		t.Errorf("owner's screenshot: got %q, %v", out.String(), err)
+	}
+	if err := s.writeScreenshot(&out, bob, strconv.FormatInt(id, 10)); err == nil {
+		t.Errorf("another key downloaded screenshot %d", id)
+	}
+
+	for _, write := range []func() error{
+		func() error { return s.writeScreenshots(&out, "", false) },
+		func() error { return s.writeScreenshot(&out, "", "latest") },
+	} {
+		if err := write(); !errors.Is(err, errNoScreenshotKey) {
+			t.Errorf("keyless session: got %v, want %v", err, errNoScreenshotKey)
+		}
+	}
+
+	target := captureTarget{store: s.store, remote: true}
+	if _, err := target.saveScreenshot("snake", core.NewScreen(10, 3)); err == nil {
+		t.Error("keyless session saved a screenshot")
+	}
+}
+
+func TestRecordingsPerKey(t *testing.T) {
+	s := &SSHServer{store: testStore(t)}
+	const alice, bob = "SHA256:alice", "SHA256:bob"
+
+	// A recording made in alice's session is saved under her key
+	target := captureTarget{store: s.store, profile: alice, remote: true, recordings: 2}
+	screen := core.NewScreen(10, 3)
+	r, err := target.startRecording("snake", "", 10, 3, func(*core.Screen) string { return "frame" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.frame(time.Now(), screen, "")
+	if msg := r.finish(); !strings.HasPrefix(msg, "Recording 1 saved") {
+		t.Fatalf("finish: %s", msg)
+	}
+
+	var out bytes.Buffer
+	if err := s.writeRecording(&out, alice, "latest"); err != nil || !strings.Contains(out.String(), "frame") {
+		t.Errorf("owner's recording: got %q, %v", out.String(), err)
+	}
+	if err := s.writeRecording(&out, bob, "1"); err == nil {
+		t.Error("another key downloaded recording 1")
+	}
+
+	// Pruning only counts the owner's recordings
+	for range 3 {
+		if _, err := s.store.SaveRecording(storage.Recording{Username: bob, GameID: "dino", Content: []byte("b")}, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if recs, err := s.store.Recordings(alice); err != nil || len(recs) != 1 {
+		t.Errorf("alice's recordings after bob's: %d, %v", len(recs), err)
+	}
+
+	for _, write := range []func() error{
+		func() error { return s.writeRecordings(&out, "", false) },
+		func() error { return s.writeRecording(&out, "", "latest") },
+	} {
+		if err := write(); !errors.Is(err, errNoRecordingKey) {
+			t.Errorf("keyless session: got %v, want %v", err, errNoRecordingKey)
+		}
+	}
+
+	target.profile = ""
+	if _, err := target.startRecording("snake", "", 10, 3, nil); err == nil {
+		t.Error("keyless session started a recording")
+	}
+}
+
+func TestCaptureListing(t *testing.T) {
+	s := &SSHServer{store: testStore(t)}
+	const alice = "SHA256:alice"
+
+	var out bytes.Buffer
+	if err := s.writeScreenshots(&out, alice, false); err != nil || !strings.HasPrefix(out.String(), "No screenshots yet.") {
+		t.Errorf("empty listing: got %q, %v", out.String(), err)
+	}
+	if err := s.writeScreenshot(&out, alice, "latest"); err == nil || err.Error() != "no screenshots yet" {
+		t.Errorf("latest of none: got %v", err)
+	}
+
+	id, err := s.store.SaveScreenshot(storage.Screenshot{Username: alice, GameID: "snake", Format: "text", Content: []byte("shot")}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shots, err := s.store.Screenshots(alice)
+	if err != nil || len(shots) != 1 {
+		t.Fatalf("screenshots: %d, %v", len(shots), err)
+	}
+
+	out.Reset()
+	if err := s.writeScreenshots(&out, alice, false); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("  %-6s  %-12s  %-6s  %-8s  %s\n", "ID", "Game", "Format", "Size", "Date") +
+		fmt.Sprintf("  %-6s  %-12s  %-6s  %-8s  %s\n", "--", "----", "------", "----", "----") +
+		fmt.Sprintf("  %-6d  %-12s  %-6s  %-8d  %s\n", id, "snake", "text", 4, shots[0].CreatedAt.Format("2006-01-02 15:04")) +
+		"\nDownload one with: ssh <host> screenshot <id> > file\n"
+	if out.String() != want {
+		t.Errorf("listing:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	for which, msg := range map[string]string{
+		"abc": `invalid screenshot ID "abc"`,
+		"999": "no screenshot with ID 999",
+	} {
+		if err := s.writeScreenshot(&out, alice, which); err == nil || err.Error() != msg {
+			t.Errorf("screenshot %s: got %v, want %q", which, err, msg)
+		}
+	}
+	if err := s.writeRecording(&out, alice, "latest"); err == nil || err.Error() != "no recordings yet" {
+		t.Errorf("latest recording of none: got %v", err)
+	}
+}
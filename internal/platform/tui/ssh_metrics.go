@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/vovakirdan/tui-arcade/internal/metrics"
+)
+
+// serverMetrics holds counters that can't be derived from the coordinator or session registry.
+type serverMetrics struct {
+	startedAt    time.Time
+	gamesStarted *metrics.CounterVec
+}
+
+// newServerMetrics creates the server-side counters.
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		startedAt: time.Now(),
+		gamesStarted: metrics.NewCounterVec(
+			"arcade_games_started_total",
+			"Games started, by game ID (online matches count once).",
+			"game",
+		),
+	}
+}
+
+// GameStarted records that a game was started. Safe to call on a nil receiver.
+func (m *serverMetrics) GameStarted(gameID string) {
+	if m == nil {
+		return
+	}
+	m.gamesStarted.Inc(gameID)
+}
+
+// families gathers all metric families exposed by the server.
+func (s *SSHServer) families() []metrics.Family {
+	matchOverruns := metrics.Family{
+		Name: "arcade_match_tick_overruns",
+		Help: "Ticks that took longer than the tick interval, per active match.",
+		Type: metrics.TypeGauge,
+	}
+	for _, m := range s.coordinator.Matches() {
+		matchOverruns.Samples = append(matchOverruns.Samples, metrics.Sample{
+			Labels: []metrics.Label{
+				{Name: "match_id", Value: string(m.ID)},
+				{Name: "game", Value: m.GameID},
+			},
+			Value: float64(m.Overruns),
+		})
+	}
+
+	return []metrics.Family{
+		metrics.Gauge("arcade_uptime_seconds", "Seconds since the server started.",
+			time.Since(s.metrics.startedAt).Seconds()),
+		metrics.Gauge("arcade_sessions_active", "Connected SSH sessions.",
+			float64(s.sessions.Count())),
+		metrics.Gauge("arcade_lobbies_active", "Open lobbies waiting for a second player.",
+			float64(s.coordinator.LobbyCount())),
+		metrics.Gauge("arcade_matches_active", "Online matches currently running.",
+			float64(s.coordinator.MatchCount())),
+		matchOverruns,
+		metrics.Counter("arcade_match_tick_overruns_total", "Overrun ticks across all online matches.",
+			float64(s.coordinator.TickOverruns())),
+		metrics.Counter("arcade_match_inputs_dropped_total", "Player inputs dropped because a match input buffer was full.",
+			float64(s.coordinator.DroppedInputs())),
+		metrics.Counter("arcade_session_events_dropped_total", "Events dropped because a session event buffer was full.",
+			float64(s.sessions.DroppedEvents())),
+		s.metrics.gamesStarted.Family(),
+	}
+}
+
+// handleMetrics serves all metrics in Prometheus text format.
+func (s *SSHServer) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	var buf bytes.Buffer
+	for _, f := range s.families() {
+		if err := metrics.WriteFamily(&buf, f); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	//nolint:errcheck // Client may have gone away, nothing to do
+	w.Write(buf.Bytes())
+}
+
+// handleHealth reports whether the server can serve players.
+// Returns 503 if the scores database has become unreachable.
+func (s *SSHServer) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if s.store != nil {
+		if err := s.store.Ping(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "unhealthy: %v\n", err)
+			return
+		}
+	}
+
+	fmt.Fprintln(w, "ok")
+}
+
+// startMetricsServer starts the HTTP metrics/health endpoint if configured.
+// Returns nil if no metrics address is set.
+func (s *SSHServer) startMetricsServer() (*http.Server, error) {
+	if s.config.MetricsAddress == "" {
+		return nil, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/healthz", s.handleHealth)
+
+	listener, err := net.Listen("tcp", s.config.MetricsAddress)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen for metrics on %s: %w", s.config.MetricsAddress, err)
+	}
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("metrics server error", "error", err)
+		}
+	}()
+
+	s.logger.Info("serving metrics", "address", listener.Addr().String())
+	return srv, nil
+}
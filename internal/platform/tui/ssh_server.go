@@ -2,22 +2,30 @@
 package tui
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/bubbletea"
+	"github.com/muesli/termenv"
 
+	"github.com/vovakirdan/tui-arcade/internal/config"
 	"github.com/vovakirdan/tui-arcade/internal/core"
 	"github.com/vovakirdan/tui-arcade/internal/games/breakout"
 	"github.com/vovakirdan/tui-arcade/internal/games/pong"
@@ -42,14 +50,59 @@ type SSHServerConfig struct {
 
 	// IdleTimeout is how long to wait before closing idle connections.
 	IdleTimeout time.Duration
+
+	// MetricsAddress is the host:port for the HTTP /metrics and /healthz endpoint
+	// (e.g., "127.0.0.1:9100"). If empty, the endpoint is disabled.
+	MetricsAddress string
+
+	// AdminKeysPath is an authorized_keys file listing admins' public keys.
+	// Sessions authenticated with one of these keys get the Admin console.
+	// If empty, the admin console is disabled.
+	AdminKeysPath string
+
+	// AuthorizedKeysPath is an authorized_keys file of players allowed to connect.
+	// If empty, anyone may connect. Admin keys are always allowed.
+	AuthorizedKeysPath string
+
+	// BanListPath is the file of banned key fingerprints, IPs and CIDR ranges.
+	// If empty, ~/.arcade/bans.txt is used.
+	BanListPath string
+
+	// MaxSessions caps concurrent sessions server-wide (0 = unlimited).
+	MaxSessions int
+
+	// MaxSessionsPerIP caps concurrent sessions from one IP address (0 = unlimited).
+	MaxSessionsPerIP int
+
+	// ConnectionsPerMinute limits new connections per IP address per minute (0 = unlimited).
+	ConnectionsPerMinute int
+
+	// Renderer selects how sessions are drawn: RendererStandard (default) or
+	// RendererDiff, which sends only changed cells to cut bandwidth.
+	Renderer string
+
+	// RenderFPS caps the frames drawn per second for each session (0 = DefaultRenderFPS).
+	// Games keep simulating at their own tick rate, so a lower cap saves bandwidth
+	// without slowing them down.
+	RenderFPS int
+
+	// MaxRecordings is how many asciicast recordings are kept per player.
+	// Players opt in per session with Ctrl+R or "play <game> --record".
+	// 0 disables recording over SSH.
+	MaxRecordings int
+
+	// ChatFilterPath is a file of words masked in chat, one per line.
+	// If empty, chat is not filtered.
+	ChatFilterPath string
 }
 
 // DefaultSSHServerConfig returns a config with sensible defaults.
 func DefaultSSHServerConfig() SSHServerConfig {
 	return SSHServerConfig{
-		Address:     ":23234",
-		DBPath:      "~/.arcade/scores.db",
-		IdleTimeout: 30 * time.Minute,
+		Address:       ":23234",
+		DBPath:        "~/.arcade/scores.db",
+		IdleTimeout:   30 * time.Minute,
+		MaxRecordings: DefaultMaxRecordings,
 	}
 }
 
@@ -61,6 +114,15 @@ type SSHServer struct {
 	logger      *log.Logger
 	coordinator *multiplayer.Coordinator
 	sessions    *multiplayer.SessionRegistry
+	metrics     *serverMetrics
+	httpServer  *http.Server // Metrics/health endpoint, nil if disabled
+	adminKeys   []ssh.PublicKey
+	allowedKeys []ssh.PublicKey // Used only when AuthorizedKeysPath is set
+	bans        *banList
+	access      *accessControl
+
+	connMu      sync.Mutex
+	connections map[multiplayer.SessionID]*connection
 }
 
 // NewSSHServer creates a new SSH server with the given configuration.
@@ -95,21 +157,78 @@ func NewSSHServer(cfg SSHServerConfig) (*SSHServer, error) {
 		logger:      logger,
 		coordinator: coordinator,
 		sessions:    sessions,
+		metrics:     newServerMetrics(),
+		connections: make(map[multiplayer.SessionID]*connection),
+		access:      newAccessControl(cfg),
+	}
+
+	// closeStore releases the database if server setup fails
+	closeStore := func() {
+		if store != nil {
+			store.Close()
+		}
+	}
+
+	// Load admin keys
+	if cfg.AdminKeysPath != "" {
+		adminKeys, keysErr := loadAuthorizedKeys(cfg.AdminKeysPath)
+		if keysErr != nil {
+			closeStore()
+			return nil, fmt.Errorf("cannot load admin keys: %w", keysErr)
+		}
+		srv.adminKeys = adminKeys
+	}
+
+	// Load the player allow-list
+	if cfg.AuthorizedKeysPath != "" {
+		allowedKeys, keysErr := loadAuthorizedKeys(cfg.AuthorizedKeysPath)
+		if keysErr != nil {
+			closeStore()
+			return nil, fmt.Errorf("cannot load authorized keys: %w", keysErr)
+		}
+		srv.allowedKeys = allowedKeys
+	}
+
+	// Load the ban list
+	banListPath := cfg.BanListPath
+	if banListPath == "" {
+		banListPath, err = arcadePath("bans.txt")
+		if err != nil {
+			closeStore()
+			return nil, err
+		}
+	}
+	bans, err := loadBanList(banListPath)
+	if err != nil {
+		closeStore()
+		return nil, err
+	}
+	srv.bans = bans
+
+	// Load the chat filter
+	if cfg.ChatFilterPath != "" {
+		filter, filterErr := loadChatFilter(cfg.ChatFilterPath)
+		if filterErr != nil {
+			closeStore()
+			return nil, filterErr
+		}
+		coordinator.SetChatFilter(filter)
 	}
 
 	// Resolve host key path
 	hostKeyPath := cfg.HostKeyPath
 	if hostKeyPath == "" {
-		home, homeErr := os.UserHomeDir()
-		if homeErr != nil {
-			return nil, fmt.Errorf("cannot get home directory: %w", homeErr)
+		hostKeyPath, err = arcadePath("host_key")
+		if err != nil {
+			closeStore()
+			return nil, err
 		}
-		hostKeyPath = filepath.Join(home, ".arcade", "host_key")
 	}
 
 	// Ensure host key directory exists
 	hostKeyDir := filepath.Dir(hostKeyPath)
 	if mkdirErr := os.MkdirAll(hostKeyDir, 0o700); mkdirErr != nil {
+		closeStore()
 		return nil, fmt.Errorf("cannot create host key directory: %w", mkdirErr)
 	}
 
@@ -119,17 +238,23 @@ func NewSSHServer(cfg SSHServerConfig) (*SSHServer, error) {
 		wish.WithHostKeyPath(hostKeyPath),
 		wish.WithIdleTimeout(cfg.IdleTimeout),
 		wish.WithMiddleware(
-			bubbletea.Middleware(srv.teaHandler),
+			srv.diffCleanupMiddleware,
+			bubbletea.MiddlewareWithProgramHandler(srv.programHandler, termenv.Ascii),
+			srv.commandMiddleware,
+			srv.accessMiddleware,
 			srv.loggingMiddleware,
 		),
+		// Always ask for keys so admins and banned keys can be recognized.
+		// Keyless clients are let in unless an allow-list is configured.
+		wish.WithPublicKeyAuth(srv.authPublicKey),
+		wish.WithKeyboardInteractiveAuth(srv.authKeyboardInteractive),
+		ssh.WrapConn(srv.handleConn),
 	}
 
 	// Create the server
 	server, err := wish.NewServer(opts...)
 	if err != nil {
-		if store != nil {
-			store.Close()
-		}
+		closeStore()
 		return nil, fmt.Errorf("cannot create SSH server: %w", err)
 	}
 
@@ -137,7 +262,54 @@ func NewSSHServer(cfg SSHServerConfig) (*SSHServer, error) {
 	return srv, nil
 }
 
-// teaHandler creates a Bubble Tea program for each SSH session.
+// arcadePath returns the path of a file in the ~/.arcade directory.
+func arcadePath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot get home directory: %w", err)
+	}
+	return filepath.Join(home, ".arcade", name), nil
+}
+
+// diffSessionKey marks sessions drawn with the diff renderer in the session context.
+type diffSessionKey struct{}
+
+// programHandler creates the Bubble Tea program for an SSH session.
+// With the diff renderer, Bubble Tea's own renderer is disabled and the session
+// model writes its frames straight to the session.
+func (s *SSHServer) programHandler(sshSession ssh.Session) *tea.Program {
+	model, opts := s.teaHandler(sshSession)
+	if model == nil {
+		return nil
+	}
+	opts = append(opts, bubbletea.MakeOptions(sshSession)...)
+
+	if s.config.Renderer == RendererDiff {
+		var screens *ScreenRenderer
+		if sm, ok := model.(SessionModel); ok {
+			screens = sm.renderer
+		}
+		// PTYs are emulated, so the session itself is the client's terminal
+		model = newDiffModel(model, NewDiffRenderer(screens), sshSession)
+		opts = append(opts, tea.WithoutRenderer())
+		sshSession.Context().SetValue(diffSessionKey{}, true)
+	}
+
+	return tea.NewProgram(model, opts...)
+}
+
+// diffCleanupMiddleware runs after a session's program ends and restores the
+// client's terminal if the diff renderer drew it, as Bubble Tea's renderer didn't.
+func (s *SSHServer) diffCleanupMiddleware(next ssh.Handler) ssh.Handler {
+	return func(sshSession ssh.Session) {
+		if diff, ok := sshSession.Context().Value(diffSessionKey{}).(bool); ok && diff {
+			io.WriteString(sshSession, escLeaveScreen) //nolint:errcheck // Best-effort restore
+		}
+		next(sshSession)
+	}
+}
+
+// teaHandler creates the model and program options for each SSH session.
 func (s *SSHServer) teaHandler(sshSession ssh.Session) (tea.Model, []tea.ProgramOption) {
 	pty, _, ok := sshSession.Pty()
 	if !ok {
@@ -147,10 +319,11 @@ func (s *SSHServer) teaHandler(sshSession ssh.Session) (tea.Model, []tea.Program
 
 	// Create runtime config from PTY size
 	cfg := core.RuntimeConfig{
-		ScreenW:  pty.Window.Width,
-		ScreenH:  pty.Window.Height,
-		TickRate: 60,
-		Seed:     time.Now().UnixNano(),
+		ScreenW:   pty.Window.Width,
+		ScreenH:   pty.Window.Height,
+		TickRate:  60,
+		RenderFPS: s.config.RenderFPS,
+		Seed:      time.Now().UnixNano(),
 	}
 
 	// Create session ID and channel session for coordinator communication
@@ -159,13 +332,91 @@ func (s *SSHServer) teaHandler(sshSession ssh.Session) (tea.Model, []tea.Program
 
 	// Register session with registry
 	s.sessions.Register(channelSession)
+	s.trackConnection(sessionID, sshSession)
+
+	// Clean up when the connection goes away, however the session ended
+	go func() {
+		<-sshSession.Context().Done()
+		s.coordinator.Send(multiplayer.SessionDisconnectedMsg{SessionID: sessionID})
+		channelSession.Close()
+		s.sessions.Unregister(sessionID)
+		s.untrackConnection(sessionID)
+	}()
 
 	// Create session model that handles menu + game flow
 	model := NewSessionModel(s.store, cfg, sshSession.User(), sessionID, channelSession, s.coordinator)
+	model.profile = keyFingerprint(sshSession.PublicKey())
+	model.metrics = s.metrics
+	model.maxRecordings = s.config.MaxRecordings
+	model.renderer = NewScreenRenderer(newSessionRenderer(sshSession))
+	model.renderer.SetTheme(LoadUserTheme(s.store, model.profile, true))
+	model.keys = LoadPlayerKeyBindings(s.store, model.profile)
+	model.maxFPS = cmp.Or(s.config.RenderFPS, DefaultRenderFPS)
+	if prefs, err := LoadSettings(s.store, model.profile, true); err == nil {
+		model.applySettings(prefs)
+	}
+	model.term = sshSession
+	if s.isAdmin(sshSession) {
+		key := keyFingerprint(sshSession.PublicKey())
+		s.logger.Info("admin connected", "user", sshSession.User(), "key", key)
+		model.admin = &serverAdmin{server: s, admin: sshSession.User(), key: key, sessionID: sessionID}
+	}
+	model.menu = model.newMenu()
+	s.coordinator.Send(multiplayer.JoinHallMsg{SessionID: sessionID})
+
+	// "play <game>" and "watch" skip the menu; commandMiddleware has validated them
+	var start tea.Model = model
+	if args := sshSession.Command(); len(args) > 0 {
+		params, _, record := splitCommandArgs(args)
+		model.recordGames = record
+		switch args[0] {
+		case "play":
+			start = model.startCommandGame(params[0])
+		case "watch":
+			model.state = SessionStateWatchList
+			model.watch = NewWatchModel(s.coordinator, cfg.ScreenW, cfg.ScreenH)
+			model.watch.keyMapper = model.keys.Mapper("")
+			start = model
+		}
+	}
 
-	return model, []tea.ProgramOption{
+	return start, []tea.ProgramOption{
 		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
+		tea.WithFPS(cfg.RenderFPS),
+	}
+}
+
+// sessionEnviron exposes the client's environment to termenv for color detection.
+type sessionEnviron []string
+
+// Environ returns the environment as KEY=VALUE pairs.
+func (e sessionEnviron) Environ() []string {
+	return e
+}
+
+// Getenv returns the value of an environment variable, or "" if unset.
+// Later entries win, so the PTY's TERM overrides one sent by the client.
+func (e sessionEnviron) Getenv(key string) string {
+	value := ""
+	for _, kv := range e {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+			value = v
+		}
 	}
+	return value
+}
+
+// newSessionRenderer creates a lipgloss renderer with the color profile of the client's terminal,
+// detected from the PTY's TERM and the client's COLORTERM and NO_COLOR (if it sends them).
+// Unlike bubbletea.MakeRenderer it doesn't query the terminal, so silent clients don't stall.
+func newSessionRenderer(sshSession ssh.Session) *lipgloss.Renderer {
+	pty, _, ok := sshSession.Pty()
+	if !ok || pty.Term == "" || pty.Term == "dumb" {
+		return lipgloss.NewRenderer(sshSession, termenv.WithProfile(termenv.Ascii))
+	}
+	env := sessionEnviron(append(sshSession.Environ(), "TERM="+pty.Term))
+	return lipgloss.NewRenderer(sshSession, termenv.WithEnvironment(env), termenv.WithUnsafe())
 }
 
 // loggingMiddleware logs SSH session events.
@@ -190,6 +441,14 @@ func (s *SSHServer) ListenAndServe() error {
 	// Start coordinator
 	s.coordinator.Start()
 
+	// Start optional metrics/health endpoint
+	httpServer, err := s.startMetricsServer()
+	if err != nil {
+		s.coordinator.Stop()
+		return err
+	}
+	s.httpServer = httpServer
+
 	// Setup signal handling for graceful shutdown
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
@@ -213,6 +472,11 @@ func (s *SSHServer) Shutdown() error {
 	// Stop coordinator
 	s.coordinator.Stop()
 
+	if s.httpServer != nil {
+		//nolint:errcheck // Best-effort shutdown of the metrics endpoint
+		s.httpServer.Shutdown(ctx)
+	}
+
 	if s.store != nil {
 		s.store.Close()
 	}
@@ -246,18 +510,30 @@ const (
 	SessionStateBreakoutMode
 	SessionStateSnakeMode
 	SessionStateT2048Mode
+	SessionStateRunnerMode
 	SessionStateOnlineLobby
 	SessionStateInGame
 	SessionStateOnlineGame
 	SessionStateScoreboard
+	SessionStateAdmin
+	SessionStateWatchList
+	SessionStateSpectating
+	SessionStateSettings
 )
 
+// bannerDuration is how long an admin broadcast stays on screen.
+const bannerDuration = 10 * time.Second
+
+// bannerExpiredMsg clears the broadcast banner once it has been shown long enough.
+type bannerExpiredMsg struct{}
+
 // SessionModel manages the full arcade session flow: menu -> game -> menu.
 // This is the top-level model used for SSH sessions.
 type SessionModel struct {
 	store          *storage.Store
 	config         core.RuntimeConfig
 	username       string
+	profile        string // Key fingerprint the player's settings are saved under; "" when keyless
 	sessionID      multiplayer.SessionID
 	channelSession *multiplayer.ChannelSession
 	coordinator    *multiplayer.Coordinator
@@ -268,15 +544,45 @@ type SessionModel struct {
 	breakoutMode BreakoutModeModel
 	snakeMode    SnakeModeModel
 	t2048Mode    T2048ModeModel
+	runnerMode   RunnerModeModel
 	lobby        OnlineLobbyModel
 	scoreboard   ScoreboardModel
+	adminConsole AdminModel
+	watch        WatchModel
+	settings     SettingsModel
 	game         registry.Game
 	gameModel    *GameModel
 	quitting     bool
 
+	pongPersonality string // CPU personality ID for local Pong games
+
 	// Online game state
-	onlineGame   *pong.Game   // Local game instance for rendering from snapshots
-	onlineScreen *core.Screen // Screen buffer for online game rendering
+	onlineGame   *pong.Game                    // Local game instance for rendering from snapshots
+	onlineScreen *core.Screen                  // Screen buffer for online game rendering
+	onlineHelp   *helpOverlay                  // Help shown over an online match, or nil
+	onlineKeys   *keyInput                     // Paddle keys, whose held state the server keeps
+	watching     multiplayer.WatchStartedEvent // Match being spectated
+
+	// Chat: the arcade hall, the current lobby or match, and the message being typed
+	hallChat *chatLog
+	roomChat *chatLog
+	chatting *chatInput
+
+	metrics  *serverMetrics  // Server counters, nil outside the SSH server
+	admin    adminBackend    // Admin operations, nil unless the user is an admin
+	renderer *ScreenRenderer // Renders for the client's terminal colors
+	keys     *KeyBindings    // The player's key bindings
+	prefs    config.Settings // The player's settings: saved, or for this session when keyless
+	maxFPS   int             // The server's render rate, which players can't exceed
+	term     io.Writer       // The player's terminal, for keyboard protocol escapes
+
+	// Admin broadcasts and capture results shown over the top line
+	banner      string
+	bannerUntil time.Time
+
+	maxRecordings int        // Recordings kept per player; 0 disables recording
+	recordGames   bool       // Record every game from the start ("play <game> --record")
+	recording     *recording // Online match being recorded
 }
 
 // NewSessionModel creates a new session model.
@@ -297,22 +603,215 @@ func NewSessionModel(
 		coordinator:    coordinator,
 		state:          SessionStateMenu,
 		menu:           NewMenuModel(store, cfg),
+		keys:           currentKeyBindings(),
+		hallChat:       &chatLog{},
+		roomChat:       &chatLog{},
 	}
 }
 
 // Init initializes the session.
+// The session model is the only reader of coordinator events; it forwards them to the current state.
 func (m SessionModel) Init() tea.Cmd {
-	return m.menu.Init()
+	var initCmd tea.Cmd
+	switch m.state {
+	case SessionStateInGame:
+		initCmd = m.gameModel.Init()
+	case SessionStateWatchList:
+		initCmd = m.watch.Init()
+	default:
+		initCmd = m.menu.Init()
+	}
+	return tea.Batch(initCmd, m.waitForEvents())
+}
+
+// startCommandGame sets up a game launched with "ssh host play <game>".
+// Pong starts against the default CPU; the other games start solo.
+func (m SessionModel) startCommandGame(gameID string) SessionModel {
+	mode := multiplayer.MatchModeSolo
+	if gameID == "pong" {
+		mode = multiplayer.MatchModeVsCPU
+		m.pongPersonality = pong.PersonalityPro.ID
+	}
+	model, _ := m.startLocalGame(gameID, mode)
+	if sm, ok := model.(SessionModel); ok {
+		return sm
+	}
+	return m
+}
+
+// applySettings applies a player's settings to the session.
+func (m *SessionModel) applySettings(prefs config.Settings) {
+	m.prefs = prefs
+	m.config.RenderFPS = m.maxFPS
+	if prefs.RenderFPS > 0 {
+		m.config.RenderFPS = min(prefs.RenderFPS, m.maxFPS)
+	}
+}
+
+// newSettings creates the settings screen. A keyless player's settings
+// aren't saved anywhere to load them from, so it starts from the session's.
+func (m SessionModel) newSettings() SettingsModel {
+	settings := NewSettingsModel(m.store, m.profile, m.renderer, m.config.ScreenW, m.config.ScreenH)
+	settings.keys = m.keys
+	if m.profile == "" {
+		settings.prefs = m.prefs
+		settings.prefs.Theme = m.renderer.Theme().Name
+	}
+	return settings
+}
+
+// newMenu creates the main menu, including the Admin entry for admins.
+func (m SessionModel) newMenu() MenuModel {
+	menu := NewMenuModel(m.store, m.config).WithNickname(m.prefs.Nickname).WithAttract(m.prefs.Attract).WithRenderer(m.renderer)
+	menu.keyMapper = m.keys.Mapper("")
+	if m.admin != nil {
+		menu = menu.WithAdmin()
+	}
+	return menu
 }
 
 // Update handles messages for the session.
 func (m SessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	msg = translateKitty(msg)
+
 	// Handle window resize globally
 	if wsm, ok := msg.(tea.WindowSizeMsg); ok {
 		m.config.ScreenW = wsm.Width
 		m.config.ScreenH = wsm.Height
 	}
 
+	// A recording that reached its limits is saved before anything else happens
+	if m.recording != nil && m.recording.full {
+		saved := m.stopOnlineRecording()
+		model, cmd := m.Update(msg)
+		return model, tea.Batch(saved, cmd)
+	}
+
+	switch msg := msg.(type) {
+	case multiplayer.SessionEvent:
+		return m.handleSessionEvent(msg)
+	case bannerExpiredMsg:
+		if !time.Now().Before(m.bannerUntil) {
+			m.banner = ""
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.handleChatKey(msg) {
+			return m, nil
+		}
+	}
+
+	return m.updateState(msg)
+}
+
+// chatScope returns the chat channel of the current state: the hall in the
+// menu, the room in online lobbies and matches. It returns false where
+// there is no chat.
+func (m SessionModel) chatScope() (multiplayer.ChatScope, bool) {
+	switch m.state {
+	case SessionStateMenu:
+		return multiplayer.ChatScopeHall, !m.menu.attract.running
+	case SessionStateOnlineLobby:
+		state := m.lobby.State()
+		return multiplayer.ChatScopeRoom, state == OnlineStateHostWaiting || state == OnlineStateJoinWaiting
+	case SessionStateOnlineGame, SessionStateSpectating:
+		return multiplayer.ChatScopeRoom, true
+	}
+	return 0, false
+}
+
+// handleChatKey types a chat message: chatKey starts one, and while typing
+// every key goes to the message. It reports whether the key was used.
+func (m *SessionModel) handleChatKey(msg tea.KeyMsg) bool {
+	scope, ok := m.chatScope()
+	if !ok || m.coordinator == nil || msg.String() == "ctrl+c" {
+		m.chatting = nil
+		return false
+	}
+	if m.state == SessionStateMenu {
+		m.menu.attract.touch() // No demos while typing
+	}
+	if m.chatting != nil && m.chatting.scope != scope {
+		m.chatting = nil // Started somewhere else
+	}
+
+	if m.chatting == nil {
+		if msg.String() != chatKey {
+			return false
+		}
+		m.chatting = &chatInput{scope: scope}
+		return true
+	}
+
+	if text, done := m.chatting.handleKey(msg); done {
+		if text != "" {
+			m.sendChat(m.chatting.scope, text)
+		}
+		m.chatting = nil
+	}
+	return true
+}
+
+// typing returns the chat message being typed in the current state, if any.
+func (m SessionModel) typing() *chatInput {
+	if scope, ok := m.chatScope(); ok && m.chatting != nil && m.chatting.scope == scope {
+		return m.chatting
+	}
+	return nil
+}
+
+// sendChat sends a chat message under the player's nickname, or their user
+// name without one.
+func (m SessionModel) sendChat(scope multiplayer.ChatScope, text string) {
+	m.coordinator.Send(multiplayer.ChatMsg{
+		SessionID: m.sessionID,
+		Name:      cmp.Or(m.prefs.Nickname, m.username),
+		Scope:     scope,
+		Text:      text,
+	})
+}
+
+// showBanner shows a message on the top row for d and returns the command
+// that clears it afterwards.
+func (m *SessionModel) showBanner(text string, d time.Duration) tea.Cmd {
+	m.banner = text
+	m.bannerUntil = time.Now().Add(d)
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return bannerExpiredMsg{}
+	})
+}
+
+// handleSessionEvent handles session-wide events and forwards the rest to the current state.
+// Always re-arms the event pump.
+func (m SessionModel) handleSessionEvent(evt multiplayer.SessionEvent) (tea.Model, tea.Cmd) {
+	switch evt := evt.(type) {
+	case multiplayer.BannerEvent:
+		return m, tea.Batch(m.showBanner(evt.Message, bannerDuration), m.waitForEvents())
+	case multiplayer.KickedEvent:
+		// The server closes the connection shortly after
+		m.quitting = true
+		m.notifyDisconnect()
+		return m, tea.Quit
+	case multiplayer.ChatEvent:
+		if evt.Scope == multiplayer.ChatScopeHall {
+			m.hallChat.add(evt)
+		} else {
+			m.roomChat.add(evt)
+		}
+		return m, m.waitForEvents()
+	case multiplayer.ChatErrorEvent:
+		return m, tea.Batch(m.showBanner(evt.Message, bannerDuration), m.waitForEvents())
+	}
+
+	model, cmd := m.updateState(evt)
+	if sm, ok := model.(SessionModel); ok && !sm.quitting {
+		return sm, tea.Batch(cmd, sm.waitForEvents())
+	}
+	return model, cmd
+}
+
+// updateState dispatches a message to the current state.
+func (m SessionModel) updateState(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state {
 	case SessionStateMenu:
 		return m.updateMenu(msg)
@@ -324,6 +823,8 @@ func (m SessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateSnakeMode(msg)
 	case SessionStateT2048Mode:
 		return m.updateT2048Mode(msg)
+	case SessionStateRunnerMode:
+		return m.updateRunnerMode(msg)
 	case SessionStateOnlineLobby:
 		return m.updateLobby(msg)
 	case SessionStateInGame:
@@ -332,6 +833,14 @@ func (m SessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateOnlineGame(msg)
 	case SessionStateScoreboard:
 		return m.updateScoreboard(msg)
+	case SessionStateAdmin:
+		return m.updateAdmin(msg)
+	case SessionStateWatchList:
+		return m.updateWatchList(msg)
+	case SessionStateSpectating:
+		return m.updateSpectating(msg)
+	case SessionStateSettings:
+		return m.updateSettings(msg)
 	}
 	return m, nil
 }
@@ -362,10 +871,23 @@ func (m SessionModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if selected := m.menu.Selected(); selected != nil {
 		m.config = m.menu.Config()
 
+		if selected.GameID == adminMenuID && m.admin != nil {
+			m.state = SessionStateAdmin
+			m.adminConsole = NewAdminModel(m.admin, m.config.ScreenW, m.config.ScreenH)
+			return m, m.adminConsole.Init()
+		}
+
+		if selected.GameID == settingsMenuID {
+			m.state = SessionStateSettings
+			m.settings = m.newSettings()
+			return m, m.settings.Init()
+		}
+
 		// Special handling for Pong - show mode selection
 		if selected.GameID == "pong" {
 			m.state = SessionStatePongMode
 			m.pongMode = NewPongModeModel(m.config.ScreenW, m.config.ScreenH)
+			m.pongMode.keyMapper = m.keys.Mapper("")
 			return m, m.pongMode.Init()
 		}
 
@@ -373,6 +895,7 @@ func (m SessionModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if selected.GameID == "breakout" {
 			m.state = SessionStateBreakoutMode
 			m.breakoutMode = NewBreakoutModeModel(m.config.ScreenW, m.config.ScreenH)
+			m.breakoutMode.keyMapper = m.keys.Mapper("")
 			return m, m.breakoutMode.Init()
 		}
 
@@ -380,6 +903,7 @@ func (m SessionModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if selected.GameID == "snake" {
 			m.state = SessionStateSnakeMode
 			m.snakeMode = NewSnakeModeModel(m.config.ScreenW, m.config.ScreenH)
+			m.snakeMode.keyMapper = m.keys.Mapper("")
 			return m, m.snakeMode.Init()
 		}
 
@@ -387,9 +911,18 @@ func (m SessionModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if selected.GameID == "2048" {
 			m.state = SessionStateT2048Mode
 			m.t2048Mode = NewT2048ModeModel(m.config.ScreenW, m.config.ScreenH)
+			m.t2048Mode.keyMapper = m.keys.Mapper("")
 			return m, m.t2048Mode.Init()
 		}
 
+		// Runner games can be raced in split screen - show mode selection
+		if IsRaceGame(selected.GameID) {
+			m.state = SessionStateRunnerMode
+			m.runnerMode = NewRunnerModeModel(selected.GameID, m.config.ScreenW, m.config.ScreenH)
+			m.runnerMode.keyMapper = m.keys.Mapper("")
+			return m, m.runnerMode.Init()
+		}
+
 		// For other games, start directly
 		return m.startLocalGame(selected.GameID, selected.Mode)
 	}
@@ -415,7 +948,7 @@ func (m SessionModel) updatePongMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check for back
 	if m.pongMode.WantsBack() {
 		m.state = SessionStateMenu
-		m.menu = NewMenuModel(m.store, m.config)
+		m.menu = m.newMenu()
 		return m, m.menu.Init()
 	}
 
@@ -425,17 +958,22 @@ func (m SessionModel) updatePongMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if mode == multiplayer.MatchModeOnlinePvP {
 			// Start online lobby
 			m.state = SessionStateOnlineLobby
+			m.roomChat.clear()
 			m.lobby = NewOnlineLobbyModel(
 				"pong",
 				m.sessionID,
 				m.coordinator,
-				m.channelSession.Events(),
 				m.config.ScreenW,
 				m.config.ScreenH,
 			)
+			m.lobby.keyMapper = m.keys.Mapper("")
 			return m, m.lobby.Init()
 		}
-		// Start vs CPU game
+		if mode == multiplayer.MatchModeLocal2P {
+			return m.startLocalGame("pong", mode)
+		}
+		// Start vs CPU game with the chosen personality
+		m.pongPersonality = m.pongMode.Personality()
 		return m.startLocalGame("pong", multiplayer.MatchModeVsCPU)
 	}
 
@@ -460,7 +998,7 @@ func (m SessionModel) updateBreakoutMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check for back
 	if m.breakoutMode.WantsBack() {
 		m.state = SessionStateMenu
-		m.menu = NewMenuModel(m.store, m.config)
+		m.menu = m.newMenu()
 		return m, m.menu.Init()
 	}
 
@@ -497,7 +1035,7 @@ func (m SessionModel) updateSnakeMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check for back
 	if m.snakeMode.WantsBack() {
 		m.state = SessionStateMenu
-		m.menu = NewMenuModel(m.store, m.config)
+		m.menu = m.newMenu()
 		return m, m.menu.Init()
 	}
 
@@ -534,7 +1072,7 @@ func (m SessionModel) updateT2048Mode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check for back
 	if m.t2048Mode.WantsBack() {
 		m.state = SessionStateMenu
-		m.menu = NewMenuModel(m.store, m.config)
+		m.menu = m.newMenu()
 		return m, m.menu.Init()
 	}
 
@@ -553,6 +1091,36 @@ func (m SessionModel) updateT2048Mode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updateRunnerMode handles the mode selection of the runner games.
+func (m SessionModel) updateRunnerMode(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	newModel, cmd := m.runnerMode.Update(msg)
+	if runnerModel, ok := newModel.(RunnerModeModel); ok {
+		m.runnerMode = runnerModel
+	}
+
+	// Check if user quit
+	if m.runnerMode.IsQuitting() {
+		m.quitting = true
+		m.notifyDisconnect()
+		return m, tea.Quit
+	}
+
+	// Check for back
+	if m.runnerMode.WantsBack() {
+		m.state = SessionStateMenu
+		m.menu = m.newMenu()
+		return m, m.menu.Init()
+	}
+
+	// Check if mode was selected
+	if selection := m.runnerMode.Selected(); selection != nil {
+		return m.startLocalGame(m.runnerMode.gameID, selection.Mode)
+	}
+
+	return m, cmd
+}
+
 // updateScoreboard handles scoreboard updates.
 func (m SessionModel) updateScoreboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -571,13 +1139,159 @@ func (m SessionModel) updateScoreboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check for back to menu
 	if m.scoreboard.IsGoingBack() {
 		m.state = SessionStateMenu
-		m.menu = NewMenuModel(m.store, m.config)
+		m.menu = m.newMenu()
 		return m, m.menu.Init()
 	}
 
 	return m, cmd
 }
 
+// updateAdmin handles admin console updates.
+func (m SessionModel) updateAdmin(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	newModel, cmd := m.adminConsole.Update(msg)
+	if adminModel, ok := newModel.(AdminModel); ok {
+		m.adminConsole = adminModel
+	}
+
+	// Check if user quit
+	if m.adminConsole.IsQuitting() {
+		m.quitting = true
+		m.notifyDisconnect()
+		return m, tea.Quit
+	}
+
+	// Check for back to menu
+	if m.adminConsole.WantsBack() {
+		m.state = SessionStateMenu
+		m.menu = m.newMenu()
+		return m, m.menu.Init()
+	}
+
+	return m, cmd
+}
+
+// updateSettings handles the settings screen.
+func (m SessionModel) updateSettings(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	newModel, cmd := m.settings.Update(msg)
+	if settingsModel, ok := newModel.(SettingsModel); ok {
+		m.settings = settingsModel
+	}
+
+	// Check if user quit
+	if m.settings.IsQuitting() {
+		m.quitting = true
+		m.notifyDisconnect()
+		return m, tea.Quit
+	}
+
+	// Check for back to menu, with any key bindings changed in the settings
+	if m.settings.WantsBack() {
+		m.keys = m.settings.keys
+		m.applySettings(m.settings.prefs)
+		m.state = SessionStateMenu
+		m.menu = m.newMenu()
+		return m, m.menu.Init()
+	}
+
+	return m, cmd
+}
+
+// updateWatchList handles the spectator match list.
+func (m SessionModel) updateWatchList(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if started, ok := msg.(multiplayer.WatchStartedEvent); ok {
+		m.state = SessionStateSpectating
+		m.watching = started
+		m.onlineGame = pong.NewOnline()
+		m.onlineGame.Reset(m.config)
+		m.onlineScreen = core.NewScreen(m.config.ScreenW, m.config.ScreenH)
+		if m.recordGames {
+			return m, m.startOnlineRecording()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	newModel, cmd := m.watch.Update(msg)
+	if watchModel, ok := newModel.(WatchModel); ok {
+		m.watch = watchModel
+	}
+
+	// Check if user quit
+	if m.watch.IsQuitting() {
+		m.quitting = true
+		m.notifyDisconnect()
+		return m, tea.Quit
+	}
+
+	// Check for back to menu
+	if m.watch.WantsBack() {
+		m.state = SessionStateMenu
+		m.menu = m.newMenu()
+		return m, m.menu.Init()
+	}
+
+	// Ask the coordinator to add us as a spectator; it answers with WatchStartedEvent or WatchErrorEvent
+	if selected := m.watch.Selected(); selected != nil {
+		m.coordinator.Send(multiplayer.WatchMatchMsg{
+			SessionID: m.sessionID,
+			MatchID:   selected.ID,
+		})
+		m.watch.selected = nil
+	}
+
+	return m, cmd
+}
+
+// updateSpectating handles updates while watching someone else's match.
+func (m SessionModel) updateSpectating(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.stopOnlineRecording()
+			m.quitting = true
+			m.notifyDisconnect()
+			return m, tea.Quit
+		case screenshotKey:
+			return m, m.saveOnlineScreenshot()
+		case recordKey:
+			return m, m.toggleOnlineRecording()
+		case "esc", "b", "q":
+			m.coordinator.Send(multiplayer.StopWatchingMsg{
+				SessionID: m.sessionID,
+				MatchID:   m.watching.MatchID,
+			})
+			return m.backToWatchList("")
+		}
+	case multiplayer.SnapshotEvent:
+		if snap, ok := msg.Snapshot.(pong.PongSnapshot); ok && msg.MatchID == m.watching.MatchID {
+			if m.onlineGame != nil {
+				m.onlineGame.ApplySnapshot(snap)
+			}
+		}
+	case multiplayer.MatchEndedEvent:
+		if msg.MatchID == m.watching.MatchID {
+			return m.backToWatchList(fmt.Sprintf("Match %s has ended", m.watching.Code))
+		}
+	}
+	return m, nil
+}
+
+// backToWatchList leaves spectating and shows the match list with an optional message.
+func (m SessionModel) backToWatchList(message string) (tea.Model, tea.Cmd) {
+	saved := m.stopOnlineRecording()
+	m.state = SessionStateWatchList
+	m.onlineGame = nil
+	m.onlineScreen = nil
+	m.watching = multiplayer.WatchStartedEvent{}
+	m.watch = NewWatchModel(m.coordinator, m.config.ScreenW, m.config.ScreenH)
+	m.watch.keyMapper = m.keys.Mapper("")
+	m.watch.message = message
+	return m, tea.Batch(m.watch.Init(), saved)
+}
+
 // updateLobby handles online lobby updates.
 func (m SessionModel) updateLobby(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -596,31 +1310,45 @@ func (m SessionModel) updateLobby(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check for back to menu
 	if m.lobby.BackToMenu() {
 		m.state = SessionStateMenu
-		m.menu = NewMenuModel(m.store, m.config)
+		m.menu = m.newMenu()
 		return m, m.menu.Init()
 	}
 
 	// Check if match started
 	if m.lobby.State() == OnlineStateInMatch {
+		// Count each online match once, from the host's side
+		if m.lobby.Side() == core.Player1 {
+			m.metrics.GameStarted(m.lobby.gameID)
+		}
 		m.state = SessionStateOnlineGame
 		// Initialize local game instance for rendering
 		m.onlineGame = pong.NewOnline()
 		m.onlineGame.Reset(m.config)
 		m.onlineScreen = core.NewScreen(m.config.ScreenW, m.config.ScreenH)
-		return m, m.waitForEvents()
+		m.onlineHelp = nil
+		m.onlineKeys = newKeyInput(m.term)
+		m.onlineKeys.start()
+		if m.recordGames {
+			return m, m.startOnlineRecording()
+		}
+		return m, nil
 	}
 
 	return m, cmd
 }
 
-// startLocalGame starts a local (solo/vs CPU) game.
+// startLocalGame starts a local (solo, vs CPU or local 2P) game.
 func (m SessionModel) startLocalGame(gameID string, mode multiplayer.MatchMode) (tea.Model, tea.Cmd) {
-	game, err := registry.Create(gameID)
+	game, err := NewLocalGame(gameID, mode, m.prefs.GameDifficulty(gameID))
 	if err != nil {
 		return m, nil
 	}
+	if p, ok := game.(*pong.Game); ok {
+		p.SetPersonality(m.pongPersonality)
+	}
 
 	m.game = game
+	m.metrics.GameStarted(gameID)
 
 	// Create match for this game session
 	match := multiplayer.NewMatch(
@@ -631,23 +1359,33 @@ func (m SessionModel) startLocalGame(gameID string, mode multiplayer.MatchMode)
 
 	// Create game model
 	gameModel := NewGameModel(game, m.store, m.config, match)
+	gameModel.renderer = m.renderer
+	gameModel.keyMapper = m.keys.gameMapper(game)
+	gameModel.bindings = m.keys
+	gameModel.profile = m.profile
+	gameModel.keys = newKeyInput(m.term)
+	gameModel.captures = m.captures()
+	gameModel.record = m.recordGames
 	m.gameModel = &gameModel
 	m.state = SessionStateInGame
 
 	return m, m.gameModel.Init()
 }
 
-// waitForEvents returns a command that waits for coordinator events.
+// waitForEvents returns a command that waits for the next coordinator event.
+// Returns nil once the session is closed.
 func (m SessionModel) waitForEvents() tea.Cmd {
+	channelSession := m.channelSession
 	return func() tea.Msg {
-		if m.channelSession == nil {
+		if channelSession == nil {
 			return nil
 		}
-		evt, ok := <-m.channelSession.Events()
-		if !ok {
+		select {
+		case evt := <-channelSession.Events():
+			return evt
+		case <-channelSession.Done():
 			return nil
 		}
-		return evt
 	}
 }
 
@@ -673,16 +1411,22 @@ func (m SessionModel) updateGame(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Check if user quit game (back to menu)
 	if m.gameModel.BackToMenu() {
+		m.keys = m.gameModel.bindings
+		recorded := m.gameModel.FinishRecording()
 		m.state = SessionStateMenu
 		m.gameModel = nil
 		m.game = nil
 		// Reset menu state
-		m.menu = NewMenuModel(m.store, m.config)
+		m.menu = m.newMenu()
+		if recorded != "" {
+			return m, tea.Batch(m.menu.Init(), m.showBanner(recorded, bannerDuration))
+		}
 		return m, m.menu.Init()
 	}
 
 	// Check if user quit entirely
 	if m.gameModel.IsQuitting() {
+		m.gameModel.FinishRecording()
 		m.quitting = true
 		m.notifyDisconnect()
 		return m, tea.Quit
@@ -693,6 +1437,13 @@ func (m SessionModel) updateGame(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // updateOnlineGame handles updates when in online multiplayer game.
 func (m SessionModel) updateOnlineGame(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := m.onlineKeys.handle(msg); ok {
+		if action, _ := m.keys.Mapper("pong").MapKey(key); action == core.ActionUp || action == core.ActionDown {
+			m.onlineKeys.release(core.Player1, action)
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleOnlineGameKey(msg)
@@ -703,79 +1454,159 @@ func (m SessionModel) updateOnlineGame(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.onlineGame.ApplySnapshot(snap)
 			}
 		}
-		return m, m.waitForEvents()
+		m.sendHeldKeys()
+		return m, nil
 	case multiplayer.MatchEndedEvent:
 		// Match ended - return to menu
+		saved := m.stopOnlineRecording()
+		m.onlineKeys.stop()
 		m.state = SessionStateMenu
 		m.onlineGame = nil
 		m.onlineScreen = nil
-		m.menu = NewMenuModel(m.store, m.config)
-		return m, m.menu.Init()
+		m.menu = m.newMenu()
+		return m, tea.Batch(m.menu.Init(), saved)
 	}
-	return m, m.waitForEvents()
+	return m, nil
 }
 
 // handleOnlineGameKey handles keyboard input during online game.
 func (m SessionModel) handleOnlineGameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	// Screenshot and recording
+	switch key {
+	case screenshotKey:
+		return m, m.saveOnlineScreenshot()
+	case recordKey:
+		return m, m.toggleOnlineRecording()
+	}
+
+	if m.onlineHelp != nil {
+		// Any key closes the help; the match doesn't wait for it
+		m.onlineHelp = nil
+		return m, nil
+	}
+	if key == helpKey {
+		m.onlineHelp = newHelpOverlay(m.onlineGame, m.keys.Mapper("pong"))
+		return m, nil
+	}
+
+	action, isQuit := m.keys.Mapper("pong").MapKey(msg)
+
 	// Global quit
-	if key == "ctrl+c" {
+	if isQuit || key == "ctrl+c" {
+		m.stopOnlineRecording()
+		m.onlineKeys.stop()
 		m.quitting = true
 		m.notifyDisconnect()
 		return m, tea.Quit
 	}
 
-	// Screenshot
-	if key == "ctrl+s" {
-		m.saveOnlineScreenshot()
-		return m, nil
-	}
-
-	// Back to menu (Esc)
-	if key == "esc" {
+	// Back to menu
+	if action == core.ActionBack {
 		// Send leave match message
 		m.coordinator.Send(multiplayer.LeaveMatchMsg{
 			SessionID: m.sessionID,
 			MatchID:   m.lobby.MatchID(),
 		})
+		saved := m.stopOnlineRecording()
+		m.onlineKeys.stop()
 		m.state = SessionStateMenu
-		m.menu = NewMenuModel(m.store, m.config)
-		return m, m.menu.Init()
+		m.menu = m.newMenu()
+		return m, tea.Batch(m.menu.Init(), saved)
 	}
 
-	// Game input - map keys to input frame and send to coordinator
-	input := core.NewInputFrame()
-	hasInput := false
-	switch key {
-	case "up", "w":
-		input.Set(core.ActionUp)
-		hasInput = true
-	case "down", "s":
-		input.Set(core.ActionDown)
-		hasInput = true
-	}
-
-	if hasInput {
-		m.coordinator.Send(multiplayer.PlayerInputMsg{
-			MatchID: m.lobby.MatchID(),
-			Player:  m.lobby.Side(),
-			Input:   input,
-		})
+	// Quick chat on the number keys not bound to the game
+	if n, err := strconv.Atoi(key); action == core.ActionNone && err == nil && n >= 1 && n <= len(quickChats) {
+		m.sendChat(multiplayer.ChatScopeRoom, quickChats[n-1])
+		return m, nil
+	}
+
+	// Game input - send paddle presses to the coordinator; holds follow
+	// with the next snapshot
+	switch action {
+	case core.ActionUp, core.ActionDown:
+		if m.onlineKeys.press(core.Player1, action, time.Now()) {
+			input := core.NewInputFrame()
+			input.Set(action)
+			m.sendOnlineInput(input)
+		}
 	}
 
 	return m, nil
 }
 
-// View renders the current view.
+// sendHeldKeys tells the coordinator which paddle keys started or stopped
+// being held, since the match keeps held keys between ticks.
+func (m SessionModel) sendHeldKeys() {
+	if input, changed := m.onlineKeys.heldChanges(time.Now()); changed {
+		m.sendOnlineInput(input)
+	}
+}
+
+// sendOnlineInput sends input for the player's side of the online match.
+func (m SessionModel) sendOnlineInput(input core.InputFrame) {
+	m.coordinator.Send(multiplayer.PlayerInputMsg{
+		MatchID: m.lobby.MatchID(),
+		Player:  m.lobby.Side(),
+		Input:   input,
+	})
+}
+
+// View renders the current view, with any admin broadcast over the top line.
 func (m SessionModel) View() string {
 	if m.quitting {
 		return ""
 	}
 
+	view := m.viewState()
+	if m.banner != "" {
+		view = overlayBanner(view, m.banner, m.config.ScreenW)
+	}
+	if m.recording != nil && m.onlineScreen != nil {
+		m.recording.frame(time.Now(), m.onlineScreen, view)
+	}
+	return view
+}
+
+// ViewScreen returns the game screen for the diff renderer while a game is shown,
+// with any admin broadcast drawn over the top line.
+func (m SessionModel) ViewScreen() *core.Screen {
+	if m.quitting {
+		return nil
+	}
+
+	var screen *core.Screen
+	switch m.state {
+	case SessionStateInGame:
+		if m.gameModel != nil {
+			screen = m.gameModel.ViewScreen()
+		}
+	case SessionStateOnlineGame:
+		if m.onlineGame != nil && m.onlineScreen != nil {
+			m.renderOnlineGame()
+			screen = m.onlineScreen
+		}
+	}
+
+	if screen != nil && m.banner != "" {
+		// The game redraws the whole screen every frame, so drawing on it is safe
+		screen.DrawTextStyled(0, 0, bannerText(m.banner, screen.Width()), core.ColorDefault, core.ColorDefault, core.AttrBold|core.AttrReverse)
+	}
+	if screen != nil && screen == m.onlineScreen && m.recording != nil {
+		m.recording.frame(time.Now(), screen, "")
+	}
+	return screen
+}
+
+// viewState renders the current state.
+func (m SessionModel) viewState() string {
 	switch m.state {
 	case SessionStateMenu:
-		return m.menu.View()
+		if m.menu.attract.running || m.coordinator == nil {
+			return m.menu.View()
+		}
+		return overlayChat(m.menu.View(), "ARCADE HALL", m.hallChat, m.typing(), m.config.ScreenW, m.config.ScreenH)
 	case SessionStatePongMode:
 		return m.pongMode.View()
 	case SessionStateBreakoutMode:
@@ -784,27 +1615,74 @@ func (m SessionModel) View() string {
 		return m.snakeMode.View()
 	case SessionStateT2048Mode:
 		return m.t2048Mode.View()
+	case SessionStateRunnerMode:
+		return m.runnerMode.View()
 	case SessionStateOnlineLobby:
+		if _, ok := m.chatScope(); ok {
+			return overlayChat(m.lobby.View(), "LOBBY CHAT", m.roomChat, m.typing(), m.config.ScreenW, m.config.ScreenH)
+		}
 		return m.lobby.View()
 	case SessionStateScoreboard:
 		return m.scoreboard.View()
+	case SessionStateAdmin:
+		return m.adminConsole.View()
 	case SessionStateInGame:
 		if m.gameModel != nil {
 			return m.gameModel.View()
 		}
 	case SessionStateOnlineGame:
 		return m.viewOnlineGame()
+	case SessionStateWatchList:
+		return m.watch.View()
+	case SessionStateSpectating:
+		return m.viewSpectating()
+	case SessionStateSettings:
+		return m.settings.View()
 	}
 
 	return m.menu.View()
 }
 
+// bannerStyle highlights admin broadcasts.
+var bannerStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+
+// bannerText pads a broadcast message to a full-width banner line.
+func bannerText(banner string, width int) string {
+	text := " " + banner + " "
+	if width > 0 {
+		text = centerText(core.TruncateWidth(text, width), width)
+		text = core.PadWidth(text, width)
+	}
+	return text
+}
+
+// overlayBanner replaces the first line of a view with a full-width banner.
+func overlayBanner(view, banner string, width int) string {
+	text := bannerText(banner, width)
+
+	_, rest, found := strings.Cut(view, "\n")
+	if !found {
+		return bannerStyle.Render(text)
+	}
+	return bannerStyle.Render(text) + "\n" + rest
+}
+
+// renderOnlineGame draws the online match from the latest snapshot, with the
+// help over it while it is open.
+func (m SessionModel) renderOnlineGame() {
+	m.onlineGame.Render(m.onlineScreen)
+	drawMatchChat(m.onlineScreen, m.roomChat, m.typing(), time.Now())
+	if m.onlineHelp != nil {
+		m.onlineHelp.render(m.onlineScreen)
+	}
+}
+
 // viewOnlineGame renders the online game view based on latest snapshot.
 func (m SessionModel) viewOnlineGame() string {
 	// Render actual game if available
 	if m.onlineGame != nil && m.onlineScreen != nil {
-		m.onlineGame.Render(m.onlineScreen)
-		return RenderScreen(m.onlineScreen)
+		m.renderOnlineGame()
+		return m.renderer.Render(m.onlineScreen)
 	}
 
 	// Fallback placeholder (should not normally reach here)
@@ -816,22 +1694,72 @@ func (m SessionModel) viewOnlineGame() string {
 	return b.String()
 }
 
-// saveOnlineScreenshot saves a screenshot of the online game view.
-func (m *SessionModel) saveOnlineScreenshot() {
-	// Create screenshots directory
-	dir := filepath.Join(os.Getenv("HOME"), ".arcade", "screenshots")
-	//nolint:errcheck // Best-effort directory creation
-	os.MkdirAll(dir, 0o755)
+// viewSpectating renders the watched match with a spectator footer on the last row.
+func (m SessionModel) viewSpectating() string {
+	view := m.viewOnlineGame()
+	footer := centerText(fmt.Sprintf("SPECTATING %s  |  T: Chat  |  Esc: Back", m.watching.Code), m.config.ScreenW)
+
+	lines := strings.Split(view, "\n")
+	if len(lines) > 1 {
+		lines[len(lines)-1] = footer
+		return strings.Join(lines, "\n")
+	}
+	return view + "\n" + footer
+}
+
+// saveOnlineScreenshot saves a screenshot of the online game for the player
+// to download and shows where it went in the banner.
+func (m *SessionModel) saveOnlineScreenshot() tea.Cmd {
+	if m.onlineGame == nil || m.onlineScreen == nil {
+		return nil
+	}
+	m.onlineGame.Render(m.onlineScreen)
+	msg := m.captures().screenshotMessage(m.onlineGame.ID(), m.onlineScreen)
+	return m.showBanner(msg, noticeTime)
+}
+
+// toggleOnlineRecording stops and saves the recording of the online match,
+// or starts one.
+func (m *SessionModel) toggleOnlineRecording() tea.Cmd {
+	if m.recording != nil {
+		return m.stopOnlineRecording()
+	}
+	return m.startOnlineRecording()
+}
+
+// startOnlineRecording starts recording the online match as the player sees it.
+func (m *SessionModel) startOnlineRecording() tea.Cmd {
+	if m.onlineGame == nil || m.onlineScreen == nil {
+		return nil
+	}
+	r, err := m.captures().startRecording(m.onlineGame.ID(), "", m.onlineScreen.Width(), m.onlineScreen.Height(), m.renderer.Render)
+	if err != nil {
+		return m.showBanner("Recording failed: "+err.Error(), noticeTime)
+	}
+	m.recording = r
+	return m.showBanner("Recording - press Ctrl+R to stop", noticeTime)
+}
 
-	// Generate filename with timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("online_%s_%s.txt", m.username, timestamp)
-	path := filepath.Join(dir, filename)
+// stopOnlineRecording saves the recording of the online match, if any, and
+// shows where it went.
+func (m *SessionModel) stopOnlineRecording() tea.Cmd {
+	if m.recording == nil {
+		return nil
+	}
+	msg := m.recording.finish()
+	m.recording = nil
+	return m.showBanner(msg, bannerDuration)
+}
 
-	// Get current view and save it
-	content := m.viewOnlineGame()
-	//nolint:errcheck // Best-effort save
-	os.WriteFile(path, []byte(content), 0o600)
+// captures returns where the player's screenshots and recordings are saved.
+func (m SessionModel) captures() captureTarget {
+	return captureTarget{
+		store:      m.store,
+		profile:    m.profile,
+		renderer:   m.renderer,
+		remote:     true,
+		recordings: m.maxRecordings,
+	}
 }
 
 // GameModel wraps a game with multiplayer support and back-to-menu capability.
@@ -844,6 +1772,16 @@ type GameModel struct {
 	inputFrame core.MultiInputFrame
 	gameState  core.GameState
 	keyMapper  *KeyMapper
+	bindings   *KeyBindings // The player's key bindings, rebindable from the pause menu
+	keys       *keyInput
+	mouse      *mouseInput
+	profile    string          // Whose settings the pause menu changes; see LoadSettings
+	renderer   *ScreenRenderer // Nil renders for the local terminal
+	captures   captureTarget   // Where screenshots and recordings are saved
+	record     bool            // Record the game from the start
+	loop       *frameLoop
+	pause      *pauseMenu   // Open pause menu, or nil while the game runs
+	help       *helpOverlay // Open help, or nil
 	quitting   bool
 	backToMenu bool
 	scoreSaved bool
@@ -862,21 +1800,48 @@ func NewGameModel(game registry.Game, store *storage.Store, cfg core.RuntimeConf
 		config:     cfg,
 		match:      match,
 		inputFrame: core.NewMultiInputFrame(),
-		keyMapper:  NewKeyMapper(),
+		keyMapper:  currentKeyBindings().gameMapper(game),
+		bindings:   currentKeyBindings(),
+		keys:       newKeyInput(nil),
+		mouse:      newMouseInput(),
+		profile:    LocalUsername(),
+		captures:   localCaptures(store),
+		loop:       newFrameLoop(cfg),
 	}
 }
 
 // Init initializes the game.
 func (m GameModel) Init() tea.Cmd {
 	m.game.Reset(m.config)
-	return tickCmd(m.config.TickRate)
+	m.keys.start()
+	cmd := m.loop.start()
+	if m.record && m.loop.recording == nil {
+		m.loop.toggleRecording(m.startRecording)
+	}
+	return cmd
 }
 
 // Update handles messages.
 func (m GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := m.keys.handle(msg); ok {
+		if player, action, _ := m.keyMapper.MapPlayerKey(key); action != core.ActionNone {
+			m.keys.release(player, action)
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case tea.MouseMsg:
+		if m.pause != nil {
+			return m.handlePause(m.pause.handleMouse(msg, m.screen.Width(), m.screen.Height()))
+		}
+		if m.help != nil {
+			return m, nil
+		}
+		m.mouse.handle(msg)
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.config.ScreenW = msg.Width
 		m.config.ScreenH = msg.Height
@@ -884,9 +1849,10 @@ func (m GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.gameState.GameOver {
 			m.game.Reset(m.config)
 		}
+		m.loop.invalidate()
 		return m, nil
 	case TickMsg:
-		return m.handleTick()
+		return m.handleTick(time.Time(msg))
 	}
 	return m, nil
 }
@@ -894,48 +1860,143 @@ func (m GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // handleKey processes keyboard input.
 func (m GameModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Check for screenshot
-	if msg.String() == "ctrl+s" {
+	if msg.String() == screenshotKey {
 		m.saveScreenshot()
 		return m, nil
 	}
 
-	// Check for quit
-	if m.keyMapper.MapKeyToMultiFrame(msg, &m.inputFrame) {
+	if msg.String() == recordKey {
+		m.loop.toggleRecording(m.startRecording)
+		return m, nil
+	}
+
+	if msg.String() == statsKey {
+		m.loop.toggleStats()
+		return m, nil
+	}
+
+	if m.pause != nil {
+		return m.handlePause(m.pause.handleKey(msg, m.keyMapper))
+	}
+	if m.help != nil {
+		// Any key closes the help
+		m.help = nil
+		m.loop.invalidate()
+		return m, nil
+	}
+	if msg.String() == helpKey {
+		m.openHelp()
+		return m, nil
+	}
+
+	player, action, isQuit := m.keyMapper.MapPlayerKey(msg)
+	switch {
+	case isQuit:
 		m.quitting = true
+		m.keys.stop()
 		return m, tea.Quit
+	case action == core.ActionNone || !m.keys.press(player, action, time.Now()):
+		// Repeats of a held key are picked up by step
+	case action == core.ActionBack && m.gameState.GameOver:
+		// Back to menu (B or Esc when game over)
+		m.backToMenu = true
+		m.keys.stop()
+	case action == core.ActionPause || action == core.ActionBack:
+		m.openPause()
+	default:
+		in := m.inputFrame.Player(player)
+		in.Set(action)
+		m.inputFrame.SetPlayer(player, in)
 	}
 
-	// Check for back to menu (B or Esc when game over or paused)
-	action := m.keyMapper.MapKeyToMenuAction(msg)
-	if action == MenuActionBack && (m.gameState.GameOver || m.gameState.Paused) {
-		m.backToMenu = true
+	return m, nil
+}
+
+// openPause pauses the game and opens the pause menu.
+func (m *GameModel) openPause() {
+	settings := NewSettingsModel(m.store, m.profile, m.renderer, m.screen.Width(), m.screen.Height())
+	settings.keys = m.bindings
+	m.pause = newPauseMenu(m.game.ID(), "Quit to menu", m.loop, settings)
+	m.keys.reset()
+	m.inputFrame.Clear()
+	m.loop.invalidate()
+}
+
+// openHelp pauses the game and shows its help.
+func (m *GameModel) openHelp() {
+	m.help = newHelpOverlay(m.game, m.keyMapper)
+	m.keys.reset()
+	m.inputFrame.Clear()
+	m.loop.invalidate()
+}
+
+// handlePause acts on the result of input to the pause menu.
+func (m GameModel) handlePause(result pauseResult) (tea.Model, tea.Cmd) {
+	switch result {
+	case pauseStay:
+		// Keys may have been rebound on the controls screen
+		m.bindings = m.pause.keys()
+		m.keyMapper = m.bindings.gameMapper(m.game)
 		return m, nil
+	case pauseRestarted:
+		m.restart()
+	case pauseLeave:
+		m.backToMenu = true
+		m.keys.stop()
+	case pauseQuitAll:
+		m.quitting = true
+		m.keys.stop()
+		return m, tea.Quit
 	}
-
+	m.pause = nil
+	m.loop.invalidate()
 	return m, nil
 }
 
-// handleTick processes simulation ticks.
-func (m GameModel) handleTick() (tea.Model, tea.Cmd) {
-	// Check for restart
+// restart starts the game over with a new seed.
+func (m *GameModel) restart() {
+	m.config.Seed = time.Now().UnixNano()
+	m.game.Reset(m.config)
+	m.gameState = m.game.State()
+	m.scoreSaved = false
+	m.inputFrame.Clear()
+}
+
+// handleTick runs the simulation steps that are due and schedules the next tick.
+func (m GameModel) handleTick(now time.Time) (tea.Model, tea.Cmd) {
+	m.loop.advance(now, m.step)
+	return m, m.loop.next()
+}
+
+// step runs one simulation step. Nothing moves while the game is paused
+// or its help is open.
+func (m *GameModel) step() {
+	if m.pause != nil || m.help != nil {
+		return
+	}
+
+	now := time.Now()
 	p1Input := m.inputFrame.Player1Frame()
+	m.keys.apply(core.Player1, &p1Input, now)
+	if m.keyMapper.Mouse() {
+		m.mouse.apply(&p1Input)
+	}
+
+	// Check for restart
 	if p1Input.Has(core.ActionRestart) && m.gameState.GameOver {
-		m.config.Seed = time.Now().UnixNano()
-		m.game.Reset(m.config)
-		m.gameState = m.game.State()
-		m.scoreSaved = false
-		m.inputFrame.Clear()
-		return m, tickCmd(m.config.TickRate)
+		m.restart()
+		return
 	}
 
 	// Note: For VsCPU mode, AI is handled directly in the game (e.g., Pong handles CPU paddle)
 
-	// Run game simulation with Player1 input (single player games use InputFrame)
-	result := m.game.Step(p1Input)
+	// Run game simulation with Player1 input, or both players' when they share the keyboard
+	result := stepPlayers(m.game, &m.inputFrame, p1Input, m.keys, now)
 	m.gameState = result.State
 
-	// Save score on game over
-	if m.gameState.GameOver && !m.scoreSaved && m.gameState.Score > 0 {
+	// Save score on game over; two players have no high score
+	_, twoPlayer := localTwoPlayer(m.game)
+	if m.gameState.GameOver && !m.scoreSaved && m.gameState.Score > 0 && !twoPlayer {
 		if m.store != nil {
 			//nolint:errcheck // Best-effort save
 			m.store.SaveScore(m.game.ID(), m.gameState.Score)
@@ -944,7 +2005,6 @@ func (m GameModel) handleTick() (tea.Model, tea.Cmd) {
 	}
 
 	m.inputFrame.Clear()
-	return m, tickCmd(m.config.TickRate)
 }
 
 // View renders the game.
@@ -953,8 +2013,31 @@ func (m GameModel) View() string {
 		return ""
 	}
 
-	m.game.Render(m.screen)
-	return RenderScreen(m.screen)
+	if m.pause != nil && m.pause.controlsOpen() {
+		return m.pause.view(m.screen.Width())
+	}
+
+	return m.loop.view(m.screen, m.render, m.renderer.Render)
+}
+
+// ViewScreen renders the game for the diff renderer.
+func (m GameModel) ViewScreen() *core.Screen {
+	if m.quitting || (m.pause != nil && m.pause.controlsOpen()) {
+		return nil
+	}
+	return m.loop.viewScreen(m.screen, m.render)
+}
+
+// render draws the game, with the pause menu or the help over it while
+// they are open.
+func (m GameModel) render(dst *core.Screen) {
+	m.game.Render(dst)
+	if m.pause != nil {
+		m.pause.render(dst)
+	}
+	if m.help != nil {
+		m.help.render(dst)
+	}
 }
 
 // IsQuitting returns true if user requested to quit entirely.
@@ -967,22 +2050,20 @@ func (m GameModel) BackToMenu() bool {
 	return m.backToMenu
 }
 
-// saveScreenshot saves the current screen to a file.
-func (m *GameModel) saveScreenshot() {
-	// Render current state
-	m.game.Render(m.screen)
-
-	// Create screenshots directory
-	dir := filepath.Join(os.Getenv("HOME"), ".arcade", "screenshots")
-	//nolint:errcheck // Best-effort directory creation
-	os.MkdirAll(dir, 0o755)
+// startRecording starts recording the game as the player sees it.
+func (m GameModel) startRecording() (*recording, error) {
+	return m.captures.startRecording(m.game.ID(), "", m.screen.Width(), m.screen.Height(), m.renderer.Render)
+}
 
-	// Generate filename with timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("%s_%s.txt", m.game.ID(), timestamp)
-	path := filepath.Join(dir, filename)
+// FinishRecording saves the recording in progress, if any, and returns a
+// message saying where it went.
+func (m GameModel) FinishRecording() string {
+	return m.loop.stopRecording()
+}
 
-	// Save screenshot
-	//nolint:errcheck // Best-effort save
-	os.WriteFile(path, []byte(m.screen.String()), 0o600)
+// saveScreenshot saves the current screen in the player's format and shows
+// where it went.
+func (m *GameModel) saveScreenshot() {
+	m.game.Render(m.screen)
+	m.loop.showNotice(m.captures.screenshotMessage(m.game.ID(), m.screen))
 }
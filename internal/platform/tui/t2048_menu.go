@@ -59,6 +59,8 @@ func (m T2048ModeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -76,6 +78,16 @@ func (m T2048ModeModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m.handleModeSelectKey(action)
 }
 
+func (m T2048ModeModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	var action MenuAction
+	if m.inLevelSelect {
+		m.levelCursor, action = menuMouse(msg, menuShortListRow, len(t2048.LevelNames()), m.levelCursor)
+		return m.handleLevelSelectKey(action)
+	}
+	m.cursor, action = menuMouse(msg, menuListRow, 3, m.cursor) // Campaign, Endless, Select Level
+	return m.handleModeSelectKey(action)
+}
+
 func (m T2048ModeModel) handleModeSelectKey(action MenuAction) (tea.Model, tea.Cmd) {
 	switch action {
 	case MenuActionQuit:
@@ -239,6 +251,7 @@ func RunT2048ModeSelector(cfg core.RuntimeConfig) (*T2048Selection, core.Runtime
 	p := tea.NewProgram(
 		model,
 		tea.WithAltScreen(),
+		tea.WithMouseAllMotion(),
 	)
 
 	finalModel, err := p.Run()
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vovakirdan/tui-arcade/internal/multiplayer"
+)
+
+// watchRefreshInterval is how often the spectator list reloads active matches.
+const watchRefreshInterval = 2 * time.Second
+
+// watchRefreshMsg triggers a reload of the spectator list.
+type watchRefreshMsg struct{}
+
+// WatchModel lists active online matches that can be spectated.
+type WatchModel struct {
+	coordinator *multiplayer.Coordinator
+	keyMapper   *KeyMapper
+	width       int
+	height      int
+
+	matches  []multiplayer.MatchInfo
+	cursor   int
+	message  string // Error from the last watch attempt
+	selected *multiplayer.MatchInfo
+	back     bool
+	quitting bool
+}
+
+// NewWatchModel creates the spectator list.
+func NewWatchModel(coordinator *multiplayer.Coordinator, width, height int) WatchModel {
+	m := WatchModel{
+		coordinator: coordinator,
+		keyMapper:   NewKeyMapper(),
+		width:       width,
+		height:      height,
+	}
+	m.refresh()
+	return m
+}
+
+// Init starts the periodic refresh.
+func (m WatchModel) Init() tea.Cmd {
+	return watchRefreshCmd()
+}
+
+// watchRefreshCmd schedules the next list refresh.
+func watchRefreshCmd() tea.Cmd {
+	return tea.Tick(watchRefreshInterval, func(time.Time) tea.Msg {
+		return watchRefreshMsg{}
+	})
+}
+
+// refresh reloads the active matches.
+func (m *WatchModel) refresh() {
+	m.matches = m.coordinator.Matches()
+	m.cursor = min(m.cursor, max(0, len(m.matches)-1))
+}
+
+// Update handles messages.
+func (m WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case watchRefreshMsg:
+		m.refresh()
+		return m, watchRefreshCmd()
+	case multiplayer.WatchErrorEvent:
+		m.selected = nil
+		m.message = msg.Message
+		m.refresh()
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m WatchModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMapper.MapKeyToMenuAction(msg) {
+	case MenuActionQuit:
+		m.quitting = true
+		return m, tea.Quit
+	case MenuActionBack:
+		m.back = true
+	case MenuActionUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case MenuActionDown:
+		if m.cursor < len(m.matches)-1 {
+			m.cursor++
+		}
+	case MenuActionSelect:
+		if len(m.matches) > 0 {
+			selected := m.matches[m.cursor]
+			m.selected = &selected
+			m.message = ""
+		}
+	}
+
+	if msg.String() == "r" {
+		m.refresh()
+	}
+	return m, nil
+}
+
+// View renders the spectator list.
+func (m WatchModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(centerText("LIVE MATCHES", m.width))
+	b.WriteString("\n\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(centerText("No matches are being played right now.", m.width))
+		b.WriteString("\n")
+	}
+
+	for i, info := range m.matches {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%-6s %-6s %s vs %s  (%d watching)",
+			cursor, info.Code, info.GameID, sessionUser(info.Player1), sessionUser(info.Player2), info.Spectators)
+		b.WriteString(centerText(line, m.width))
+		b.WriteString("\n")
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		b.WriteString(centerText(fmt.Sprintf("Error: %s", m.message), m.width))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(centerText("Up/Down: Navigate  |  Enter: Watch  |  R: Refresh  |  Esc: Menu  |  Q: Quit", m.width))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// Selected returns the match the user chose to watch, or nil.
+func (m WatchModel) Selected() *multiplayer.MatchInfo {
+	return m.selected
+}
+
+// WantsBack returns true if the user wants to go back to the menu.
+func (m WatchModel) WantsBack() bool {
+	return m.back
+}
+
+// IsQuitting returns true if the user wants to quit entirely.
+func (m WatchModel) IsQuitting() bool {
+	return m.quitting
+}
+
+// sessionUser extracts the username from an SSH session ID ("user-timestamp").
+func sessionUser(id multiplayer.SessionID) string {
+	s := string(id)
+	if i := strings.LastIndex(s, "-"); i > 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -40,6 +40,74 @@ type Game interface {
 	State() core.GameState
 }
 
+// TwoPlayerGame is a game two players can play at one keyboard. The
+// platform splits the keyboard between them and steps the game with
+// StepMulti while TwoPlayer is true.
+type TwoPlayerGame interface {
+	Game
+
+	// SetTwoPlayer makes Player2 a second player at the keyboard instead of
+	// the computer. The platform calls it before the first Reset.
+	SetTwoPlayer(on bool)
+
+	// TwoPlayer reports whether two players share the keyboard.
+	TwoPlayer() bool
+
+	// StepMulti advances the simulation by one tick with input from both players.
+	StepMulti(in core.MultiInputFrame) core.StepResult
+}
+
+// HelpGame is a game that explains how it is played. The platform shows
+// its help in an overlay, the same locally and over SSH.
+type HelpGame interface {
+	Game
+
+	// Help returns the game's objective, controls and legend.
+	Help() Help
+}
+
+// Help describes how a game is played.
+type Help struct {
+	Objective string        // What the player tries to do
+	Controls  []HelpControl // What the controls do, in display order
+	Legend    []HelpSymbol  // What the symbols on screen mean
+}
+
+// HelpControl is one control of a game. The platform shows the keys bound to
+// Action, so rebinding is reflected; Keys names controls that aren't a
+// bindable action, like the mouse.
+type HelpControl struct {
+	Action core.Action
+	Keys   string // Used when Action is ActionNone
+	Text   string
+}
+
+// HelpSymbol explains a glyph a game draws.
+type HelpSymbol struct {
+	Glyph rune
+	Color core.Color
+	Text  string
+}
+
+// DemoGame is a game that can play itself. The menu's attract mode shows
+// its demos when nobody is playing.
+type DemoGame interface {
+	Game
+
+	// Autopilot returns the input a built-in player gives in the next step.
+	Autopilot() core.InputFrame
+}
+
+// DifficultyGame is a game with difficulty presets. The platform sets each
+// game's preset itself, so players sharing a server can pick their own.
+type DifficultyGame interface {
+	Game
+
+	// SetDifficulty selects a preset by name ("easy", "normal", "hard" or
+	// "fixed"), taking effect on the next Reset. Empty keeps the config's.
+	SetDifficulty(preset string)
+}
+
 // GameInfo contains metadata about a registered game.
 type GameInfo struct {
 	ID    string
@@ -52,6 +120,8 @@ type Factory func() Game
 var (
 	factories = make(map[string]Factory)
 	titles    = make(map[string]string)
+	helps     = make(map[string]Help)
+	demos     = make(map[string]bool)
 	mu        sync.RWMutex
 )
 
@@ -68,9 +138,15 @@ func Register(id string, f Factory) {
 
 	factories[id] = f
 
-	// Get title by creating a temporary instance
+	// Get title, help and demo support by creating a temporary instance
 	g := f()
 	titles[id] = g.Title()
+	if hg, ok := g.(HelpGame); ok {
+		helps[id] = hg.Help()
+	}
+	if _, ok := g.(DemoGame); ok {
+		demos[id] = true
+	}
 }
 
 // List returns information about all registered games, sorted by ID.
@@ -115,3 +191,39 @@ func Exists(id string) bool {
 	_, ok := factories[id]
 	return ok
 }
+
+// Title returns the display name of a registered game, or its ID if the
+// game is not registered.
+func Title(id string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if title, ok := titles[id]; ok {
+		return title
+	}
+	return id
+}
+
+// HelpFor returns the help of a registered game, and false if the game is
+// not registered or doesn't describe itself.
+func HelpFor(id string) (Help, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	help, ok := helps[id]
+	return help, ok
+}
+
+// Demos returns the IDs of the registered games that can play themselves,
+// sorted.
+func Demos() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	ids := make([]string, 0, len(demos))
+	for id := range demos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
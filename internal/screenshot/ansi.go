@@ -0,0 +1,84 @@
+package screenshot
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// ANSI writes a screen as text with SGR escape sequences, viewable with cat
+// or less -R. Colors are down-sampled to opts.Profile.
+func ANSI(w io.Writer, s *core.Screen, opts Options) error {
+	var buf []byte
+	for _, row := range spans(s, opts) {
+		styled := false
+		for _, sp := range row {
+			fg, bg := sp.style.fg.Downsample(opts.Profile), sp.style.bg.Downsample(opts.Profile)
+			plain := fg.IsDefault() && bg.IsDefault() && sp.style.attrs == core.AttrNone
+			if !plain || styled {
+				buf = AppendSGR(buf, fg, bg, sp.style.attrs)
+				styled = !plain
+			}
+			buf = append(buf, sp.text...)
+		}
+		if styled {
+			buf = append(buf, "\x1b[0m"...)
+		}
+		buf = append(buf, '\n')
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// AppendSGR appends the SGR escape sequence that selects a style from scratch:
+// a reset followed by the attributes and colors. The colors must already be
+// down-sampled (see core.Color.Downsample).
+func AppendSGR(seq []byte, fg, bg core.Color, attrs core.Attr) []byte {
+	seq = append(seq, "\x1b[0"...)
+	if attrs.Has(core.AttrBold) {
+		seq = append(seq, ";1"...)
+	}
+	if attrs.Has(core.AttrDim) {
+		seq = append(seq, ";2"...)
+	}
+	if attrs.Has(core.AttrUnderline) {
+		seq = append(seq, ";4"...)
+	}
+	if attrs.Has(core.AttrReverse) {
+		seq = append(seq, ";7"...)
+	}
+	seq = appendSGRColor(seq, fg, 30, 90, 38)
+	seq = appendSGRColor(seq, bg, 40, 100, 48)
+	return append(seq, 'm')
+}
+
+// appendSGRColor appends the SGR parameters for a down-sampled color.
+// base and bright are the parameters for the 8 basic and 8 bright colors;
+// extended introduces a 256-color or RGB value.
+func appendSGRColor(seq []byte, c core.Color, base, bright, extended int) []byte {
+	if c.IsDefault() {
+		return seq
+	}
+	seq = append(seq, ';')
+	if index, ok := c.Index(); ok {
+		switch {
+		case index < 8:
+			return strconv.AppendInt(seq, int64(base)+int64(index), 10)
+		case index < 16:
+			return strconv.AppendInt(seq, int64(bright)+int64(index-8), 10)
+		default:
+			seq = strconv.AppendInt(seq, int64(extended), 10)
+			seq = append(seq, ";5;"...)
+			return strconv.AppendInt(seq, int64(index), 10)
+		}
+	}
+	r, g, b := c.RGBValues()
+	seq = strconv.AppendInt(seq, int64(extended), 10)
+	seq = append(seq, ";2;"...)
+	seq = strconv.AppendInt(seq, int64(r), 10)
+	seq = append(seq, ';')
+	seq = strconv.AppendInt(seq, int64(g), 10)
+	seq = append(seq, ';')
+	return strconv.AppendInt(seq, int64(b), 10)
+}
@@ -0,0 +1,110 @@
+package screenshot
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// Colors of cells that use the terminal's defaults, as a dark terminal shows them.
+const (
+	defaultForeground = "#d0d0d0"
+	defaultBackground = "#101010"
+)
+
+// defaultTitle is used when Options.Title is empty.
+const defaultTitle = "TUI Arcade screenshot"
+
+// HTML writes a screen as a self-contained web page: a <pre> block of styled
+// spans with no external stylesheets, scripts or fonts.
+func HTML(w io.Writer, s *core.Screen, opts Options) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { margin: 0; background: %s; }
+pre { margin: 0; padding: 1em; color: %s; font: 15px/1.2 "DejaVu Sans Mono", Menlo, Consolas, monospace; }
+</style>
+</head>
+<body>
+<pre>`, html.EscapeString(title(opts)), defaultBackground, defaultForeground)
+
+	for y, row := range spans(s, opts) {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		for _, sp := range row {
+			text := html.EscapeString(sp.text)
+			if css := spanCSS(sp.style); css != "" {
+				fmt.Fprintf(&b, `<span style="%s">%s</span>`, css, text)
+			} else {
+				b.WriteString(text)
+			}
+		}
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// spanCSS returns the inline CSS for a style, or "" for the default look.
+func spanCSS(st style) string {
+	fg, bg := hexColors(st)
+	var decls []string
+	if fg != defaultForeground {
+		decls = append(decls, "color:"+fg)
+	}
+	if bg != "" {
+		decls = append(decls, "background:"+bg)
+	}
+	if st.attrs.Has(core.AttrBold) {
+		decls = append(decls, "font-weight:bold")
+	}
+	if st.attrs.Has(core.AttrDim) {
+		decls = append(decls, "opacity:0.6")
+	}
+	if st.attrs.Has(core.AttrUnderline) {
+		decls = append(decls, "text-decoration:underline")
+	}
+	return strings.Join(decls, ";")
+}
+
+// hexColors returns the text color and the background color of a style as
+// hex values, with reverse video applied. bg is empty for the default background.
+func hexColors(st style) (fg, bg string) {
+	fg = defaultForeground
+	if !st.fg.IsDefault() {
+		fg = hexColor(st.fg)
+	}
+	if !st.bg.IsDefault() {
+		bg = hexColor(st.bg)
+	}
+	if st.attrs.Has(core.AttrReverse) {
+		if bg == "" {
+			bg = defaultBackground
+		}
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+// hexColor formats a concrete color as #rrggbb.
+func hexColor(c core.Color) string {
+	r, g, b := c.RGBValues()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// title returns the page or image title.
+func title(opts Options) string {
+	if opts.Title != "" {
+		return opts.Title
+	}
+	return defaultTitle
+}
@@ -0,0 +1,154 @@
+// Package screenshot exports screen buffers as files: plain text, colored
+// ANSI text, self-contained HTML pages and SVG images.
+package screenshot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// Format is a screenshot file format.
+type Format string
+
+// Screenshot formats.
+const (
+	FormatText Format = "text" // Plain characters, no colors
+	FormatANSI Format = "ansi" // Colored text for terminals (cat, less -R)
+	FormatHTML Format = "html" // Self-contained web page
+	FormatSVG  Format = "svg"  // Vector image
+)
+
+// DefaultFormat is used when no format is configured.
+const DefaultFormat = FormatANSI
+
+// Formats returns all formats in the order settings list them.
+func Formats() []Format {
+	return []Format{FormatANSI, FormatHTML, FormatSVG, FormatText}
+}
+
+// ParseFormat parses a format name. The empty string selects DefaultFormat.
+func ParseFormat(name string) (Format, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultFormat, nil
+	}
+	if name == "txt" {
+		return FormatText, nil
+	}
+	for _, f := range Formats() {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown screenshot format %q (use ansi, html, svg or text)", name)
+}
+
+// Extension returns the file extension for the format, with the dot.
+func (f Format) Extension() string {
+	switch f {
+	case FormatANSI:
+		return ".ans"
+	case FormatHTML:
+		return ".html"
+	case FormatSVG:
+		return ".svg"
+	default:
+		return ".txt"
+	}
+}
+
+// Options control how a screen is exported.
+type Options struct {
+	Theme *core.Theme // Resolves color roles; nil uses the default theme
+
+	// Profile limits the colors of ANSI output to what a terminal supports.
+	// The zero value is monochrome, so callers usually set ProfileTrueColor
+	// or the profile of the terminal the screenshot was taken on.
+	Profile core.ColorProfile
+
+	Title string // Title of HTML pages and SVG images
+}
+
+// Encode writes a screen in the given format.
+func Encode(w io.Writer, s *core.Screen, f Format, opts Options) error {
+	switch f {
+	case FormatText:
+		_, err := io.WriteString(w, s.String()+"\n")
+		return err
+	case FormatANSI:
+		return ANSI(w, s, opts)
+	case FormatHTML:
+		return HTML(w, s, opts)
+	case FormatSVG:
+		return SVG(w, s, opts)
+	default:
+		return fmt.Errorf("unknown screenshot format %q", f)
+	}
+}
+
+// Bytes returns a screen encoded in the given format.
+func Bytes(s *core.Screen, f Format, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, s, f, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// style is the resolved look of a cell: concrete colors and attributes.
+type style struct {
+	fg, bg core.Color
+	attrs  core.Attr
+}
+
+// span is a run of cells in one row that share a style.
+type span struct {
+	x     int // First column
+	width int // Columns covered, counting both halves of wide characters
+	text  string
+	style style
+}
+
+// spans splits each row of a screen into runs of same-styled cells.
+// Colors are resolved with the theme; a monochrome theme drops them.
+func spans(s *core.Screen, opts Options) [][]span {
+	rows := make([][]span, s.Height())
+	var text strings.Builder
+
+	for y := range s.Height() {
+		x := 0
+		for x < s.Width() {
+			start := x
+			st := resolve(s.GetCell(x, y), opts.Theme)
+			text.Reset()
+			for x < s.Width() {
+				cell := s.GetCell(x, y)
+				if !cell.IsContinuation() {
+					if resolve(cell, opts.Theme) != st {
+						break
+					}
+					text.WriteString(cell.Text())
+				}
+				x++
+			}
+			rows[y] = append(rows[y], span{x: start, width: x - start, text: text.String(), style: st})
+		}
+	}
+	return rows
+}
+
+// resolve returns the concrete style of a cell.
+func resolve(cell core.Cell, theme *core.Theme) style {
+	if theme != nil && theme.Monochrome {
+		return style{attrs: cell.Attrs}
+	}
+	return style{
+		fg:    theme.Resolve(cell.Color),
+		bg:    theme.Resolve(cell.Background),
+		attrs: cell.Attrs,
+	}
+}
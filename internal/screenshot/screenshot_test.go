@@ -0,0 +1,182 @@
+package screenshot
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// testScreen returns a small screen with colors, attributes and a wide character.
+func testScreen() *core.Screen {
+	s := core.NewScreen(6, 2)
+	s.DrawTextWithColor(0, 0, "ab", core.ColorRed)
+	s.DrawTextStyled(3, 0, "<&", core.RolePlayer, core.ColorBlue, core.AttrBold)
+	s.DrawText(0, 1, "猫 x")
+	return s
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Format
+		wantErr  bool
+	}{
+		{"", DefaultFormat, false},
+		{"ansi", FormatANSI, false},
+		{" HTML ", FormatHTML, false},
+		{"svg", FormatSVG, false},
+		{"txt", FormatText, false},
+		{"png", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.expected {
+			t.Errorf("ParseFormat(%q) = %q, %v, expected %q (error %v)", tt.input, got, err, tt.expected, tt.wantErr)
+		}
+	}
+
+	for _, f := range Formats() {
+		if f.Extension() == "" {
+			t.Errorf("%s has no extension", f)
+		}
+	}
+}
+
+func TestSpans(t *testing.T) {
+	rows := spans(testScreen(), Options{})
+
+	if len(rows[0]) != 4 {
+		t.Fatalf("row 0 has %d spans, expected 4: %+v", len(rows[0]), rows[0])
+	}
+	if sp := rows[0][0]; sp.text != "ab" || sp.style.fg != core.ColorRed {
+		t.Errorf("first span = %+v, expected red \"ab\"", sp)
+	}
+	if sp := rows[0][2]; sp.x != 3 || sp.style.fg != core.ColorYellow {
+		t.Errorf("player span = %+v, expected the role resolved to yellow at x=3", sp)
+	}
+
+	// The wide character covers two columns but is one character of text
+	if sp := rows[1][0]; sp.text != "猫 x  " || sp.width != 6 {
+		t.Errorf("wide row span = %q covering %d columns, expected 6", sp.text, sp.width)
+	}
+
+	mono := core.NewTheme("mono")
+	mono.Monochrome = true
+	for _, sp := range spans(testScreen(), Options{Theme: mono})[0] {
+		if !sp.style.fg.IsDefault() || !sp.style.bg.IsDefault() {
+			t.Errorf("monochrome span %+v should have no colors", sp)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	out, err := Bytes(testScreen(), FormatText, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(out); got != "ab <& \n猫 x  \n" {
+		t.Errorf("text = %q", got)
+	}
+}
+
+func TestANSI(t *testing.T) {
+	out, err := Bytes(testScreen(), FormatANSI, Options{Profile: core.ProfileTrueColor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(out), "\n")
+
+	expected := "\x1b[0;31mab\x1b[0m \x1b[0;1;33;44m<&\x1b[0m "
+	if lines[0] != expected {
+		t.Errorf("row 0 = %q, expected %q", lines[0], expected)
+	}
+	if lines[1] != "猫 x  " {
+		t.Errorf("plain row = %q, expected no escapes", lines[1])
+	}
+
+	// Monochrome output keeps attributes but drops colors
+	out, _ = Bytes(testScreen(), FormatANSI, Options{Profile: core.ProfileMono})
+	if strings.Contains(string(out), ";31") || !strings.Contains(string(out), "\x1b[0;1m<&") {
+		t.Errorf("mono output = %q", out)
+	}
+}
+
+func TestAppendSGR(t *testing.T) {
+	tests := []struct {
+		fg, bg   core.Color
+		attrs    core.Attr
+		expected string
+	}{
+		{core.ColorDefault, core.ColorDefault, core.AttrNone, "\x1b[0m"},
+		{core.Color256(9), core.Color256(2), core.AttrUnderline, "\x1b[0;4;91;42m"},
+		{core.Color256(208), core.ColorDefault, core.AttrReverse, "\x1b[0;7;38;5;208m"},
+		{core.RGB(1, 2, 3), core.RGB(4, 5, 6), core.AttrDim, "\x1b[0;2;38;2;1;2;3;48;2;4;5;6m"},
+	}
+	for _, tt := range tests {
+		if got := string(AppendSGR(nil, tt.fg, tt.bg, tt.attrs)); got != tt.expected {
+			t.Errorf("AppendSGR(%v, %v, %v) = %q, expected %q", tt.fg, tt.bg, tt.attrs, got, tt.expected)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	out, err := Bytes(testScreen(), FormatHTML, Options{Title: "Snake <1>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(out)
+
+	for _, want := range []string{
+		"<title>Snake &lt;1&gt;</title>",
+		`<span style="color:#cd0000">ab</span>`,
+		`<span style="color:#cdcd00;background:#0000ee;font-weight:bold">&lt;&amp;</span>`,
+		"猫 x  </pre>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page is missing %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "<script") || strings.Contains(page, "<link") {
+		t.Error("page should be self-contained")
+	}
+}
+
+func TestHexColorsReverse(t *testing.T) {
+	fg, bg := hexColors(style{attrs: core.AttrReverse})
+	if fg != defaultBackground || bg != defaultForeground {
+		t.Errorf("reverse default colors = %s on %s, expected swapped defaults", fg, bg)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	out, err := Bytes(testScreen(), FormatSVG, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The image must be well-formed XML
+	dec := xml.NewDecoder(strings.NewReader(string(out)))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if err != io.EOF {
+				t.Fatalf("invalid XML: %v\n%s", err, out)
+			}
+			break
+		}
+	}
+
+	image := string(out)
+	for _, want := range []string{
+		`width="54" height="36"`,
+		`<rect x="27" y="0" width="18" height="18" fill="#0000ee"/>`,
+		`<text x="27" y="14" textLength="18" lengthAdjust="spacingAndGlyphs" fill="#cdcd00" font-weight="bold">&lt;&amp;</text>`,
+		`<text x="0" y="32" textLength="54"`,
+	} {
+		if !strings.Contains(image, want) {
+			t.Errorf("image is missing %q:\n%s", want, image)
+		}
+	}
+}
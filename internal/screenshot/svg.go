@@ -0,0 +1,67 @@
+package screenshot
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/vovakirdan/tui-arcade/internal/core"
+)
+
+// SVG cell geometry, in pixels.
+const (
+	svgCellWidth  = 9
+	svgCellHeight = 18
+	svgBaseline   = 14 // Text baseline below the top of a row
+	svgFontSize   = 15
+)
+
+// SVG writes a screen as a vector image. Each run of text is stretched to its
+// exact column width, so the grid lines up whatever monospace font the viewer has.
+func SVG(w io.Writer, s *core.Screen, opts Options) error {
+	width, height := s.Width()*svgCellWidth, s.Height()*svgCellHeight
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" xml:space="preserve">
+<title>%s</title>
+<rect width="100%%" height="100%%" fill="%s"/>
+<g font-family="DejaVu Sans Mono, Menlo, Consolas, monospace" font-size="%d">
+`, width, height, width, height, html.EscapeString(title(opts)), defaultBackground, svgFontSize)
+
+	for y, row := range spans(s, opts) {
+		top := y * svgCellHeight
+		for _, sp := range row {
+			x, spanWidth := sp.x*svgCellWidth, sp.width*svgCellWidth
+			fg, bg := hexColors(sp.style)
+			if bg != "" {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					x, top, spanWidth, svgCellHeight, bg)
+			}
+			if strings.TrimSpace(sp.text) == "" {
+				continue
+			}
+			fmt.Fprintf(&b, `<text x="%d" y="%d" textLength="%d" lengthAdjust="spacingAndGlyphs" fill="%s"%s>%s</text>`+"\n",
+				x, top+svgBaseline, spanWidth, fg, svgTextAttrs(sp.style.attrs), html.EscapeString(sp.text))
+		}
+	}
+	b.WriteString("</g>\n</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// svgTextAttrs returns the SVG attributes for text attributes, with a leading space.
+func svgTextAttrs(attrs core.Attr) string {
+	var b strings.Builder
+	if attrs.Has(core.AttrBold) {
+		b.WriteString(` font-weight="bold"`)
+	}
+	if attrs.Has(core.AttrDim) {
+		b.WriteString(` opacity="0.6"`)
+	}
+	if attrs.Has(core.AttrUnderline) {
+		b.WriteString(` text-decoration="underline"`)
+	}
+	return b.String()
+}
@@ -42,6 +42,38 @@ type OnlineMatchResult struct {
 	CreatedAt      time.Time
 }
 
+// AdminAction represents one entry in the admin audit log.
+type AdminAction struct {
+	ID        int64
+	Admin     string // Username and key fingerprint of the admin who acted
+	Action    string // e.g. "kick", "end_match", "broadcast", "clear_scores"
+	Target    string // Session ID, match ID or game ID the action applied to
+	Details   string // Free-form context (e.g. the broadcast message)
+	CreatedAt time.Time
+}
+
+// Screenshot is a screenshot saved for a player, e.g. one taken over SSH.
+type Screenshot struct {
+	ID        int64
+	Username  string // Owner: the SSH player's key fingerprint
+	GameID    string
+	Format    string // Screenshot format name (ansi, html, svg, text)
+	Content   []byte // Empty when listed with Screenshots
+	Size      int    // Length of Content in bytes
+	CreatedAt time.Time
+}
+
+// Recording is an asciicast recording saved for a player, e.g. one made over SSH.
+type Recording struct {
+	ID        int64
+	Username  string // Owner: the SSH player's key fingerprint
+	GameID    string
+	Duration  time.Duration
+	Content   []byte // Empty when listed with Recordings
+	Size      int    // Length of Content in bytes
+	CreatedAt time.Time
+}
+
 // Open creates or opens a SQLite database at the given path.
 // It creates the parent directories if needed and runs migrations.
 func Open(dbPath string) (*Store, error) {
@@ -111,12 +143,57 @@ func (s *Store) migrate() error {
 		CREATE INDEX IF NOT EXISTS idx_online_matches_game_id ON online_matches(game_id);
 		CREATE INDEX IF NOT EXISTS idx_online_matches_player1 ON online_matches(player1_session);
 		CREATE INDEX IF NOT EXISTS idx_online_matches_player2 ON online_matches(player2_session);
+
+		CREATE TABLE IF NOT EXISTS admin_audit (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			admin TEXT NOT NULL,
+			action TEXT NOT NULL,
+			target TEXT NOT NULL DEFAULT '',
+			details TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS user_settings (
+			username TEXT NOT NULL,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (username, key)
+		);
+
+		CREATE TABLE IF NOT EXISTS screenshots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			username TEXT NOT NULL,
+			game_id TEXT NOT NULL,
+			format TEXT NOT NULL,
+			content BLOB NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_screenshots_username ON screenshots(username, id DESC);
+
+		CREATE TABLE IF NOT EXISTS recordings (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			username TEXT NOT NULL,
+			game_id TEXT NOT NULL,
+			duration_ms INTEGER NOT NULL,
+			content BLOB NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_recordings_username ON recordings(username, id DESC);
 	`
 
 	_, err := s.db.Exec(schema)
 	return err
 }
 
+// Ping checks that the database is still reachable.
+func (s *Store) Ping() error {
+	if err := s.db.Ping(); err != nil {
+		return fmt.Errorf("storage: database unreachable: %w", err)
+	}
+	return nil
+}
+
 // Close closes the database connection.
 func (s *Store) Close() error {
 	if s.db != nil {
@@ -172,15 +249,7 @@ func (s *Store) TopScores(gameID string, limit int) ([]ScoreEntry, error) {
 			return nil, fmt.Errorf("storage: cannot scan row: %w", err)
 		}
 
-		// Parse the datetime - handle both time.Time and string
-		switch v := createdAt.(type) {
-		case time.Time:
-			e.CreatedAt = v
-		case string:
-			if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
-				e.CreatedAt = parsed
-			}
-		}
+		e.CreatedAt = parseTimestamp(createdAt)
 		entries = append(entries, e)
 	}
 
@@ -213,15 +282,7 @@ func (s *Store) AllScores(gameID string) ([]ScoreEntry, error) {
 			return nil, fmt.Errorf("storage: cannot scan row: %w", err)
 		}
 
-		// Parse the datetime - handle both time.Time and string
-		switch v := createdAt.(type) {
-		case time.Time:
-			e.CreatedAt = v
-		case string:
-			if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
-				e.CreatedAt = parsed
-			}
-		}
+		e.CreatedAt = parseTimestamp(createdAt)
 		entries = append(entries, e)
 	}
 
@@ -323,15 +384,7 @@ func (s *Store) OnlineMatchByID(matchID string) (*OnlineMatchResult, error) {
 		result.WinnerSession = winnerSession.String
 	}
 
-	// Parse the datetime
-	switch v := createdAt.(type) {
-	case time.Time:
-		result.CreatedAt = v
-	case string:
-		if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
-			result.CreatedAt = parsed
-		}
-	}
+	result.CreatedAt = parseTimestamp(createdAt)
 
 	return &result, nil
 }
@@ -381,15 +434,7 @@ func (s *Store) RecentOnlineMatches(limit int) ([]OnlineMatchResult, error) {
 			result.WinnerSession = winnerSession.String
 		}
 
-		// Parse the datetime
-		switch v := createdAt.(type) {
-		case time.Time:
-			result.CreatedAt = v
-		case string:
-			if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
-				result.CreatedAt = parsed
-			}
-		}
+		result.CreatedAt = parseTimestamp(createdAt)
 
 		results = append(results, result)
 	}
@@ -447,15 +492,7 @@ func (s *Store) PlayerMatchHistory(sessionID string, limit int) ([]OnlineMatchRe
 			result.WinnerSession = winnerSession.String
 		}
 
-		// Parse the datetime
-		switch v := createdAt.(type) {
-		case time.Time:
-			result.CreatedAt = v
-		case string:
-			if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
-				result.CreatedAt = parsed
-			}
-		}
+		result.CreatedAt = parseTimestamp(createdAt)
 
 		results = append(results, result)
 	}
@@ -522,14 +559,7 @@ func (s *Store) GetGameStats(gameID string) (*GameStats, error) {
 		return nil, fmt.Errorf("storage: cannot get last played: %w", err)
 	}
 	if err == nil {
-		switch v := lastPlayed.(type) {
-		case time.Time:
-			stats.LastPlayed = v
-		case string:
-			if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
-				stats.LastPlayed = parsed
-			}
-		}
+		stats.LastPlayed = parseTimestamp(lastPlayed)
 	}
 
 	return stats, nil
@@ -555,17 +585,289 @@ func (s *Store) GetAllGamesStats() (map[string]*GameStats, error) {
 			return nil, fmt.Errorf("storage: cannot scan stats row: %w", err)
 		}
 
-		switch v := lastPlayed.(type) {
-		case time.Time:
-			s.LastPlayed = v
-		case string:
-			if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
-				s.LastPlayed = parsed
-			}
-		}
+		s.LastPlayed = parseTimestamp(lastPlayed)
 
 		stats[s.GameID] = &s
 	}
 
 	return stats, nil
 }
+
+// LogAdminAction records an admin action in the audit log.
+func (s *Store) LogAdminAction(admin, action, target, details string) error {
+	_, err := s.db.Exec(
+		"INSERT INTO admin_audit (admin, action, target, details) VALUES (?, ?, ?, ?)",
+		admin, action, target, details,
+	)
+	if err != nil {
+		return fmt.Errorf("storage: cannot log admin action: %w", err)
+	}
+	return nil
+}
+
+// RecentAdminActions retrieves the most recent admin actions, newest first.
+func (s *Store) RecentAdminActions(limit int) ([]AdminAction, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := s.db.Query(
+		`SELECT id, admin, action, target, details, created_at
+		 FROM admin_audit
+		 ORDER BY id DESC
+		 LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("storage: cannot query admin audit: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []AdminAction
+	for rows.Next() {
+		var a AdminAction
+		var createdAt any
+
+		if err := rows.Scan(&a.ID, &a.Admin, &a.Action, &a.Target, &a.Details, &createdAt); err != nil {
+			return nil, fmt.Errorf("storage: cannot scan row: %w", err)
+		}
+
+		a.CreatedAt = parseTimestamp(createdAt)
+
+		actions = append(actions, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("storage: row iteration error: %w", err)
+	}
+
+	return actions, nil
+}
+
+// GetUserSetting retrieves a user's setting. ok is false if it was never set.
+func (s *Store) GetUserSetting(username, key string) (value string, ok bool, err error) {
+	err = s.db.QueryRow(
+		"SELECT value FROM user_settings WHERE username = ? AND key = ?",
+		username, key,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("storage: cannot get user setting: %w", err)
+	}
+	return value, true, nil
+}
+
+// SetUserSetting stores a user's setting, replacing any previous value.
+func (s *Store) SetUserSetting(username, key, value string) error {
+	_, err := s.db.Exec(
+		`INSERT INTO user_settings (username, key, value) VALUES (?, ?, ?)
+		 ON CONFLICT(username, key) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP`,
+		username, key, value,
+	)
+	if err != nil {
+		return fmt.Errorf("storage: cannot set user setting: %w", err)
+	}
+	return nil
+}
+
+// SaveScreenshot stores a player's screenshot and deletes their oldest ones
+// beyond keep. Returns the new screenshot's ID.
+func (s *Store) SaveScreenshot(shot Screenshot, keep int) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO screenshots (username, game_id, format, content) VALUES (?, ?, ?, ?)",
+		shot.Username, shot.GameID, shot.Format, shot.Content,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("storage: cannot save screenshot: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("storage: cannot get screenshot ID: %w", err)
+	}
+
+	if keep > 0 {
+		_, err = s.db.Exec(
+			`DELETE FROM screenshots
+			 WHERE username = ? AND id NOT IN (
+			     SELECT id FROM screenshots WHERE username = ? ORDER BY id DESC LIMIT ?
+			 )`,
+			shot.Username, shot.Username, keep,
+		)
+		if err != nil {
+			return id, fmt.Errorf("storage: cannot prune screenshots: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
+// Screenshots lists a player's screenshots without their content, newest first.
+// username is the owner the screenshots were saved under.
+func (s *Store) Screenshots(username string) ([]Screenshot, error) {
+	rows, err := s.db.Query(
+		`SELECT id, username, game_id, format, length(content), created_at
+		 FROM screenshots
+		 WHERE username = ?
+		 ORDER BY id DESC`,
+		username,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("storage: cannot query screenshots: %w", err)
+	}
+	defer rows.Close()
+
+	var shots []Screenshot
+	for rows.Next() {
+		var shot Screenshot
+		var createdAt any
+
+		if err := rows.Scan(&shot.ID, &shot.Username, &shot.GameID, &shot.Format, &shot.Size, &createdAt); err != nil {
+			return nil, fmt.Errorf("storage: cannot scan row: %w", err)
+		}
+		shot.CreatedAt = parseTimestamp(createdAt)
+		shots = append(shots, shot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("storage: row iteration error: %w", err)
+	}
+
+	return shots, nil
+}
+
+// ScreenshotByID retrieves one of a player's screenshots with its content.
+// Returns nil if the player has no screenshot with that ID.
+func (s *Store) ScreenshotByID(username string, id int64) (*Screenshot, error) {
+	var shot Screenshot
+	var createdAt any
+
+	err := s.db.QueryRow(
+		`SELECT id, username, game_id, format, content, created_at
+		 FROM screenshots
+		 WHERE username = ? AND id = ?`,
+		username, id,
+	).Scan(&shot.ID, &shot.Username, &shot.GameID, &shot.Format, &shot.Content, &createdAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: cannot query screenshot: %w", err)
+	}
+
+	shot.Size = len(shot.Content)
+	shot.CreatedAt = parseTimestamp(createdAt)
+	return &shot, nil
+}
+
+// SaveRecording stores a player's recording and deletes their oldest ones
+// beyond keep. Returns the new recording's ID.
+func (s *Store) SaveRecording(rec Recording, keep int) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO recordings (username, game_id, duration_ms, content) VALUES (?, ?, ?, ?)",
+		rec.Username, rec.GameID, rec.Duration.Milliseconds(), rec.Content,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("storage: cannot save recording: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("storage: cannot get recording ID: %w", err)
+	}
+
+	if keep > 0 {
+		_, err = s.db.Exec(
+			`DELETE FROM recordings
+			 WHERE username = ? AND id NOT IN (
+			     SELECT id FROM recordings WHERE username = ? ORDER BY id DESC LIMIT ?
+			 )`,
+			rec.Username, rec.Username, keep,
+		)
+		if err != nil {
+			return id, fmt.Errorf("storage: cannot prune recordings: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
+// Recordings lists a player's recordings without their content, newest first.
+// username is the owner the recordings were saved under.
+func (s *Store) Recordings(username string) ([]Recording, error) {
+	rows, err := s.db.Query(
+		`SELECT id, username, game_id, duration_ms, length(content), created_at
+		 FROM recordings
+		 WHERE username = ?
+		 ORDER BY id DESC`,
+		username,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("storage: cannot query recordings: %w", err)
+	}
+	defer rows.Close()
+
+	var recs []Recording
+	for rows.Next() {
+		var rec Recording
+		var durationMs int64
+		var createdAt any
+
+		if err := rows.Scan(&rec.ID, &rec.Username, &rec.GameID, &durationMs, &rec.Size, &createdAt); err != nil {
+			return nil, fmt.Errorf("storage: cannot scan row: %w", err)
+		}
+		rec.Duration = time.Duration(durationMs) * time.Millisecond
+		rec.CreatedAt = parseTimestamp(createdAt)
+		recs = append(recs, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("storage: row iteration error: %w", err)
+	}
+
+	return recs, nil
+}
+
+// RecordingByID retrieves one of a player's recordings with its content.
+// Returns nil if the player has no recording with that ID.
+func (s *Store) RecordingByID(username string, id int64) (*Recording, error) {
+	var rec Recording
+	var durationMs int64
+	var createdAt any
+
+	err := s.db.QueryRow(
+		`SELECT id, username, game_id, duration_ms, content, created_at
+		 FROM recordings
+		 WHERE username = ? AND id = ?`,
+		username, id,
+	).Scan(&rec.ID, &rec.Username, &rec.GameID, &durationMs, &rec.Content, &createdAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: cannot query recording: %w", err)
+	}
+
+	rec.Duration = time.Duration(durationMs) * time.Millisecond
+	rec.Size = len(rec.Content)
+	rec.CreatedAt = parseTimestamp(createdAt)
+	return &rec, nil
+}
+
+// parseTimestamp converts a scanned DATETIME column, a time or a string
+// depending on the driver, to a time. Returns the zero time if it can't.
+func parseTimestamp(v any) time.Time {
+	switch v := v.(type) {
+	case time.Time:
+		return v
+	case string:
+		if parsed, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestStoreOpenClose(t *testing.T) {
@@ -225,3 +226,191 @@ func TestStoreExpandHomePath(t *testing.T) {
 		t.Error("Database file was not created in nested directory")
 	}
 }
+
+func TestStoreAdminAudit(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.LogAdminAction("alice", "clear_scores", "flappy", ""); err != nil {
+		t.Fatalf("LogAdminAction() failed: %v", err)
+	}
+	if err := store.LogAdminAction("alice", "broadcast", "", "Server restarting"); err != nil {
+		t.Fatalf("LogAdminAction() failed: %v", err)
+	}
+
+	actions, err := store.RecentAdminActions(10)
+	if err != nil {
+		t.Fatalf("RecentAdminActions() failed: %v", err)
+	}
+
+	if len(actions) != 2 {
+		t.Fatalf("Expected 2 actions, got %d", len(actions))
+	}
+
+	// Newest first
+	if actions[0].Action != "broadcast" || actions[0].Details != "Server restarting" {
+		t.Errorf("Unexpected newest action: %+v", actions[0])
+	}
+	if actions[1].Action != "clear_scores" || actions[1].Target != "flappy" {
+		t.Errorf("Unexpected oldest action: %+v", actions[1])
+	}
+}
+
+func TestStoreUserSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer store.Close()
+
+	if _, ok, err := store.GetUserSetting("alice", "theme"); err != nil || ok {
+		t.Fatalf("GetUserSetting() on empty store = (ok=%v, err=%v), expected not found", ok, err)
+	}
+
+	if err := store.SetUserSetting("alice", "theme", "solarized"); err != nil {
+		t.Fatalf("SetUserSetting() failed: %v", err)
+	}
+	if err := store.SetUserSetting("alice", "theme", "mono"); err != nil {
+		t.Fatalf("SetUserSetting() overwrite failed: %v", err)
+	}
+	if err := store.SetUserSetting("bob", "theme", "classic"); err != nil {
+		t.Fatalf("SetUserSetting() failed: %v", err)
+	}
+
+	value, ok, err := store.GetUserSetting("alice", "theme")
+	if err != nil || !ok {
+		t.Fatalf("GetUserSetting() = (ok=%v, err=%v), expected a value", ok, err)
+	}
+	if value != "mono" {
+		t.Errorf("Expected the latest value 'mono', got %q", value)
+	}
+
+	value, _, err = store.GetUserSetting("bob", "theme")
+	if err != nil {
+		t.Fatalf("GetUserSetting() failed: %v", err)
+	}
+	if value != "classic" {
+		t.Errorf("Settings should be per user, got %q for bob", value)
+	}
+}
+
+func TestStoreScreenshots(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer store.Close()
+
+	// Keep the newest two per user
+	var ids []int64
+	for _, content := range []string{"one", "two", "three"} {
+		id, err := store.SaveScreenshot(Screenshot{Username: "alice", GameID: "snake", Format: "html", Content: []byte(content)}, 2)
+		if err != nil {
+			t.Fatalf("SaveScreenshot() failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := store.SaveScreenshot(Screenshot{Username: "bob", GameID: "pong", Format: "svg", Content: []byte("b")}, 2); err != nil {
+		t.Fatalf("SaveScreenshot() failed: %v", err)
+	}
+
+	shots, err := store.Screenshots("alice")
+	if err != nil {
+		t.Fatalf("Screenshots() failed: %v", err)
+	}
+	if len(shots) != 2 {
+		t.Fatalf("Expected 2 screenshots after pruning, got %d", len(shots))
+	}
+	if shots[0].ID != ids[2] || shots[1].ID != ids[1] {
+		t.Errorf("Expected newest first (%d, %d), got (%d, %d)", ids[2], ids[1], shots[0].ID, shots[1].ID)
+	}
+	if shots[0].Size != len("three") || shots[0].Content != nil {
+		t.Errorf("Listed screenshot should have size %d and no content, got %d and %q", len("three"), shots[0].Size, shots[0].Content)
+	}
+	if shots[0].CreatedAt.IsZero() {
+		t.Error("CreatedAt should be set")
+	}
+
+	shot, err := store.ScreenshotByID("alice", ids[2])
+	if err != nil || shot == nil {
+		t.Fatalf("ScreenshotByID() = %v, %v, expected a screenshot", shot, err)
+	}
+	if string(shot.Content) != "three" || shot.Format != "html" || shot.GameID != "snake" {
+		t.Errorf("Unexpected screenshot %+v", shot)
+	}
+
+	// Pruned screenshots and other users' screenshots are not found
+	for _, tc := range []struct {
+		user string
+		id   int64
+	}{{"alice", ids[0]}, {"bob", ids[2]}} {
+		shot, err := store.ScreenshotByID(tc.user, tc.id)
+		if err != nil || shot != nil {
+			t.Errorf("ScreenshotByID(%q, %d) = %v, %v, expected nil", tc.user, tc.id, shot, err)
+		}
+	}
+}
+
+func TestStoreRecordings(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer store.Close()
+
+	// Keep the newest two per user
+	var ids []int64
+	for i, content := range []string{"one", "two", "three"} {
+		rec := Recording{Username: "alice", GameID: "dino", Duration: time.Duration(i+1) * time.Second, Content: []byte(content)}
+		id, err := store.SaveRecording(rec, 2)
+		if err != nil {
+			t.Fatalf("SaveRecording() failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	recs, err := store.Recordings("alice")
+	if err != nil {
+		t.Fatalf("Recordings() failed: %v", err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("Expected 2 recordings after pruning, got %d", len(recs))
+	}
+	if recs[0].ID != ids[2] || recs[0].Duration != 3*time.Second || recs[0].Size != len("three") || recs[0].Content != nil {
+		t.Errorf("Unexpected newest recording %+v", recs[0])
+	}
+
+	rec, err := store.RecordingByID("alice", ids[1])
+	if err != nil || rec == nil {
+		t.Fatalf("RecordingByID() = %v, %v, expected a recording", rec, err)
+	}
+	if string(rec.Content) != "two" || rec.Duration != 2*time.Second || rec.CreatedAt.IsZero() {
+		t.Errorf("Unexpected recording %+v", rec)
+	}
+
+	// Pruned recordings and other users' recordings are not found
+	for _, tc := range []struct {
+		user string
+		id   int64
+	}{{"alice", ids[0]}, {"bob", ids[2]}} {
+		rec, err := store.RecordingByID(tc.user, tc.id)
+		if err != nil || rec != nil {
+			t.Errorf("RecordingByID(%q, %d) = %v, %v, expected nil", tc.user, tc.id, rec, err)
+		}
+	}
+}